@@ -0,0 +1,125 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common
+
+import (
+	"math/big"
+)
+
+// IsBailliePSWPrime runs the Baillie-PSW primality test on `n`: a strong Miller-Rabin test to base 2 followed by a
+// strong Lucas probable prime test with parameters chosen by Selfridge's method A.
+// No composite number is known to pass this combination of tests.
+// Unlike big.Int.ProbablyPrime, the test is implemented explicitly here so that it can be audited on its own.
+func IsBailliePSWPrime(n *big.Int) bool {
+	if n == nil || n.Sign() <= 0 {
+		return false
+	}
+	if n.IsInt64() && n.Int64() < 64 {
+		switch n.Int64() {
+		case 2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47, 53, 59, 61:
+			return true
+		}
+		return false
+	}
+	if n.Bit(0) == 0 {
+		return false
+	}
+	for _, prime := range smallPrimes {
+		if new(big.Int).Mod(n, big.NewInt(int64(prime))).Sign() == 0 {
+			return false
+		}
+	}
+	return isStrongProbablePrimeBase2(n) && isStrongLucasProbablePrime(n)
+}
+
+// isStrongProbablePrimeBase2 is the Miller-Rabin test to base 2 for an odd n > 2
+func isStrongProbablePrimeBase2(n *big.Int) bool {
+	nm1 := new(big.Int).Sub(n, one)
+	s := nm1.TrailingZeroBits()
+	d := new(big.Int).Rsh(nm1, s)
+	x := new(big.Int).Exp(two, d, n)
+	if x.Cmp(one) == 0 || x.Cmp(nm1) == 0 {
+		return true
+	}
+	for r := uint(1); r < s; r++ {
+		x.Mul(x, x).Mod(x, n)
+		if x.Cmp(nm1) == 0 {
+			return true
+		}
+		if x.Cmp(one) == 0 {
+			return false
+		}
+	}
+	return false
+}
+
+// isStrongLucasProbablePrime is the strong Lucas test for an odd n > 2 that is not divisible by a small prime.
+// The parameters are P = 1 and Q = (1 - D) / 4, where D is the first of 5, -7, 9, -11, ... with Jacobi(D, n) = -1.
+func isStrongLucasProbablePrime(n *big.Int) bool {
+	// a perfect square has no D with Jacobi(D, n) = -1
+	if sqrt := new(big.Int).Sqrt(n); new(big.Int).Mul(sqrt, sqrt).Cmp(n) == 0 {
+		return false
+	}
+	D := big.NewInt(5)
+	for {
+		j := big.Jacobi(D, n)
+		if j == -1 {
+			break
+		}
+		if j == 0 && new(big.Int).Abs(D).Cmp(n) != 0 {
+			return false
+		}
+		if D.Sign() > 0 {
+			D.Add(D, two).Neg(D)
+		} else {
+			D.Neg(D).Add(D, two)
+		}
+	}
+	P := big.NewInt(1)
+	Q := new(big.Int).Sub(one, D)
+	Q.Rsh(Q, 2) // (1 - D) is divisible by 4; Rsh rounds towards negative infinity which is exact here
+	modN := ModInt(n)
+	Dn, Qn := new(big.Int).Mod(D, n), new(big.Int).Mod(Q, n)
+
+	// n + 1 = d * 2^s with d odd
+	np1 := new(big.Int).Add(n, one)
+	s := np1.TrailingZeroBits()
+	d := new(big.Int).Rsh(np1, s)
+
+	// halve mod n (n is odd)
+	half := func(x *big.Int) *big.Int {
+		if x.Bit(0) == 1 {
+			x = new(big.Int).Add(x, n)
+		}
+		return new(big.Int).Rsh(x, 1)
+	}
+
+	// binary Lucas chain from the most significant bit of d, starting at k = 1
+	U, V, Qk := big.NewInt(1), new(big.Int).Set(P), new(big.Int).Set(Qn)
+	for i := d.BitLen() - 2; i >= 0; i-- {
+		// k -> 2k
+		U = modN.Mul(U, V)
+		V = modN.Sub(modN.Mul(V, V), modN.Add(Qk, Qk))
+		Qk = modN.Mul(Qk, Qk)
+		if d.Bit(i) == 1 {
+			// k -> k + 1
+			U, V = half(modN.Add(modN.Mul(P, U), V)), half(modN.Add(modN.Mul(Dn, U), modN.Mul(P, V)))
+			Qk = modN.Mul(Qk, Qn)
+		}
+	}
+	if U.Sign() == 0 || V.Sign() == 0 {
+		return true
+	}
+	for r := uint(1); r < s; r++ {
+		V = modN.Sub(modN.Mul(V, V), modN.Add(Qk, Qk))
+		if V.Sign() == 0 {
+			return true
+		}
+		Qk = modN.Mul(Qk, Qk)
+	}
+	return false
+}
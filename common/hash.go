@@ -10,7 +10,10 @@ import (
 	"crypto"
 	_ "crypto/sha512"
 	"encoding/binary"
+	"hash"
 	"math/big"
+
+	"golang.org/x/crypto/sha3"
 )
 
 const (
@@ -20,8 +23,14 @@ const (
 // SHA-512/256 is protected against length extension attacks and is more performant than SHA-256 on 64-bit architectures.
 // https://en.wikipedia.org/wiki/Template:Comparison_of_SHA_functions
 func SHA512_256(in ...[]byte) []byte {
+	return SHA512_256With(crypto.SHA512_256.New(), in...)
+}
+
+// SHA512_256With is SHA512_256 computed with `state`, a SHA-512/256 hash.Hash that it resets first, so that a caller
+// that hashes many inputs can reuse one state. `state` must not be used concurrently.
+func SHA512_256With(state hash.Hash, in ...[]byte) []byte {
 	var data []byte
-	state := crypto.SHA512_256.New()
+	state.Reset()
 	inLen := len(in)
 	if inLen == 0 {
 		return nil
@@ -56,8 +65,19 @@ func SHA512_256(in ...[]byte) []byte {
 }
 
 func SHA512_256i(in ...*big.Int) *big.Int {
+	return hashBigInts(crypto.SHA512_256.New(), "SHA512_256i", in...)
+}
+
+// SHA3_256i is SHA512_256i with SHA3-256, for the integrations that standardize on SHA-3. Like SHA512_256i it hashes
+// tss-lib's own encoding of the inputs: their count, then the bytes of each followed by a delimiter and their length.
+// It is therefore not the SHA3-256 of the concatenated inputs, and only matches implementations of that encoding.
+func SHA3_256i(in ...*big.Int) *big.Int {
+	return hashBigInts(sha3.New256(), "SHA3_256i", in...)
+}
+
+// hashBigInts hashes `in` with `state`, prefixed with their count and each followed by a delimiter and its length
+func hashBigInts(state hash.Hash, name string, in ...*big.Int) *big.Int {
 	var data []byte
-	state := crypto.SHA512_256.New()
 	inLen := len(in)
 	if inLen == 0 {
 		return nil
@@ -87,7 +107,7 @@ func SHA512_256i(in ...*big.Int) *big.Int {
 	// n < len(data) or an error will never happen.
 	// see: https://golang.org/pkg/hash/#Hash and https://github.com/golang/go/wiki/Hashing#the-hashhash-interface
 	if _, err := state.Write(data); err != nil {
-		Logger.Errorf("SHA512_256i Write() failed: %v", err)
+		Logger.Errorf("%s Write() failed: %v", name, err)
 		return nil
 	}
 	return new(big.Int).SetBytes(state.Sum(nil))
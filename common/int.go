@@ -19,6 +19,23 @@ var (
 	two  = big.NewInt(2)
 )
 
+// ZeroBigInts overwrites the words backing each of `xs`, including any spare capacity, and sets it to 0.
+// It is used to wipe secrets from memory once they are no longer needed; the pointers remain valid.
+// Copies made by earlier arithmetic are not reached, so the secrets must not have been shared with other values.
+func ZeroBigInts(xs ...*big.Int) {
+	for _, x := range xs {
+		if x == nil {
+			continue
+		}
+		words := x.Bits()
+		words = words[:cap(words)]
+		for i := range words {
+			words[i] = 0
+		}
+		x.SetInt64(0)
+	}
+}
+
 func ModInt(mod *big.Int) *modInt {
 	return (*modInt)(mod)
 }
@@ -0,0 +1,32 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+func TestZeroBigInts(t *testing.T) {
+	// a reduced product keeps the words of the larger intermediate value in its spare capacity
+	secret := new(big.Int).Mul(common.MustGetRandomInt(rand.Reader, 1024), common.MustGetRandomInt(rand.Reader, 1024))
+	secret.Mod(secret, common.MustGetRandomInt(rand.Reader, 256))
+	backing := secret.Bits()
+	backing = backing[:cap(backing)]
+	assert.Greater(t, len(backing), len(secret.Bits()))
+
+	common.ZeroBigInts(secret, nil)
+	assert.Zero(t, secret.Sign())
+	for _, word := range backing {
+		assert.Zero(t, word)
+	}
+}
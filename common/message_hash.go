@@ -0,0 +1,63 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"fmt"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// MessageHash describes how a raw message is hashed before it is signed.
+type MessageHash struct {
+	Name   string
+	digest func(msg []byte) []byte
+}
+
+var (
+	// HashBitcoin is the double SHA-256 used by Bitcoin transaction signatures.
+	HashBitcoin = MessageHash{"double-SHA256", func(msg []byte) []byte {
+		h := sha256.Sum256(msg)
+		h = sha256.Sum256(h[:])
+		return h[:]
+	}}
+	// HashEthereum is the (legacy, pre-NIST) Keccak-256 used by Ethereum.
+	HashEthereum = MessageHash{"Keccak-256", func(msg []byte) []byte {
+		h := sha3.NewLegacyKeccak256()
+		_, _ = h.Write(msg)
+		return h.Sum(nil)
+	}}
+	// HashNone signs the raw message as is, as EdDSA does; it cannot be used for ECDSA.
+	HashNone = MessageHash{Name: "none"}
+)
+
+// NewMessageHash returns a MessageHash for a hash function of the standard library, which must be linked in.
+func NewMessageHash(hashFunc crypto.Hash) (MessageHash, error) {
+	if !hashFunc.Available() {
+		return MessageHash{}, fmt.Errorf("the hash function %d is not available", hashFunc)
+	}
+	return MessageHash{hashFunc.String(), func(msg []byte) []byte {
+		h := hashFunc.New()
+		_, _ = h.Write(msg)
+		return h.Sum(nil)
+	}}, nil
+}
+
+// IsNone returns true if the message is not hashed.
+func (h MessageHash) IsNone() bool {
+	return h.digest == nil
+}
+
+// Digest hashes `msg`, or returns it unchanged for HashNone.
+func (h MessageHash) Digest(msg []byte) []byte {
+	if h.IsNone() {
+		return msg
+	}
+	return h.digest(msg)
+}
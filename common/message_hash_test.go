@@ -0,0 +1,33 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common_test
+
+import (
+	"crypto"
+	_ "crypto/sha512"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+func TestMessageHash(t *testing.T) {
+	assert.Equal(t, "5df6e0e2761359d30a8275058e299fcc0381534545f55cf43e41983f5d4c9456",
+		hex.EncodeToString(common.HashBitcoin.Digest(nil)))
+	assert.Equal(t, "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+		hex.EncodeToString(common.HashEthereum.Digest(nil)))
+	assert.True(t, common.HashNone.IsNone())
+	assert.Equal(t, []byte("msg"), common.HashNone.Digest([]byte("msg")))
+
+	sha512, err := common.NewMessageHash(crypto.SHA512)
+	assert.NoError(t, err)
+	assert.Len(t, sha512.Digest([]byte("msg")), 64)
+	_, err = common.NewMessageHash(crypto.Hash(0))
+	assert.Error(t, err)
+}
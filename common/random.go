@@ -16,7 +16,8 @@ import (
 )
 
 const (
-	mustGetRandomIntMaxBits = 5000
+	// the largest draws are in facproof, below q^3*N0*NCap: 8960 bits with 4096-bit moduli and a 256-bit curve
+	mustGetRandomIntMaxBits = 10000
 )
 
 // MustGetRandomInt panics if it is unable to gather entropy from `io.Reader` or when `bits` is <= 0
@@ -25,6 +25,15 @@ type (
 		q,
 		p *big.Int // p = 2q + 1
 	}
+
+	// SafePrimeOptions adjusts how GetRandomSafePrimesConcurrent accepts candidate safe primes.
+	SafePrimeOptions struct {
+		// BailliePSW additionally runs the explicit Baillie-PSW test of IsBailliePSWPrime on both `q` and `p`
+		// before a safe prime is accepted. The test only runs on candidates that have already passed the other
+		// checks, so it costs roughly two extra modular exponentiations per accepted prime, and the overall
+		// generation time is dominated by the search as before (typically well under 1% slower for 1024 bits).
+		BailliePSW bool
+	}
 )
 
 func (sgp *GermainSafePrime) Prime() *big.Int {
@@ -124,7 +133,16 @@ var ErrGeneratorCancelled = fmt.Errorf("generator work cancelled")
 // This function generates safe primes of at least 6 `bitLen`. For every
 // generated safe prime, the two most significant bits are always set to `1`
 // - we don't want the generated number to be too small.
-func GetRandomSafePrimesConcurrent(ctx context.Context, bitLen, numPrimes int, concurrency int, rand io.Reader) ([]*GermainSafePrime, error) {
+//
+// Optional SafePrimeOptions may be given, e.g. to require an explicit Baillie-PSW test.
+func GetRandomSafePrimesConcurrent(ctx context.Context, bitLen, numPrimes int, concurrency int, rand io.Reader, optionalOptions ...SafePrimeOptions) ([]*GermainSafePrime, error) {
+	var opts SafePrimeOptions
+	if 0 < len(optionalOptions) {
+		if 1 < len(optionalOptions) {
+			return nil, errors.New("GetRandomSafePrimesConcurrent: expected 0 or 1 item in `optionalOptions`")
+		}
+		opts = optionalOptions[0]
+	}
 	if bitLen < 6 {
 		return nil, errors.New("safe prime size must be at least 6 bits")
 	}
@@ -132,23 +150,29 @@ func GetRandomSafePrimesConcurrent(ctx context.Context, bitLen, numPrimes int, c
 		return nil, errors.New("numPrimes should be > 0")
 	}
 
+	if 1 < concurrency {
+		rand = &lockedReader{r: rand}
+	}
+
 	primeCh := make(chan *GermainSafePrime, concurrency*numPrimes)
 	errCh := make(chan error, concurrency)
 	primes := make([]*GermainSafePrime, 0, numPrimes)
 
 	waitGroup := &sync.WaitGroup{}
-
-	defer close(primeCh)
-	defer close(errCh)
-	defer waitGroup.Wait()
-
 	generatorCtx, cancelGeneratorCtx := context.WithCancel(ctx)
-	defer cancelGeneratorCtx()
+	// the workers select on generatorCtx before every send, so once it is cancelled they all return;
+	// the channels are closed only after that, whichever way the loop below returns
+	defer func() {
+		cancelGeneratorCtx()
+		waitGroup.Wait()
+		close(primeCh)
+		close(errCh)
+	}()
 
 	for i := 0; i < concurrency; i++ {
 		waitGroup.Add(1)
 		runGenPrimeRoutine(
-			generatorCtx, primeCh, errCh, waitGroup, rand, bitLen,
+			generatorCtx, primeCh, errCh, waitGroup, rand, bitLen, opts,
 		)
 	}
 
@@ -168,6 +192,25 @@ func GetRandomSafePrimesConcurrent(ctx context.Context, bitLen, numPrimes int, c
 	}
 }
 
+// GetRandomSafePrimesWithReader is GetRandomSafePrimesConcurrent with the source of randomness given first.
+// The reads from `rand` are serialized, so it does not have to be safe for concurrent use. With a concurrency of 1
+// the primes are fully determined by the bytes read, which allows reproducible generation from a seeded DRBG.
+func GetRandomSafePrimesWithReader(ctx context.Context, rand io.Reader, bitLen, numPrimes, concurrency int) ([]*GermainSafePrime, error) {
+	return GetRandomSafePrimesConcurrent(ctx, bitLen, numPrimes, concurrency, rand)
+}
+
+// lockedReader serializes the reads of the generator goroutines from a shared reader.
+type lockedReader struct {
+	mtx sync.Mutex
+	r   io.Reader
+}
+
+func (lr *lockedReader) Read(p []byte) (int, error) {
+	lr.mtx.Lock()
+	defer lr.mtx.Unlock()
+	return lr.r.Read(p)
+}
+
 // Starts a Goroutine searching for a safe prime of the specified `pBitLen`.
 // If succeeds, writes prime `p` and prime `q` such that `p = 2q+1` to the
 // `primeCh`. Prime `p` has a bit length equal to `pBitLen` and prime `q` has
@@ -195,12 +238,15 @@ func GetRandomSafePrimesConcurrent(ctx context.Context, bitLen, numPrimes int, c
 //     If `p` is coprime to all the elements of `smallPrimes`, go to point 5.
 //  5. At this point, we know `q` is potentially prime, and `p=2q+1` is also
 //     potentially prime. We need to execute a final primality test for `q`.
-//     We apply Miller-Rabin and Baillie-PSW tests. If they succeed, it means
+//     We apply Go's ProbablyPrime, which runs Miller-Rabin and Baillie-PSW tests. If they succeed, it means
 //     that `q` is prime with a very high probability. Knowing `q` is prime,
 //     we use Pocklington's criterion to prove the primality of `p=2q+1`, that
 //     is, we execute Fermat primality test to base 2 checking whether
 //     `2^{p-1} = 1 (mod p)`. It's significantly faster than running full
 //     Miller-Rabin and Baillie-PSW for `p`.
+//     Note that big.Int.ProbablyPrime does include a Baillie-PSW test; when
+//     `opts.BailliePSW` is set, `q` and `p` are also checked by our own
+//     explicit implementation of it (IsBailliePSWPrime).
 //     If `q` and `p` are found to be prime, return them as a result. If not, go
 //     back to the point 1.
 func runGenPrimeRoutine(
@@ -210,6 +256,7 @@ func runGenPrimeRoutine(
 	waitGroup *sync.WaitGroup,
 	rand io.Reader,
 	pBitLen int,
+	opts SafePrimeOptions,
 ) {
 	qBitLen := pBitLen - 1
 	b := uint(qBitLen % 8)
@@ -233,7 +280,10 @@ func runGenPrimeRoutine(
 			default:
 				_, err := io.ReadFull(rand, bytes)
 				if err != nil {
-					errCh <- err
+					select {
+					case errCh <- err:
+					case <-ctx.Done():
+					}
 					return
 				}
 
@@ -309,6 +359,12 @@ func runGenPrimeRoutine(
 					break
 				}
 
+				// the search above may have taken a while; do not start the
+				// expensive tests once cancelled
+				if ctx.Err() != nil {
+					return
+				}
+
 				// There is a tiny possibility that, by adding delta, we caused
 				// the number to be one bit too long. Thus we check BitLen
 				// here.
@@ -316,8 +372,14 @@ func runGenPrimeRoutine(
 					isPocklingtonCriterionSatisfied(p) &&
 					q.BitLen() == qBitLen {
 
-					if sgp := (&GermainSafePrime{p: p, q: q}); sgp.Validate() {
-						primeCh <- &GermainSafePrime{p: p, q: q}
+					if sgp := (&GermainSafePrime{p: p, q: q}); sgp.Validate() &&
+						(!opts.BailliePSW || (IsBailliePSWPrime(q) && IsBailliePSWPrime(p))) {
+						// the caller may have stopped receiving; do not block past cancellation
+						select {
+						case primeCh <- &GermainSafePrime{p: p, q: q}:
+						case <-ctx.Done():
+							return
+						}
 					}
 					p, q = new(big.Int), new(big.Int)
 				}
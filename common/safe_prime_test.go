@@ -9,6 +9,9 @@ package common
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
 	"math/big"
 	"runtime"
 	"testing"
@@ -54,3 +57,120 @@ func TestGetRandomGermainPrimeConcurrent(t *testing.T) {
 		assert.True(t, sgp.Validate())
 	}
 }
+
+func TestIsBailliePSWPrime(t *testing.T) {
+	for i := int64(0); i < 20000; i++ {
+		n := big.NewInt(i)
+		assert.Equal(t, n.ProbablyPrime(20), IsBailliePSWPrime(n), "n = %d", i)
+	}
+	// strong pseudoprimes to base 2, Carmichael numbers and strong Lucas pseudoprimes
+	for _, composite := range []int64{2047, 3277, 4033, 561, 41041, 5459, 5777, 10877, 3215031751} {
+		assert.False(t, IsBailliePSWPrime(big.NewInt(composite)), "n = %d", composite)
+	}
+	// each half of the test on its own is fooled by its own pseudoprimes
+	assert.True(t, isStrongProbablePrimeBase2(big.NewInt(2047)))
+	for _, lucasPseudoprime := range []int64{5459, 5777, 10877} {
+		assert.True(t, isStrongLucasProbablePrime(big.NewInt(lucasPseudoprime)), "n = %d", lucasPseudoprime)
+	}
+	p, err := rand.Prime(rand.Reader, 1024)
+	assert.NoError(t, err)
+	assert.True(t, IsBailliePSWPrime(p))
+	q, err := rand.Prime(rand.Reader, 1024)
+	assert.NoError(t, err)
+	assert.False(t, IsBailliePSWPrime(new(big.Int).Mul(p, q)))
+}
+
+func TestGetRandomGermainPrimeConcurrentBailliePSW(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Minute)
+	defer cancel()
+	sgps, err := GetRandomSafePrimesConcurrent(ctx, 512, 2, runtime.NumCPU(), rand.Reader, SafePrimeOptions{BailliePSW: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(sgps))
+	for _, sgp := range sgps {
+		assert.True(t, sgp.Validate())
+		assert.True(t, IsBailliePSWPrime(sgp.Prime()))
+		assert.True(t, IsBailliePSWPrime(sgp.SafePrime()))
+	}
+}
+
+func TestGetRandomSafePrimesWithReaderDeterministic(t *testing.T) {
+	generate := func(concurrency int) []*GermainSafePrime {
+		sgps, err := GetRandomSafePrimesWithReader(context.Background(), newTestDRBG("seed"), 256, 2, concurrency)
+		assert.NoError(t, err)
+		assert.Len(t, sgps, 2)
+		return sgps
+	}
+	first, second := generate(1), generate(1)
+	for i := range first {
+		assert.True(t, first[i].Validate())
+		assert.Equal(t, 0, first[i].Prime().Cmp(second[i].Prime()), "the same seed should give the same primes")
+		assert.Equal(t, 0, first[i].SafePrime().Cmp(second[i].SafePrime()))
+	}
+
+	// the reader is shared safely between the generator goroutines
+	for _, sgp := range generate(4) {
+		assert.True(t, sgp.Validate())
+	}
+}
+
+func TestGetRandomSafePrimesConcurrentCancelStress(t *testing.T) {
+	iterations := 200
+	if testing.Short() {
+		iterations = 20
+	}
+	for i := 0; i < iterations; i++ {
+		// cancel at various points of the generation, including before it starts and after it is done
+		ctx, cancel := context.WithCancel(context.Background())
+		go func(delay time.Duration) {
+			time.Sleep(delay)
+			cancel()
+		}(time.Duration(i%10) * 100 * time.Microsecond)
+		sgps, err := GetRandomSafePrimesConcurrent(ctx, 128, 4, 8, rand.Reader)
+		if err != nil {
+			assert.Equal(t, ErrGeneratorCancelled, err)
+		} else {
+			assert.Len(t, sgps, 4)
+		}
+		cancel()
+	}
+
+	// every worker fails to read at about the same time
+	for i := 0; i < iterations; i++ {
+		_, err := GetRandomSafePrimesConcurrent(context.Background(), 128, 4, 8, failingReader{})
+		assert.Error(t, err)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("failingReader")
+}
+
+// testDRBG is a deterministic stream of SHA-256(seed || counter) blocks; it is not safe for concurrent use
+type testDRBG struct {
+	seed    []byte
+	counter uint64
+	buf     []byte
+}
+
+func newTestDRBG(seed string) *testDRBG {
+	return &testDRBG{seed: []byte(seed)}
+}
+
+func (d *testDRBG) Read(p []byte) (int, error) {
+	for n := 0; n < len(p); {
+		if len(d.buf) == 0 {
+			block := make([]byte, len(d.seed)+8)
+			copy(block, d.seed)
+			binary.BigEndian.PutUint64(block[len(d.seed):], d.counter)
+			d.counter++
+			sum := sha256.Sum256(block)
+			d.buf = sum[:]
+		}
+		c := copy(p[n:], d.buf)
+		d.buf = d.buf[c:]
+		n += c
+	}
+	return len(p), nil
+}
@@ -0,0 +1,71 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+)
+
+// DER returns the ASN.1 DER encoding of a secp256k1 ECDSA signature, as used in Bitcoin transactions. S is normalized
+// to the lower half of the group order (BIP 62), which signing already does; the encoding is strict (BIP 66).
+func (data *SignatureData) DER() ([]byte, error) {
+	if data == nil || len(data.GetR()) == 0 || len(data.GetR()) > 32 || len(data.GetS()) == 0 || len(data.GetS()) > 32 {
+		return nil, errors.New("DER: the signature is not a secp256k1 ECDSA signature")
+	}
+	var r, s btcec.ModNScalar
+	if overflow := r.SetByteSlice(data.GetR()); overflow || r.IsZero() {
+		return nil, errors.New("DER: R is out of range")
+	}
+	if overflow := s.SetByteSlice(data.GetS()); overflow || s.IsZero() {
+		return nil, errors.New("DER: S is out of range")
+	}
+	return btcecdsa.NewSignature(&r, &s).Serialize(), nil
+}
+
+// CompactRecoverable returns the 65-byte [R || S || V] signature, where V is the recovery id (0 or 1) without the
+// offset of 27 expected by some legacy APIs. It returns nil unless the signature is a 32-byte ECDSA signature with a
+// recovery id.
+func (data *SignatureData) CompactRecoverable() []byte {
+	if data == nil || len(data.GetR()) != 32 || len(data.GetS()) != 32 || len(data.GetSignatureRecovery()) != 1 {
+		return nil
+	}
+	sig := make([]byte, 0, 65)
+	sig = append(sig, data.GetR()...)
+	sig = append(sig, data.GetS()...)
+	return append(sig, data.GetSignatureRecovery()[0])
+}
+
+// EthereumSignature returns the 65-byte [R || S || V] signature used by Ethereum, which is the CompactRecoverable form.
+func (data *SignatureData) EthereumSignature() []byte {
+	return data.CompactRecoverable()
+}
+
+// Ed25519Bytes returns the 64-byte [R || S] signature of RFC 8032, as accepted by crypto/ed25519, libsodium and Solana,
+// where R is the encoded point and S the little-endian scalar. R and S of the signature data are big-endian, so they are
+// reversed here and callers need not convert them. It returns nil unless the signature is an EdDSA signature with a
+// canonical S.
+func (data *SignatureData) Ed25519Bytes() []byte {
+	if data == nil || len(data.GetR()) == 0 || len(data.GetR()) > 32 || len(data.GetS()) == 0 || len(data.GetS()) > 32 {
+		return nil
+	}
+	if new(big.Int).SetBytes(data.GetS()).Cmp(edwards.Edwards().N) >= 0 {
+		return nil
+	}
+	sig := make([]byte, 64)
+	copy(sig[32-len(data.GetR()):32], data.GetR())
+	copy(sig[64-len(data.GetS()):], data.GetS())
+	for i, j := 0, 31; i < j; i, j = i+1, j-1 {
+		sig[i], sig[j] = sig[j], sig[i]
+		sig[32+i], sig[32+j] = sig[32+j], sig[32+i]
+	}
+	return sig
+}
@@ -0,0 +1,100 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+func TestEthereumSignature(t *testing.T) {
+	r, s := bytes.Repeat([]byte{1}, 32), bytes.Repeat([]byte{2}, 32)
+	data := &common.SignatureData{R: r, S: s, SignatureRecovery: []byte{1}}
+	assert.Equal(t, append(append(append([]byte{}, r...), s...), 1), data.EthereumSignature())
+
+	// EdDSA signatures have no recovery id
+	assert.Nil(t, (&common.SignatureData{R: r, S: s}).EthereumSignature())
+	assert.Nil(t, (&common.SignatureData{R: r[1:], S: s, SignatureRecovery: []byte{0}}).EthereumSignature())
+}
+
+func TestSignatureDER(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey()
+	assert.NoError(t, err)
+	hash := sha256.Sum256([]byte("tss-lib"))
+	// SignCompact returns [27 + 4 + V || R || S] for a compressed key
+	compact, err := btcecdsa.SignCompact(privKey, hash[:], true)
+	assert.NoError(t, err)
+	r, s, v := compact[1:33], compact[33:], compact[0]-27-4
+	data := &common.SignatureData{R: r, S: s, SignatureRecovery: []byte{v}}
+
+	der, err := data.DER()
+	if assert.NoError(t, err) {
+		sig, err := btcecdsa.ParseDERSignature(der)
+		if assert.NoError(t, err) {
+			assert.True(t, sig.Verify(hash[:], privKey.PubKey()))
+		}
+	}
+
+	// a high S is normalized
+	highS := new(big.Int).Sub(btcec.S256().N, new(big.Int).SetBytes(s))
+	highDER, err := (&common.SignatureData{R: r, S: highS.Bytes()}).DER()
+	assert.NoError(t, err)
+	assert.Equal(t, der, highDER)
+
+	// the compact form recovers the public key
+	recoverable := data.CompactRecoverable()
+	assert.Len(t, recoverable, 65)
+	pubKey, _, err := btcecdsa.RecoverCompact(append([]byte{recoverable[64] + 27}, recoverable[:64]...), hash[:])
+	if assert.NoError(t, err) {
+		assert.True(t, pubKey.IsEqual(privKey.PubKey()))
+	}
+
+	_, err = (&common.SignatureData{R: r, S: make([]byte, 32)}).DER()
+	assert.Error(t, err)
+	_, err = (&common.SignatureData{R: btcec.S256().N.Bytes(), S: s}).DER()
+	assert.Error(t, err)
+	_, err = (&common.SignatureData{R: append(r, 0), S: s}).DER()
+	assert.Error(t, err)
+}
+
+func TestEd25519Bytes(t *testing.T) {
+	priv := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{7}, ed25519.SeedSize))
+	msg := []byte("tss-lib")
+	sig := ed25519.Sign(priv, msg)
+
+	// signing saves R and S as big-endian integers
+	reversed := func(b []byte) []byte {
+		res := make([]byte, len(b))
+		for i := range b {
+			res[len(b)-1-i] = b[i]
+		}
+		return new(big.Int).SetBytes(res).Bytes()
+	}
+	data := &common.SignatureData{R: reversed(sig[:32]), S: reversed(sig[32:])}
+	assert.Equal(t, sig, data.Ed25519Bytes())
+	assert.True(t, ed25519.Verify(priv.Public().(ed25519.PublicKey), msg, data.Ed25519Bytes()))
+
+	// a leading zero dropped from S is restored
+	short := &common.SignatureData{R: data.R, S: []byte{1}}
+	assert.Equal(t, byte(1), short.Ed25519Bytes()[32])
+	assert.Len(t, short.Ed25519Bytes(), 64)
+
+	// S must be canonical
+	nonCanonical := &common.SignatureData{R: data.R, S: bytes.Repeat([]byte{0xff}, 32)}
+	assert.Nil(t, nonCanonical.Ed25519Bytes())
+	assert.Nil(t, (&common.SignatureData{R: data.R}).Ed25519Bytes())
+	assert.Nil(t, (&common.SignatureData{R: bytes.Repeat([]byte{1}, 33), S: data.S}).Ed25519Bytes())
+}
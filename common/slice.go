@@ -54,11 +54,17 @@ func NonEmptyMultiBytes(bzs [][]byte, expectLen ...int) bool {
 // PadToLengthBytesInPlace pad {0, ...} to the front of src if len(src) < length
 // output length is equal to the parameter length
 func PadToLengthBytesInPlace(src []byte, length int) []byte {
-	oriLen := len(src)
-	if oriLen < length {
-		for i := 0; i < length-oriLen; i++ {
-			src = append([]byte{0}, src...)
-		}
+	return PadBytes(src, length)
+}
+
+// PadBytes returns the big-endian `b` left-padded with zeros to `n` bytes, e.g. to serialize a big.Int of a
+// fixed-width field at its canonical length even when it has leading zero bytes, or is zero.
+// `b` is returned as is if it already has `n` or more bytes.
+func PadBytes(b []byte, n int) []byte {
+	if n <= len(b) {
+		return b
 	}
-	return src
+	padded := make([]byte, n)
+	copy(padded[n-len(b):], b)
+	return padded
 }
@@ -0,0 +1,32 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+func TestPadBytes(t *testing.T) {
+	b := []byte{1, 2}
+	assert.Equal(t, []byte{0, 0, 1, 2}, common.PadBytes(b, 4))
+	assert.Equal(t, []byte{1, 2}, b, "the input should not be modified")
+	assert.Equal(t, b, common.PadBytes(b, 2))
+	assert.Equal(t, b, common.PadBytes(b, 1))
+	assert.Equal(t, []byte{0, 0}, common.PadBytes(nil, 2))
+
+	// a value with a leading zero byte round-trips at its fixed width
+	x := new(big.Int).SetBytes([]byte{0, 0xff, 0x01})
+	padded := common.PadBytes(x.Bytes(), 3)
+	assert.Equal(t, []byte{0, 0xff, 0x01}, padded)
+	assert.Equal(t, 0, x.Cmp(new(big.Int).SetBytes(padded)))
+	assert.Equal(t, []byte{0, 0, 1}, common.PadToLengthBytesInPlace([]byte{1}, 3))
+}
@@ -0,0 +1,90 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common
+
+import (
+	"crypto"
+	"encoding/binary"
+	"hash"
+	"math/big"
+)
+
+type (
+	// Transcript accumulates the public values of a Fiat-Shamir proof and derives its challenges from them.
+	// Proofs and their verifiers must append the same values under the same labels, in the same order.
+	Transcript interface {
+		// Append absorbs `values` under `label`; a nil value is absorbed as 0.
+		Append(label string, values ...*big.Int)
+		// Challenge returns a challenge in [0, q) derived from everything appended so far.
+		Challenge(q *big.Int) *big.Int
+	}
+
+	// taggedTranscript is the transcript that the proofs of this library have always hashed: SHA512_256i_TAGGED over
+	// the values, without the labels.
+	taggedTranscript struct {
+		tag    []byte
+		values []*big.Int
+	}
+
+	// labeledTranscript absorbs the labels and the values into SHA-512/256, each prefixed with its length.
+	labeledTranscript struct {
+		state hash.Hash
+	}
+)
+
+// NewTranscript returns the default transcript, which hashes the appended values with SHA512_256i_TAGGED under `tag`
+// (e.g. the session id). The labels are not absorbed, so that the challenges, and the proofs on the wire, are the same
+// as those of the proofs that hashed their values directly.
+func NewTranscript(tag []byte) Transcript {
+	return &taggedTranscript{tag: tag}
+}
+
+// NewLabeledTranscript returns a transcript for new proofs that separates the domain `domain`, the labels and the
+// values: each is absorbed into SHA-512/256 after its 8-byte length. A challenge is absorbed in turn, so that the
+// challenges of a transcript differ from one another.
+func NewLabeledTranscript(domain string) Transcript {
+	tr := &labeledTranscript{state: crypto.SHA512_256.New()}
+	tr.absorb([]byte(domain))
+	return tr
+}
+
+func (tr *taggedTranscript) Append(_ string, values ...*big.Int) {
+	tr.values = append(tr.values, values...)
+}
+
+func (tr *taggedTranscript) Challenge(q *big.Int) *big.Int {
+	eHash := SHA512_256i_TAGGED(tr.tag, tr.values...)
+	if eHash == nil {
+		return nil
+	}
+	return RejectionSample(q, eHash)
+}
+
+func (tr *labeledTranscript) Append(label string, values ...*big.Int) {
+	for _, v := range values {
+		tr.absorb([]byte(label))
+		if v == nil {
+			v = zero
+		}
+		tr.absorb(v.Bytes())
+	}
+}
+
+func (tr *labeledTranscript) Challenge(q *big.Int) *big.Int {
+	out := tr.state.Sum(nil)
+	tr.absorb([]byte("challenge"))
+	tr.absorb(out)
+	return RejectionSample(q, new(big.Int).SetBytes(out))
+}
+
+func (tr *labeledTranscript) absorb(bz []byte) {
+	var length [8]byte
+	binary.BigEndian.PutUint64(length[:], uint64(len(bz)))
+	// writes to a hash.Hash never fail
+	_, _ = tr.state.Write(length[:])
+	_, _ = tr.state.Write(bz)
+}
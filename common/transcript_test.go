@@ -0,0 +1,51 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+// the order of secp256k1
+var transcriptTestQ, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+
+func TestTranscriptMatchesTaggedHash(t *testing.T) {
+	tag := []byte("session")
+	values := []*big.Int{big.NewInt(1), big.NewInt(2), nil, new(big.Int).Lsh(big.NewInt(1), 300)}
+
+	tr := common.NewTranscript(tag)
+	tr.Append("a", values[0])
+	tr.Append("b", values[1:]...)
+	expected := common.RejectionSample(transcriptTestQ, common.SHA512_256i_TAGGED(tag, values...))
+	assert.Equal(t, expected, tr.Challenge(transcriptTestQ), "the default transcript must keep the challenges of the existing proofs")
+
+	// pinned, as a change would break the verification of the proofs of other versions
+	assert.Equal(t, "7c9cd44585c37d95bc3bb21591dd56547bb8698d4e2cf15258a5feb82a98edb1", tr.Challenge(transcriptTestQ).Text(16))
+	assert.Nil(t, common.NewTranscript(tag).Challenge(transcriptTestQ))
+}
+
+func TestLabeledTranscript(t *testing.T) {
+	newTr := func(domain, label string) common.Transcript {
+		tr := common.NewLabeledTranscript(domain)
+		tr.Append(label, big.NewInt(1), nil)
+		return tr
+	}
+	tr := newTr("domain", "label")
+	e1 := tr.Challenge(transcriptTestQ)
+	assert.Equal(t, "3fdc8294f2314c543c7ffdcca973ae9b2188a20d1c0613d12d0aa8c918e8018f", e1.Text(16))
+	assert.True(t, e1.Cmp(transcriptTestQ) < 0)
+	assert.NotEqual(t, e1, tr.Challenge(transcriptTestQ), "successive challenges should differ")
+
+	assert.Equal(t, e1, newTr("domain", "label").Challenge(transcriptTestQ))
+	assert.NotEqual(t, e1, newTr("domain2", "label").Challenge(transcriptTestQ), "the domain should be separated")
+	assert.NotEqual(t, e1, newTr("domain", "label2").Challenge(transcriptTestQ), "the labels should be separated")
+}
@@ -0,0 +1,95 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package crypto
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// the window size of the base point tables: 2^4-1 points for each of the 64 windows of a 256-bit scalar
+const baseMultWindowBits = 4
+
+// BasePointTable holds the multiples d * 2^(baseMultWindowBits*j) * G of the base point G of a curve for every
+// non-zero window digit d and window j, so that k*G is the sum of one table point per non-zero digit of k,
+// without any doublings.
+type BasePointTable struct {
+	curve  elliptic.Curve
+	points [][]msmElement
+}
+
+var (
+	baseMultTables   = make(map[interface{}]*BasePointTable)
+	baseMultTablesMu sync.Mutex
+)
+
+// BaseMultTable returns the precomputed table of the base point of `curve`, building it on the first call for the
+// curve and caching it for the lifetime of the process. Curves are cached by their registered name, as some
+// constructors such as tss.Edwards() return a new instance on every call. btcec already multiplies the secp256k1 base point with a
+// larger precomputed table, so the table of that curve defers to it. The table only speeds up the multiplication of
+// public scalars, see VarTimeScalarBaseMult.
+func BaseMultTable(curve elliptic.Curve) *BasePointTable {
+	baseMultTablesMu.Lock()
+	defer baseMultTablesMu.Unlock()
+	var key interface{} = curve.Params()
+	if name, ok := tss.GetCurveName(curve); ok {
+		key = name
+	}
+	if table, ok := baseMultTables[key]; ok {
+		return table
+	}
+	table := newBaseMultTable(curve)
+	baseMultTables[key] = table
+	return table
+}
+
+func newBaseMultTable(curve elliptic.Curve) *BasePointTable {
+	params := curve.Params()
+	if params == btcec.S256().Params() {
+		return &BasePointTable{curve: curve}
+	}
+	var base msmElement = &msmAffine{curve, params.Gx, params.Gy}
+	windows := (params.N.BitLen() + baseMultWindowBits - 1) / baseMultWindowBits
+	points := make([][]msmElement, windows)
+	for j := range points {
+		points[j] = make([]msmElement, (1<<baseMultWindowBits)-1)
+		points[j][0] = base
+		for d := 1; d < len(points[j]); d++ {
+			points[j][d] = points[j][d-1].add(base)
+		}
+		base = points[j][len(points[j])-1].add(base)
+	}
+	return &BasePointTable{curve, points}
+}
+
+// VarTimeScalarBaseMult returns the same point as ScalarBaseMult(curve, k), i.e. |k| * G, using the table.
+// It skips the zero windows of k and looks the table up by its digits, so its timing and memory accesses depend on k:
+// it must only be used with public scalars, as when verifying proofs and signatures, never with secrets or nonces.
+func (t *BasePointTable) VarTimeScalarBaseMult(k *big.Int) *ECPoint {
+	if t.points == nil {
+		return ScalarBaseMult(t.curve, k)
+	}
+	// k.Bytes() passed to elliptic.Curve.ScalarBaseMult drops the sign
+	kk := new(big.Int).Mod(new(big.Int).Abs(k), t.curve.Params().N)
+	var acc msmElement
+	for j, row := range t.points {
+		if d := msmWindow(kk, j*baseMultWindowBits, baseMultWindowBits); d > 0 {
+			acc = msmAdd(acc, row[d-1])
+		}
+	}
+	p, err := msmToECPoint(t.curve, acc)
+	if err != nil {
+		// k is a multiple of the order; leave the identity to the curve
+		return ScalarBaseMult(t.curve, k)
+	}
+	return p
+}
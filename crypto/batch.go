@@ -0,0 +1,43 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package crypto
+
+import (
+	"runtime"
+	"sync"
+)
+
+// BatchVerify calls `verify` for the indices 0..n-1 concurrently, running at most `concurrency` calls at a time
+// (runtime.NumCPU() when `concurrency` < 1). It returns the indices for which `verify` returned false in ascending
+// order, so that they can be mapped to the culprits.
+//
+// The batch verifiers of the proof packages are built on it and share its contract: they take the same `concurrency`,
+// return the failed indices of their inputs in the same way, and decide each input as the single-proof Verify does.
+func BatchVerify(n, concurrency int, verify func(i int) bool) []int {
+	if concurrency < 1 {
+		concurrency = runtime.NumCPU()
+	}
+	ok := make([]bool, n)
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() { <-sem; wg.Done() }()
+			ok[i] = verify(i)
+		}(i)
+	}
+	wg.Wait()
+	failed := make([]int, 0)
+	for i, verified := range ok {
+		if !verified {
+			failed = append(failed, i)
+		}
+	}
+	return failed
+}
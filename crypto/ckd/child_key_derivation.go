@@ -11,13 +11,17 @@ import (
 	"crypto/sha512"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"hash"
 	"math/big"
+	"strconv"
+	"strings"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcutil/base58"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
 	"golang.org/x/crypto/ripemd160"
 )
 
@@ -58,6 +62,14 @@ const (
 	MaxSeedBytes = 64 // 512 bits
 )
 
+var (
+	// the HMAC key of the master node, defined in BIP32
+	masterKey = []byte("Bitcoin seed")
+
+	// the version bytes of the mainnet extended public keys (xpub)
+	xpubVersion = []byte{0x04, 0x88, 0xb2, 0x1e}
+)
+
 // Extended public key serialization, defined in BIP32
 func (k *ExtendedKey) String() string {
 	// version(4) || depth(1) || parentFP (4) || childinde(4) || chaincode (32) || key(33) || checksum(4)
@@ -103,26 +115,24 @@ func NewExtendedKeyFromString(key string, curve elliptic.Curve) (*ExtendedKey, e
 	chainCode := payload[13:45]
 	keyData := payload[45:78]
 
-	var pubKey ecdsa.PublicKey
-
-	if c, ok := curve.(*btcec.KoblitzCurve); ok {
+	if keyData[0] != pubKeyCompressed && keyData[0] != pubKeyCompressed|0x1 {
+		return nil, fmt.Errorf("invalid extended key: the public key has the format byte %#x, expected a compressed key", keyData[0])
+	}
+	var px, py *big.Int
+	if _, ok := curve.(*btcec.KoblitzCurve); ok {
 		pk, err := btcec.ParsePubKey(keyData)
 		if err != nil {
-			return nil, err
-		}
-		pubKey = ecdsa.PublicKey{
-			Curve: c,
-			X:     pk.X(),
-			Y:     pk.Y(),
-		}
-	} else {
-		px, py := elliptic.Unmarshal(curve, keyData)
-		pubKey = ecdsa.PublicKey{
-			Curve: curve,
-			X:     px,
-			Y:     py,
+			return nil, fmt.Errorf("invalid extended key: %v", err)
 		}
+		px, py = pk.X(), pk.Y()
+	} else if px, py = elliptic.UnmarshalCompressed(curve, keyData); px == nil {
+		return nil, errors.New("invalid extended key: the public key is not on the curve")
+	}
+	pk, err := crypto.NewECPoint(curve, px, py)
+	if err != nil {
+		return nil, fmt.Errorf("invalid extended key: %v", err)
 	}
+	pubKey := *pk.ToECDSAPubKey()
 
 	return &ExtendedKey{
 		PublicKey:  pubKey,
@@ -134,6 +144,37 @@ func NewExtendedKeyFromString(key string, curve elliptic.Curve) (*ExtendedKey, e
 	}, nil
 }
 
+// NewMaster returns the master extended public key of the `seed`, per BIP32: I = HMAC-SHA512(Key = "Bitcoin seed",
+// Data = seed), the master private key being IL and the chain code IR. The seed must be between MinSeedBytes and
+// MaxSeedBytes long.
+func NewMaster(seed []byte, curve elliptic.Curve) (*ExtendedKey, error) {
+	if len(seed) < MinSeedBytes || len(seed) > MaxSeedBytes {
+		return nil, fmt.Errorf("the seed must be between %d and %d bytes long", MinSeedBytes, MaxSeedBytes)
+	}
+
+	hmac512 := hmac.New(sha512.New, masterKey)
+	hmac512.Write(seed)
+	ilr := hmac512.Sum(nil)
+	il := ilr[:32]
+	chainCode := ilr[32:]
+	ilNum := new(big.Int).SetBytes(il)
+
+	if ilNum.Cmp(curve.Params().N) >= 0 || ilNum.Sign() == 0 {
+		// falling outside of the valid range for curve private keys
+		return nil, errors.New("invalid master key, use another seed")
+	}
+
+	pk := crypto.ScalarBaseMult(curve, ilNum)
+	return &ExtendedKey{
+		PublicKey:  *pk.ToECDSAPubKey(),
+		Depth:      0,
+		ChildIndex: 0,
+		ChainCode:  chainCode,
+		ParentFP:   []byte{0x00, 0x00, 0x00, 0x00},
+		Version:    append([]byte{}, xpubVersion...),
+	}, nil
+}
+
 func doubleHashB(b []byte) []byte {
 	first := sha256.Sum256(b)
 	second := sha256.Sum256(first[:])
@@ -180,6 +221,42 @@ func serializeCompressed(publicKeyX *big.Int, publicKeyY *big.Int) []byte {
 	return paddedAppend(b, 32, publicKeyX.Bytes())
 }
 
+// ParsePath parses a derivation path of slash-separated indices such as "m/44/60/0/0", where the leading "m" is
+// optional. Only non-hardened indices are supported, so hardened markers ("'", "h" or "H") are rejected.
+func ParsePath(path string) ([]uint32, error) {
+	path = strings.TrimSpace(path)
+	if path == "m" || path == "" {
+		return []uint32{}, nil
+	}
+	path = strings.TrimPrefix(path, "m/")
+	components := strings.Split(path, "/")
+	indices := make([]uint32, 0, len(components))
+	for i, component := range components {
+		if strings.HasSuffix(component, "'") || strings.HasSuffix(component, "h") || strings.HasSuffix(component, "H") {
+			return nil, fmt.Errorf("hardened index %q at position %d is not supported, only non-hardened derivation is", component, i)
+		}
+		index, err := strconv.ParseUint(component, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index %q at position %d", component, i)
+		}
+		if index >= HardenedKeyStart {
+			return nil, fmt.Errorf("index %d at position %d is out of the range of non-hardened indices", index, i)
+		}
+		indices = append(indices, uint32(index))
+	}
+	return indices, nil
+}
+
+// DerivePath parses the derivation `path` with ParsePath and derives the child key of `pk` at that path with
+// DeriveChildKeyFromHierarchy.
+func DerivePath(path string, pk *ExtendedKey, mod *big.Int, curve elliptic.Curve) (*big.Int, *ExtendedKey, error) {
+	indices, err := ParsePath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return DeriveChildKeyFromHierarchy(indices, pk, mod, curve)
+}
+
 func DeriveChildKeyFromHierarchy(indicesHierarchy []uint32, pk *ExtendedKey, mod *big.Int, curve elliptic.Curve) (*big.Int, *ExtendedKey, error) {
 	var k = pk
 	var err error
@@ -200,7 +277,11 @@ func DeriveChildKeyFromHierarchy(indicesHierarchy []uint32, pk *ExtendedKey, mod
 
 // DeriveChildKey Derive a child key from the given parent key. The function returns "IL" ("I left"), per BIP-32 spec. It also
 // returns the derived child key.
+// Keys on the Edwards curve are derived with DeriveChildKeyEd25519.
 func DeriveChildKey(index uint32, pk *ExtendedKey, curve elliptic.Curve) (*big.Int, *ExtendedKey, error) {
+	if _, ok := curve.(*edwards.TwistedEdwardsCurve); ok {
+		return DeriveChildKeyEd25519(index, pk)
+	}
 	if index >= HardenedKeyStart {
 		return nil, nil, errors.New("the index must be non-hardened")
 	}
@@ -257,3 +338,65 @@ func DeriveChildKey(index uint32, pk *ExtendedKey, curve elliptic.Curve) (*big.I
 	}
 	return ilNum, childPk, nil
 }
+
+// DeriveChildKeyEd25519 derives a child key from the given ed25519 parent key with non-hardened, additive derivation.
+// Like DeriveChildKey it returns "IL", to be added to the parent's shares as the key derivation delta, and the derived
+// child key. I = HMAC-SHA512(Key = chainCode, Data = key || index), where key is the 32-byte ed25519 encoding of the
+// parent public key, IL is reduced modulo the curve order, and the child key is the parent key plus IL*G.
+//
+// This is the only derivation that the parties can compute without the full private key, so hardened indices are
+// rejected. As with the non-hardened derivation of BIP-32, anyone with the extended public key can derive the child
+// public keys, and a leaked child private key together with the chain code reveals the parent private key.
+// The keys are not those of SLIP-0010, which only defines hardened derivation for ed25519.
+func DeriveChildKeyEd25519(index uint32, pk *ExtendedKey) (*big.Int, *ExtendedKey, error) {
+	if index >= HardenedKeyStart {
+		return nil, nil, fmt.Errorf("ed25519 keys can only be derived with non-hardened indices, but got %d", index)
+	}
+	if pk.Depth == maxDepth {
+		return nil, nil, errors.New("cannot derive key beyond max depth")
+	}
+	curve := edwards.Edwards()
+
+	cryptoPk, err := crypto.NewECPoint(curve, pk.X, pk.Y)
+	if err != nil {
+		common.Logger.Error("error getting pubkey from extendedkey")
+		return nil, nil, err
+	}
+
+	pkPublicKeyBytes := edwards.NewPublicKey(pk.X, pk.Y).Serialize()
+
+	data := make([]byte, 36)
+	copy(data, pkPublicKeyBytes)
+	binary.BigEndian.PutUint32(data[32:], index)
+
+	// I = HMAC-SHA512(Key = chainCode, Data=data)
+	hmac512 := hmac.New(sha512.New, pk.ChainCode)
+	hmac512.Write(data)
+	ilr := hmac512.Sum(nil)
+	il := ilr[:32]
+	childChainCode := ilr[32:]
+	ilNum := new(big.Int).Mod(new(big.Int).SetBytes(il), curve.Params().N)
+
+	if ilNum.Sign() == 0 {
+		err = errors.New("invalid derived key")
+		common.Logger.Error("error deriving child key")
+		return nil, nil, err
+	}
+
+	deltaG := crypto.ScalarBaseMult(curve, ilNum)
+	childCryptoPk, err := cryptoPk.Add(deltaG)
+	if err != nil {
+		common.Logger.Error("error adding delta G to parent key")
+		return nil, nil, err
+	}
+
+	childPk := &ExtendedKey{
+		PublicKey:  *childCryptoPk.ToECDSAPubKey(),
+		Depth:      pk.Depth + 1,
+		ChildIndex: index,
+		ChainCode:  childChainCode,
+		ParentFP:   hash160(pkPublicKeyBytes)[:4],
+		Version:    pk.Version,
+	}
+	return ilNum, childPk, nil
+}
@@ -7,10 +7,21 @@
 package ckd_test
 
 import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
 	"testing"
 
-	. "github.com/bnb-chain/tss-lib/v2/crypto/ckd"
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/ckd"
 )
 
 func TestPublicDerivation(t *testing.T) {
@@ -130,3 +141,193 @@ tests:
 		}
 	}
 }
+
+func TestEd25519Derivation(t *testing.T) {
+	ec := edwards.Edwards()
+	x := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+	pk := crypto.ScalarBaseMult(ec, x)
+	chainCode := make([]byte, 32)
+	_, _ = rand.Read(chainCode)
+	extKey := &ExtendedKey{
+		PublicKey: *pk.ToECDSAPubKey(),
+		ChainCode: chainCode,
+		ParentFP:  []byte{0x00, 0x00, 0x00, 0x00},
+	}
+
+	il, child, err := DeriveChildKeyFromHierarchy([]uint32{44, 501, 0}, extKey, ec.Params().N, ec)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(3), child.Depth)
+
+	// the delta added to the parent private key gives the child private key
+	childX := common.ModInt(ec.Params().N).Add(x, il)
+	want := crypto.ScalarBaseMult(ec, childX)
+	assert.Equal(t, 0, want.X().Cmp(child.X))
+	assert.Equal(t, 0, want.Y().Cmp(child.Y))
+
+	// DeriveChildKey dispatches on the curve
+	il1, child1, err := DeriveChildKey(44, extKey, ec)
+	assert.NoError(t, err)
+	il2, child2, err := DeriveChildKeyEd25519(44, extKey)
+	assert.NoError(t, err)
+	assert.Equal(t, il1, il2)
+	assert.Equal(t, child1.ChainCode, child2.ChainCode)
+
+	_, _, err = DeriveChildKeyEd25519(HardenedKeyStart, extKey)
+	assert.Error(t, err, "hardened derivation must be rejected")
+	_, _, err = DeriveChildKeyFromHierarchy([]uint32{0, HardenedKeyStart + 1}, extKey, ec.Params().N, ec)
+	assert.Error(t, err, "hardened derivation must be rejected")
+}
+
+func TestNewMaster(t *testing.T) {
+	// the seeds and master public keys of the test vectors in [BIP32]
+	tests := []struct {
+		name    string
+		seed    string
+		wantPub string
+	}{
+		{
+			name:    "test vector 1",
+			seed:    "000102030405060708090a0b0c0d0e0f",
+			wantPub: "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8",
+		},
+		{
+			name:    "test vector 2",
+			seed:    "fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542",
+			wantPub: "xpub661MyMwAqRbcFW31YEwpkMuc5THy2PSt5bDMsktWQcFF8syAmRUapSCGu8ED9W6oDMSgv6Zz8idoc4a6mr8BDzTJY47LJhkJ8UB7WEGuduB",
+		},
+		{
+			name:    "test vector 3",
+			seed:    "4b381541583be4423346c643850da4b320e46a87ae3d2a4e6da11eba819cd4acba45d239319ac14f863b8d5ab5a0d0c64d2e8a1e7d1457df2e5a3c51c73235be",
+			wantPub: "xpub661MyMwAqRbcEZVB4dScxMAdx6d4nFc9nvyvH3v4gJL378CSRZiYmhRoP7mBy6gSPSCYk6SzXPTf3ND1cZAceL7SfJ1Z3GC8vBgp2epUt13",
+		},
+	}
+
+	for i, test := range tests {
+		seed, err := hex.DecodeString(test.seed)
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+		master, err := NewMaster(seed, btcec.S256())
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+		assert.Equal(t, uint8(0), master.Depth, test.name)
+		assert.Equal(t, []byte{0, 0, 0, 0}, master.ParentFP, test.name)
+		assert.Equal(t, test.wantPub, master.String(), "test %d (%s)", i, test.name)
+	}
+
+	_, err := NewMaster(make([]byte, MinSeedBytes-1), btcec.S256())
+	assert.Error(t, err, "a short seed must be rejected")
+	_, err = NewMaster(make([]byte, MaxSeedBytes+1), btcec.S256())
+	assert.Error(t, err, "a long seed must be rejected")
+}
+
+func TestParsePath(t *testing.T) {
+	valid := map[string][]uint32{
+		"m":                     {},
+		"m/44/60/0/0":           {44, 60, 0, 0},
+		"44/60/0/0":             {44, 60, 0, 0},
+		"m/0/2147483647/1":      {0, 2147483647, 1},
+		"m/1000000000":          {1000000000},
+		" m/0/1/2/2/1000000000": {0, 1, 2, 2, 1000000000},
+	}
+	for path, want := range valid {
+		indices, err := ParsePath(path)
+		if assert.NoError(t, err, path) {
+			assert.Equal(t, want, indices, path)
+		}
+	}
+
+	for _, path := range []string{"m/", "m/44//0", "m/-1", "m/x", "/44", "m/2147483648", "m/4294967296"} {
+		_, err := ParsePath(path)
+		assert.Error(t, err, path)
+	}
+	for _, path := range []string{"m/44'/60'/0'/0/0", "m/44h/0", "m/0/1H"} {
+		_, err := ParsePath(path)
+		if assert.Error(t, err, path) {
+			assert.Contains(t, err.Error(), "hardened", path)
+		}
+	}
+}
+
+func TestDerivePath(t *testing.T) {
+	master, err := NewExtendedKeyFromString("xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8", btcec.S256())
+	if !assert.NoError(t, err) {
+		return
+	}
+	N := btcec.S256().Params().N
+	il, child, err := DerivePath("m/0/1/2/2", master, N, btcec.S256())
+	if !assert.NoError(t, err) {
+		return
+	}
+	wantIL, wantChild, err := DeriveChildKeyFromHierarchy([]uint32{0, 1, 2, 2}, master, N, btcec.S256())
+	assert.NoError(t, err)
+	assert.Equal(t, wantIL, il)
+	assert.Equal(t, "xpub6FHUhLbYYkgFQiFrDiXRfQFXBB2msCxKTsNyAExi6keFxQ8sHfwpogY3p3s1ePSpUqLNYks5T6a3JqpCGszt4kxbyq7tUoFP5c8KWyiDtPp", child.String())
+	assert.Equal(t, wantChild.String(), child.String())
+
+	_, _, err = DerivePath("m/0'/1", master, N, btcec.S256())
+	assert.Error(t, err)
+}
+
+// reserialize re-encodes the extended key `key` after `modify` has changed its payload, with a valid checksum
+func reserialize(t *testing.T, key string, modify func(payload []byte)) string {
+	decoded := base58.Decode(key)
+	if !assert.Len(t, decoded, 82) {
+		t.FailNow()
+	}
+	payload := decoded[:78]
+	modify(payload)
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	return base58.Encode(append(payload, second[:4]...))
+}
+
+func TestNewExtendedKeyFromStringValidatesKey(t *testing.T) {
+	const master = "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8"
+	_, err := NewExtendedKeyFromString(reserialize(t, master, func([]byte) {}), btcec.S256())
+	assert.NoError(t, err)
+
+	// a corrupted format byte of the public key
+	for _, format := range []byte{0x00, 0x04, 0x05, 0xff} {
+		corrupted := reserialize(t, master, func(payload []byte) { payload[45] = format })
+		_, err = NewExtendedKeyFromString(corrupted, btcec.S256())
+		if assert.Error(t, err, "format byte %#x", format) {
+			assert.Contains(t, err.Error(), "compressed")
+		}
+	}
+
+	// an X coordinate of no point of the curve, as x^3 + a*x + b has no square root
+	for _, c := range []struct {
+		curve elliptic.Curve
+		a     int64
+	}{{btcec.S256(), 0}, {elliptic.P256(), -3}} {
+		params := c.curve.Params()
+		x := big.NewInt(1)
+		for {
+			rhs := new(big.Int).Exp(x, big.NewInt(3), params.P)
+			rhs.Add(rhs, new(big.Int).Mul(big.NewInt(c.a), x))
+			rhs.Add(rhs, params.B).Mod(rhs, params.P)
+			if new(big.Int).ModSqrt(rhs, params.P) == nil {
+				break
+			}
+			x.Add(x, big.NewInt(1))
+		}
+		offCurve := reserialize(t, master, func(payload []byte) { x.FillBytes(payload[46:78]) })
+		_, err = NewExtendedKeyFromString(offCurve, c.curve)
+		assert.Error(t, err, "an off-curve key must be rejected (%s)", params.Name)
+	}
+
+	// a compressed key on another curve than secp256k1 is parsed
+	x := common.GetRandomPositiveInt(rand.Reader, elliptic.P256().Params().N)
+	pk := crypto.ScalarBaseMult(elliptic.P256(), x)
+	p256Key := reserialize(t, master, func(payload []byte) {
+		copy(payload[45:78], elliptic.MarshalCompressed(elliptic.P256(), pk.X(), pk.Y()))
+	})
+	extKey, err := NewExtendedKeyFromString(p256Key, elliptic.P256())
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, pk.X().Cmp(extKey.X))
+		assert.Equal(t, 0, pk.Y().Cmp(extKey.Y))
+		assert.Equal(t, p256Key, extKey.String())
+	}
+}
@@ -27,28 +27,54 @@ type (
 	HashCommitDecommit struct {
 		C HashCommitment
 		D HashDeCommitment
+		// the hash of the commitment; nil is SHA512_256Hasher. it must be the same to commit and to verify
+		Hasher Hasher
 	}
+
+	// Hasher hashes the randomness and the secrets of a commitment into its value
+	Hasher func(in ...*big.Int) *big.Int
 )
 
+// SHA512_256Hasher is the default Hasher of the commitments, common.SHA512_256i
+func SHA512_256Hasher(in ...*big.Int) *big.Int {
+	return common.SHA512_256i(in...)
+}
+
+// SHA3_256Hasher is the Hasher common.SHA3_256i, which hashes the same tss-lib encoding of the inputs as
+// SHA512_256Hasher with SHA3-256
+func SHA3_256Hasher(in ...*big.Int) *big.Int {
+	return common.SHA3_256i(in...)
+}
+
 func NewHashCommitmentWithRandomness(r *big.Int, secrets ...*big.Int) *HashCommitDecommit {
+	return newHashCommitment(nil, r, secrets...)
+}
+
+func NewHashCommitment(rand io.Reader, secrets ...*big.Int) *HashCommitDecommit {
+	r := common.MustGetRandomInt(rand, HashLength) // r
+	return NewHashCommitmentWithRandomness(r, secrets...)
+}
+
+// NewHashCommitmentWithHasher is NewHashCommitment with the hash `hasher`, e.g. SHA3_256Hasher for the integrations
+// that standardize on SHA-3. The returned commitment verifies with its Hasher; to verify one that was received,
+// set the same Hasher on the HashCommitDecommit.
+func NewHashCommitmentWithHasher(hasher Hasher, rand io.Reader, secrets ...*big.Int) *HashCommitDecommit {
+	r := common.MustGetRandomInt(rand, HashLength) // r
+	return newHashCommitment(hasher, r, secrets...)
+}
+
+func newHashCommitment(hasher Hasher, r *big.Int, secrets ...*big.Int) *HashCommitDecommit {
 	parts := make([]*big.Int, len(secrets)+1)
 	parts[0] = r
 	for i := 1; i < len(parts); i++ {
 		parts[i] = secrets[i-1]
 	}
-	hash := common.SHA512_256i(parts...)
-
-	cmt := &HashCommitDecommit{}
-	cmt.C = hash
+	cmt := &HashCommitDecommit{Hasher: hasher}
+	cmt.C = cmt.hasher()(parts...)
 	cmt.D = parts
 	return cmt
 }
 
-func NewHashCommitment(rand io.Reader, secrets ...*big.Int) *HashCommitDecommit {
-	r := common.MustGetRandomInt(rand, HashLength) // r
-	return NewHashCommitmentWithRandomness(r, secrets...)
-}
-
 func NewHashDeCommitmentFromBytes(marshalled [][]byte) HashDeCommitment {
 	return common.MultiBytesToBigInts(marshalled)
 }
@@ -58,8 +84,15 @@ func (cmt *HashCommitDecommit) Verify() bool {
 	if C == nil || D == nil {
 		return false
 	}
-	hash := common.SHA512_256i(D...)
-	return hash.Cmp(C) == 0
+	hash := cmt.hasher()(D...)
+	return hash != nil && hash.Cmp(C) == 0
+}
+
+func (cmt *HashCommitDecommit) hasher() Hasher {
+	if cmt.Hasher == nil {
+		return SHA512_256Hasher
+	}
+	return cmt.Hasher
 }
 
 func (cmt *HashCommitDecommit) DeCommit() (bool, HashDeCommitment) {
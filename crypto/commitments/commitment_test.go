@@ -37,3 +37,27 @@ func TestDeCommit(t *testing.T) {
 
 	assert.NotZero(t, len(secrets), "len(secrets) must be non-zero")
 }
+
+func TestHashCommitmentWithHasher(t *testing.T) {
+	one := big.NewInt(1)
+	zero := big.NewInt(0)
+
+	commitment := NewHashCommitmentWithHasher(SHA3_256Hasher, rand.Reader, zero, one)
+	pass, secrets := commitment.DeCommit()
+	assert.True(t, pass, "must pass")
+	assert.Equal(t, []*big.Int{zero, one}, secrets)
+
+	// a commitment received from another party opens with the same hasher only
+	received := &HashCommitDecommit{C: commitment.C, D: commitment.D, Hasher: SHA3_256Hasher}
+	assert.True(t, received.Verify(), "must pass")
+	received.Hasher = nil
+	assert.False(t, received.Verify(), "must not open with the default hasher")
+
+	defaultCmt := NewHashCommitment(rand.Reader, zero, one)
+	defaultCmt.Hasher = SHA3_256Hasher
+	assert.False(t, defaultCmt.Verify(), "must not open with SHA3-256")
+
+	// the default hasher is SHA-512/256
+	assert.Equal(t, SHA512_256Hasher(one, zero), NewHashCommitmentWithRandomness(one, zero).C)
+	assert.NotEqual(t, SHA3_256Hasher(one, zero), SHA512_256Hasher(one, zero))
+}
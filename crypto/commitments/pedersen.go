@@ -0,0 +1,146 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package commitments
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	pedersenDomain = "tss-lib pedersen generator"
+
+	// bit length of the random weights of BatchVerifyPedersen; a batch with a wrong opening passes with probability 2^-128
+	pedersenBatchWeightBits = 128
+)
+
+type (
+	// PedersenCommitment is a perfectly hiding, computationally binding commitment C = value*G + randomness*H to a
+	// scalar, where H is the generator returned by PedersenH. Commitments to a and b add up to a commitment to a+b.
+	PedersenCommitment struct {
+		C *crypto.ECPoint
+	}
+)
+
+// generators H by registered curve name, or by curve parameters for the curves that are not registered
+var pedersenHs sync.Map
+
+// NewPedersenCommitment commits to `value` with `randomness`, which should be uniform in [1, N) and is the opening of
+// the commitment along with `value`.
+func NewPedersenCommitment(ec elliptic.Curve, value, randomness *big.Int) (*PedersenCommitment, error) {
+	if value == nil || randomness == nil {
+		return nil, errors.New("NewPedersenCommitment: the value and the randomness are required")
+	}
+	H, err := PedersenH(ec)
+	if err != nil {
+		return nil, err
+	}
+	G := crypto.NewECPointNoCurveCheck(ec, ec.Params().Gx, ec.Params().Gy)
+	C, err := crypto.MultiScalarMult([]*crypto.ECPoint{G, H}, []*big.Int{value, randomness})
+	if err != nil {
+		return nil, fmt.Errorf("NewPedersenCommitment: %v", err)
+	}
+	return &PedersenCommitment{C: C}, nil
+}
+
+// NewPedersenCommitmentWithRandomness commits to `value` with randomness drawn from `rand`, which it returns.
+func NewPedersenCommitmentWithRandomness(ec elliptic.Curve, value *big.Int, rand io.Reader) (*PedersenCommitment, *big.Int, error) {
+	randomness := common.GetRandomPositiveInt(rand, ec.Params().N)
+	cmt, err := NewPedersenCommitment(ec, value, randomness)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cmt, randomness, nil
+}
+
+// Verify checks that the commitment opens to `value` with `randomness`.
+func (cmt *PedersenCommitment) Verify(value, randomness *big.Int) bool {
+	if cmt == nil || cmt.C == nil || !cmt.C.ValidateBasic() {
+		return false
+	}
+	expected, err := NewPedersenCommitment(cmt.C.Curve(), value, randomness)
+	return err == nil && expected.C.Equals(cmt.C)
+}
+
+// Add returns the commitment to the sum of the values of `cmt` and `other`, which opens with the sum of their
+// randomness.
+func (cmt *PedersenCommitment) Add(other *PedersenCommitment) (*PedersenCommitment, error) {
+	if cmt == nil || cmt.C == nil || other == nil || other.C == nil {
+		return nil, errors.New("PedersenCommitment.Add: nil commitment")
+	}
+	C, err := cmt.C.Add(other.C)
+	if err != nil {
+		return nil, err
+	}
+	return &PedersenCommitment{C: C}, nil
+}
+
+// BatchVerifyPedersen checks that each commitment opens to the value and randomness at the same index, with a single
+// multi-scalar multiplication over a random linear combination of the commitments. It does not tell which opening is
+// wrong; verify them one by one for that.
+func BatchVerifyPedersen(cmts []*PedersenCommitment, values, randomness []*big.Int, rand io.Reader) bool {
+	if len(cmts) == 0 || len(cmts) != len(values) || len(cmts) != len(randomness) {
+		return false
+	}
+	ec := cmts[0].C.Curve()
+	H, err := PedersenH(ec)
+	if err != nil {
+		return false
+	}
+	modN := common.ModInt(ec.Params().N)
+	points := make([]*crypto.ECPoint, 0, len(cmts)+2)
+	weights := make([]*big.Int, 0, len(cmts)+2)
+	sumValues, sumRandomness := big.NewInt(0), big.NewInt(0)
+	for i, cmt := range cmts {
+		if cmt == nil || cmt.C == nil || !cmt.C.ValidateBasic() || cmt.C.Curve().Params() != ec.Params() ||
+			values[i] == nil || randomness[i] == nil {
+			return false
+		}
+		rho := common.MustGetRandomInt(rand, pedersenBatchWeightBits)
+		points = append(points, cmt.C)
+		weights = append(weights, rho)
+		sumValues = modN.Add(sumValues, modN.Mul(rho, values[i]))
+		sumRandomness = modN.Add(sumRandomness, modN.Mul(rho, randomness[i]))
+	}
+	// sum(rho_i * C_i) - sum(rho_i * v_i) * G - sum(rho_i * r_i) * H must be the point at infinity, which is checked as
+	// sum(rho_i * C_i) == sum(rho_i * v_i) * G + sum(rho_i * r_i) * H
+	lhs, err := crypto.MultiScalarMult(points, weights)
+	if err != nil {
+		return false
+	}
+	G := crypto.NewECPointNoCurveCheck(ec, ec.Params().Gx, ec.Params().Gy)
+	rhs, err := crypto.MultiScalarMult([]*crypto.ECPoint{G, H}, []*big.Int{sumValues, sumRandomness})
+	return err == nil && lhs.Equals(rhs)
+}
+
+// PedersenH returns the second generator of the Pedersen commitments on `ec`. It is derived by hashing G to a point
+// with crypto.HashToCurve, so that nobody knows its discrete logarithm to the base G.
+func PedersenH(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	// cached by name, as some constructors such as tss.Edwards() return a new instance on every call
+	var key interface{} = ec.Params()
+	if name, ok := tss.GetCurveName(ec); ok {
+		key = name
+	}
+	if H, ok := pedersenHs.Load(key); ok {
+		return H.(*crypto.ECPoint), nil
+	}
+	G := crypto.NewECPointNoCurveCheck(ec, ec.Params().Gx, ec.Params().Gy)
+	H, err := crypto.HashToCurve(ec, append([]byte(pedersenDomain), G.Bytes()...))
+	if err != nil {
+		return nil, fmt.Errorf("PedersenH: %v", err)
+	}
+	pedersenHs.Store(key, H)
+	return H, nil
+}
@@ -0,0 +1,84 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package commitments_test
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestPedersenCommitment(t *testing.T) {
+	for _, ec := range []elliptic.Curve{tss.S256(), elliptic.P256(), tss.Edwards()} {
+		N := ec.Params().N
+		a, b := common.GetRandomPositiveInt(rand.Reader, N), common.GetRandomPositiveInt(rand.Reader, N)
+		ca, ra, err := NewPedersenCommitmentWithRandomness(ec, a, rand.Reader)
+		assert.NoError(t, err)
+		cb, rb, err := NewPedersenCommitmentWithRandomness(ec, b, rand.Reader)
+		assert.NoError(t, err)
+		assert.True(t, ca.Verify(a, ra))
+		assert.False(t, ca.Verify(b, ra))
+		assert.False(t, ca.Verify(a, rb))
+
+		// C(a) + C(b) == C(a+b)
+		sum, err := ca.Add(cb)
+		assert.NoError(t, err)
+		modN := common.ModInt(N)
+		cSum, err := NewPedersenCommitment(ec, modN.Add(a, b), modN.Add(ra, rb))
+		assert.NoError(t, err)
+		assert.True(t, sum.C.Equals(cSum.C), "C(a) + C(b) should be C(a+b)")
+		assert.True(t, sum.Verify(modN.Add(a, b), modN.Add(ra, rb)))
+
+		// H is on the curve, and differs from G
+		H, err := PedersenH(ec)
+		assert.NoError(t, err)
+		assert.True(t, H.IsOnCurve())
+		assert.NotEqual(t, 0, H.X().Cmp(ec.Params().Gx))
+	}
+}
+
+func TestPedersenHCache(t *testing.T) {
+	H, err := PedersenH(tss.Edwards())
+	assert.NoError(t, err)
+	// tss.Edwards() returns a new curve on every call; hashing to the curve again would take thousands of allocations
+	curves := make([]elliptic.Curve, 10)
+	for i := range curves {
+		curves[i] = tss.Edwards()
+	}
+	i := 0
+	allocs := testing.AllocsPerRun(len(curves)-1, func() {
+		H2, _ := PedersenH(curves[i])
+		assert.True(t, H.Equals(H2))
+		i++
+	})
+	assert.Less(t, allocs, 50.0, "the generator should be cached once per curve, not per curve instance")
+}
+
+func TestBatchVerifyPedersen(t *testing.T) {
+	ec := tss.S256()
+	cmts := make([]*PedersenCommitment, 5)
+	values, randomness := make([]*big.Int, 5), make([]*big.Int, 5)
+	for i := range cmts {
+		values[i] = big.NewInt(int64(i))
+		var err error
+		cmts[i], randomness[i], err = NewPedersenCommitmentWithRandomness(ec, values[i], rand.Reader)
+		assert.NoError(t, err)
+	}
+	assert.True(t, BatchVerifyPedersen(cmts, values, randomness, rand.Reader))
+
+	values[3] = big.NewInt(4)
+	assert.False(t, BatchVerifyPedersen(cmts, values, randomness, rand.Reader))
+	assert.False(t, BatchVerifyPedersen(cmts, values[:4], randomness, rand.Reader))
+	assert.False(t, BatchVerifyPedersen(nil, nil, nil, rand.Reader))
+}
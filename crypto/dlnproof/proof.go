@@ -17,10 +17,16 @@ import (
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	cmts "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
 )
 
-const Iterations = 128
+const (
+	Iterations = 128
+	// ProofBytesParts is the number of parts of a serialized proof: a length prefix followed by the elements, for
+	// each of Alpha and T
+	ProofBytesParts = 2 * (1 + Iterations)
+)
 
 type (
 	Proof struct {
@@ -102,6 +108,15 @@ func (p *Proof) Verify(h1, h2, N *big.Int) bool {
 	return true
 }
 
+func (p *Proof) ExpectedParts() int {
+	return ProofBytesParts
+}
+
+// ExpectedMaxPartLen bounds the parts: Alpha_i < NTilde and T_i < p*q < NTilde.
+func (p *Proof) ExpectedMaxPartLen(params crypto.ProofSizeParams) int {
+	return crypto.BitsToBytes(params.NTildeBits)
+}
+
 func (p *Proof) Serialize() ([][]byte, error) {
 	cb := cmts.NewBuilder()
 	cb = cb.AddPart(p.Alpha[:])
@@ -0,0 +1,100 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package ecies implements the Elliptic Curve Integrated Encryption Scheme over the curves supported by tss-lib:
+// an ephemeral Diffie-Hellman key agreement with the recipient's public key, followed by AES-256-GCM.
+package ecies
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+var kdfTag = []byte("tss-lib ecies")
+
+// Encrypt encrypts `msg` to the public key `pub`.
+// The ciphertext is R.x || R.y || nonce || sealed msg, where R is the ephemeral public key.
+func Encrypt(pub *crypto.ECPoint, msg []byte, rand io.Reader) ([]byte, error) {
+	if !pub.ValidateBasic() {
+		return nil, errors.New("ecies: invalid public key")
+	}
+	ec := pub.Curve()
+	r := common.GetRandomPositiveInt(rand, ec.Params().N)
+	R := crypto.ScalarBaseMult(ec, r)
+	header := marshalPoint(ec, R)
+	aead, err := newAEAD(ec, header, pub.ScalarMult(r))
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = io.ReadFull(rand, nonce); err != nil {
+		return nil, err
+	}
+	out := append(header, nonce...)
+	return aead.Seal(out, nonce, msg, header), nil
+}
+
+// Decrypt decrypts a ciphertext produced by Encrypt with the private key `priv` on the curve `ec`.
+func Decrypt(ec elliptic.Curve, priv *big.Int, ct []byte) ([]byte, error) {
+	if priv == nil || priv.Sign() <= 0 || priv.Cmp(ec.Params().N) >= 0 {
+		return nil, errors.New("ecies: invalid private key")
+	}
+	headerLen := 2 * coordLen(ec)
+	if len(ct) < headerLen {
+		return nil, errors.New("ecies: ciphertext too short")
+	}
+	header := ct[:headerLen]
+	R, err := crypto.NewECPoint(ec,
+		new(big.Int).SetBytes(header[:headerLen/2]),
+		new(big.Int).SetBytes(header[headerLen/2:]))
+	if err != nil {
+		return nil, fmt.Errorf("ecies: invalid ephemeral key: %v", err)
+	}
+	aead, err := newAEAD(ec, header, R.ScalarMult(priv))
+	if err != nil {
+		return nil, err
+	}
+	if len(ct) < headerLen+aead.NonceSize() {
+		return nil, errors.New("ecies: ciphertext too short")
+	}
+	nonce, sealed := ct[headerLen:headerLen+aead.NonceSize()], ct[headerLen+aead.NonceSize():]
+	msg, err := aead.Open(nil, nonce, sealed, header)
+	if err != nil {
+		return nil, errors.New("ecies: decryption failed")
+	}
+	return msg, nil
+}
+
+// ----- //
+
+func newAEAD(ec elliptic.Curve, header []byte, shared *crypto.ECPoint) (cipher.AEAD, error) {
+	key := common.SHA512_256(kdfTag, header, marshalPoint(ec, shared))
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func coordLen(ec elliptic.Curve) int {
+	return (ec.Params().BitSize + 7) / 8
+}
+
+func marshalPoint(ec elliptic.Curve, p *crypto.ECPoint) []byte {
+	l := coordLen(ec)
+	bz := make([]byte, 2*l)
+	p.X().FillBytes(bz[:l])
+	p.Y().FillBytes(bz[l:])
+	return bz
+}
@@ -0,0 +1,48 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ecies_test
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/ecies"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	for _, ec := range []elliptic.Curve{tss.S256(), tss.Edwards()} {
+		priv := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+		pub := crypto.ScalarBaseMult(ec, priv)
+		msg := []byte("a vss share")
+
+		ct, err := Encrypt(pub, msg, rand.Reader)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(ct), string(msg))
+		pt, err := Decrypt(ec, priv, ct)
+		assert.NoError(t, err)
+		assert.Equal(t, msg, pt)
+
+		// another key must not decrypt
+		other := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+		_, err = Decrypt(ec, other, ct)
+		assert.Error(t, err)
+
+		// nor a tampered ciphertext
+		tampered := append([]byte{}, ct...)
+		tampered[len(tampered)-1] ^= 0x01
+		_, err = Decrypt(ec, priv, tampered)
+		assert.Error(t, err)
+		_, err = Decrypt(ec, priv, ct[:10])
+		assert.Error(t, err)
+	}
+}
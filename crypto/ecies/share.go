@@ -0,0 +1,53 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ecies
+
+import (
+	"fmt"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// shareField is the field of the keygen p2p messages of ECDSA and EdDSA that carries the share
+const shareField protoreflect.Name = "share"
+
+// EncryptShare encrypts the keygen share for party j to its transport key, see tss.Parameters.SetShareEncryptionKeys
+func EncryptShare(params *tss.Parameters, j int, share *big.Int) ([]byte, error) {
+	pub := params.ShareEncryptionPubKeys()[j]
+	pubPoint, err := crypto.NewECPoint(params.EC(), pub.X, pub.Y)
+	if err != nil {
+		return nil, err
+	}
+	return Encrypt(pubPoint, share.Bytes(), params.Rand())
+}
+
+// DecryptShareMessage returns a copy of a keygen p2p share message with the share decrypted with this party's
+// transport key. The message content must have a bytes field named "share".
+func DecryptShareMessage(params *tss.Parameters, msg tss.ParsedMessage) (tss.ParsedMessage, error) {
+	content := proto.Clone(msg.Content()).(tss.MessageContent)
+	m := content.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName(shareField)
+	if fd == nil || fd.Kind() != protoreflect.BytesKind {
+		return nil, fmt.Errorf("ecies: %s has no share to decrypt", m.Descriptor().FullName())
+	}
+	share, err := Decrypt(params.EC(), params.ShareEncryptionKey().D, m.Get(fd).Bytes())
+	if err != nil {
+		return nil, err
+	}
+	m.Set(fd, protoreflect.ValueOfBytes(share))
+	meta := tss.MessageRouting{
+		From:        msg.GetFrom(),
+		To:          msg.GetTo(),
+		IsBroadcast: msg.IsBroadcast(),
+	}
+	return tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content)), nil
+}
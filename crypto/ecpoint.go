@@ -16,8 +16,10 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/decred/dcrd/dcrec/edwards/v2"
 
+	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
@@ -32,6 +34,10 @@ var (
 	eightInv = new(big.Int).ModInverse(eight, edwards.Edwards().Params().N)
 )
 
+// ErrPointAtInfinity is returned when the result of an operation is the point at infinity of a Weierstrass curve,
+// which has no affine coordinates and so cannot be an ECPoint.
+var ErrPointAtInfinity = errors.New("the result is the point at infinity")
+
 // Creates a new ECPoint and checks that the given coordinates are on the elliptic curve.
 func NewECPoint(curve elliptic.Curve, X, Y *big.Int) (*ECPoint, error) {
 	if !isOnCurve(curve, X, Y) {
@@ -54,11 +60,32 @@ func (p *ECPoint) Y() *big.Int {
 	return new(big.Int).Set(p.coords[1])
 }
 
+// Add returns p + p1. On a Weierstrass curve, ErrPointAtInfinity is returned when p1 is -p.
 func (p *ECPoint) Add(p1 *ECPoint) (*ECPoint, error) {
 	x, y := p.curve.Add(p.X(), p.Y(), p1.X(), p1.Y())
+	// the short Weierstrass implementations return (0, 0) for the point at infinity
+	if !isEdwards(p.curve) && x.Sign() == 0 && y.Sign() == 0 {
+		return nil, ErrPointAtInfinity
+	}
 	return NewECPoint(p.curve, x, y)
 }
 
+// Sub returns p - p1. On a Weierstrass curve, p.Sub(p) returns ErrPointAtInfinity as the result cannot be
+// represented, so that callers can tell it apart from a failure with errors.Is. On the Edwards curve p.Sub(p) returns
+// the identity, the point (0, 1).
+func (p *ECPoint) Sub(p1 *ECPoint) (*ECPoint, error) {
+	return p.Add(p1.Neg())
+}
+
+// Neg returns -p: (x, -y) on Weierstrass curves and (-x, y) on the Edwards curve.
+func (p *ECPoint) Neg() *ECPoint {
+	P := p.curve.Params().P
+	if isEdwards(p.curve) {
+		return NewECPointNoCurveCheck(p.curve, new(big.Int).Mod(new(big.Int).Neg(p.coords[0]), P), p.Y())
+	}
+	return NewECPointNoCurveCheck(p.curve, p.X(), new(big.Int).Mod(new(big.Int).Neg(p.coords[1]), P))
+}
+
 func (p *ECPoint) ScalarMult(k *big.Int) *ECPoint {
 	x, y := p.curve.ScalarMult(p.X(), p.Y(), k.Bytes())
 	newP, err := NewECPoint(p.curve, x, y) // it must be on the curve, no need to check.
@@ -161,10 +188,66 @@ func UnFlattenECPoints(curve elliptic.Curve, in []*big.Int, noCurveCheck ...bool
 	return unFlat, nil
 }
 
+// ----- //
+// Compressed encoding
+
+// Bytes returns the fixed-length compressed encoding of the point: the 32-byte encoding of RFC 8032 on the Edwards
+// curve, and the SEC 1 compressed encoding (0x02 or 0x03 followed by X) on Weierstrass curves, e.g. 33 bytes for
+// secp256k1. Unlike the big.Int coordinates, the encoding keeps its leading zeros.
+func (p *ECPoint) Bytes() []byte {
+	if isEdwards(p.curve) {
+		return edwards.NewPublicKey(p.coords[0], p.coords[1]).Serialize()
+	}
+	return elliptic.MarshalCompressed(p.curve, p.coords[0], p.coords[1])
+}
+
+// CoordinateBytes returns the big-endian coordinates of the point padded to the byte length of the curve's field,
+// for the messages that carry the X and Y of a point in separate fields.
+func (p *ECPoint) CoordinateBytes() (x, y []byte) {
+	size := (p.curve.Params().BitSize + 7) / 8
+	return common.PadBytes(p.coords[0].Bytes(), size), common.PadBytes(p.coords[1].Bytes(), size)
+}
+
+// ECPointFromBytes decodes a point encoded with ECPoint.Bytes on `curve`, checking that it is on the curve.
+// On Weierstrass curves other than secp256k1, the curve equation is assumed to be y² = x³ - 3x + b as for the NIST curves.
+func ECPointFromBytes(curve elliptic.Curve, b []byte) (*ECPoint, error) {
+	var x, y *big.Int
+	switch {
+	case isEdwards(curve):
+		pk, err := edwards.ParsePubKey(b)
+		if err != nil {
+			return nil, fmt.Errorf("ECPointFromBytes: %v", err)
+		}
+		x, y = pk.X, pk.Y
+	case curve.Params() == btcec.S256().Params():
+		if len(b) != btcec.PubKeyBytesLenCompressed {
+			return nil, errors.New("ECPointFromBytes: the point is not in compressed form")
+		}
+		pk, err := btcec.ParsePubKey(b)
+		if err != nil {
+			return nil, fmt.Errorf("ECPointFromBytes: %v", err)
+		}
+		x, y = pk.X(), pk.Y()
+	default:
+		if x, y = elliptic.UnmarshalCompressed(curve, b); x == nil {
+			return nil, errors.New("ECPointFromBytes: invalid compressed point")
+		}
+	}
+	return NewECPoint(curve, x, y)
+}
+
+func isEdwards(curve elliptic.Curve) bool {
+	_, ok := curve.(*edwards.TwistedEdwardsCurve)
+	return ok
+}
+
 // ----- //
 // Gob helpers for if you choose to encode messages with Gob.
 
+// GobEncode writes the coordinates followed by the registered name of the curve. A point on a curve that is not
+// registered is written without a name, in the older encoding, which is decoded with the global curve tss.EC().
 func (p *ECPoint) GobEncode() ([]byte, error) {
+	ecName, _ := tss.GetCurveName(p.curve)
 	buf := &bytes.Buffer{}
 	x, err := p.coords[0].GobEncode()
 	if err != nil {
@@ -185,6 +268,14 @@ func (p *ECPoint) GobEncode() ([]byte, error) {
 		return nil, err
 	}
 	buf.Write(y)
+	if ecName == "" {
+		return buf.Bytes(), nil
+	}
+	err = binary.Write(buf, binary.LittleEndian, uint32(len(ecName)))
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteString(string(ecName))
 
 	return buf.Bytes(), nil
 }
@@ -218,10 +309,65 @@ func (p *ECPoint) GobDecode(buf []byte) error {
 		return err
 	}
 	p.curve = tss.EC()
+	if reader.Len() > 0 {
+		if err := binary.Read(reader, binary.LittleEndian, &length); err != nil {
+			return err
+		}
+		name := make([]byte, length)
+		n, err = reader.Read(name)
+		if n != int(length) || err != nil {
+			return fmt.Errorf("gob decode failed: %v", err)
+		}
+		ec, ok := tss.GetCurveByName(tss.CurveName(name))
+		if !ok {
+			return fmt.Errorf("cannot find curve named with %s in curve registry, please call tss.RegisterCurve(name, curve) to register it first", name)
+		}
+		p.curve = ec
+	}
 	p.coords = [2]*big.Int{X, Y}
 	if !p.IsOnCurve() {
-		return errors.New("ECPoint.UnmarshalJSON: the point is not on the elliptic curve")
+		return fmt.Errorf("ECPoint.GobDecode: the point is not on the elliptic curve (%T)", p.curve)
+	}
+	return nil
+}
+
+// ----- //
+// encoding.BinaryMarshaler
+
+// MarshalBinary writes the length of the registered name of the curve as one byte, the name, and the compressed
+// encoding of ECPoint.Bytes, so that the point decodes on its own curve whatever the global curve is.
+func (p *ECPoint) MarshalBinary() ([]byte, error) {
+	if p.curve == nil || p.coords[0] == nil || p.coords[1] == nil {
+		return nil, errors.New("ECPoint.MarshalBinary: the point is not initialised")
+	}
+	ecName, ok := tss.GetCurveName(p.curve)
+	if !ok {
+		return nil, fmt.Errorf("cannot find %T name in curve registry, please call tss.RegisterCurve(name, curve) to register it first", p.curve)
+	}
+	if len(ecName) > 255 {
+		return nil, fmt.Errorf("ECPoint.MarshalBinary: the curve name %q is too long", ecName)
+	}
+	bz := p.Bytes()
+	buf := make([]byte, 0, 1+len(ecName)+len(bz))
+	buf = append(buf, byte(len(ecName)))
+	buf = append(buf, ecName...)
+	return append(buf, bz...), nil
+}
+
+func (p *ECPoint) UnmarshalBinary(data []byte) error {
+	if len(data) < 1 || len(data) < 1+int(data[0]) {
+		return errors.New("ECPoint.UnmarshalBinary: the data is too short")
+	}
+	name := tss.CurveName(data[1 : 1+int(data[0])])
+	ec, ok := tss.GetCurveByName(name)
+	if !ok {
+		return fmt.Errorf("cannot find curve named with %s in curve registry, please call tss.RegisterCurve(name, curve) to register it first", name)
+	}
+	point, err := ECPointFromBytes(ec, data[1+int(data[0]):])
+	if err != nil {
+		return fmt.Errorf("ECPoint.UnmarshalBinary: %v", err)
 	}
+	*p = *point
 	return nil
 }
 
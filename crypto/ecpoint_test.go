@@ -7,6 +7,11 @@
 package crypto_test
 
 import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding"
+	"encoding/gob"
 	"encoding/hex"
 	"encoding/json"
 	"math/big"
@@ -17,6 +22,7 @@ import (
 	"github.com/decred/dcrd/dcrec/edwards/v2"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/bnb-chain/tss-lib/v2/common"
 	. "github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
@@ -122,7 +128,6 @@ func TestUnFlattenECPoints(t *testing.T) {
 
 func TestS256EcpointJsonSerialization(t *testing.T) {
 	ec := btcec.S256()
-	tss.RegisterCurve("secp256k1", ec)
 
 	pubKeyBytes, err := hex.DecodeString("03935336acb03b2b801d8f8ac5e92c56c4f6e93319901fdfffba9d340a874e2879")
 	assert.NoError(t, err)
@@ -145,7 +150,6 @@ func TestS256EcpointJsonSerialization(t *testing.T) {
 
 func TestEdwardsEcpointJsonSerialization(t *testing.T) {
 	ec := edwards.Edwards()
-	tss.RegisterCurve("ed25519", ec)
 
 	pubKeyBytes, err := hex.DecodeString("ae1e5bf5f3d6bf58b5c222088671fcbe78b437e28fae944c793897b26091f249")
 	assert.NoError(t, err)
@@ -165,3 +169,257 @@ func TestEdwardsEcpointJsonSerialization(t *testing.T) {
 	assert.True(t, point.Equals(&umpoint))
 	assert.True(t, reflect.TypeOf(point.Curve()) == reflect.TypeOf(umpoint.Curve()))
 }
+
+func TestECPointJsonSerializationWithoutRegistration(t *testing.T) {
+	for _, ec := range []elliptic.Curve{tss.S256(), tss.Edwards(), elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		// the public key of a fresh keygen, g^x; the default curves are registered by tss without a RegisterCurve call
+		x := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+		ecdsaPub := ScalarBaseMult(ec, x)
+		bz, err := json.Marshal(ecdsaPub)
+		assert.NoError(t, err)
+
+		var umpoint ECPoint
+		assert.NoError(t, json.Unmarshal(bz, &umpoint))
+		assert.True(t, ecdsaPub.Equals(&umpoint))
+
+		// registering a default curve again is harmless
+		name, ok := tss.GetCurveName(ec)
+		assert.True(t, ok)
+		tss.RegisterCurve(name, ec)
+		bz2, err := json.Marshal(ecdsaPub)
+		assert.NoError(t, err)
+		assert.Equal(t, bz, bz2)
+	}
+}
+
+func TestECPointBytesRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		curve  elliptic.Curve
+		length int
+	}{
+		{"secp256k1", btcec.S256(), 33},
+		{"P-256", elliptic.P256(), 33},
+		{"ed25519", edwards.Edwards(), 32},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				k := common.GetRandomPositiveInt(rand.Reader, tc.curve.Params().N)
+				point := ScalarBaseMult(tc.curve, k)
+				bz := point.Bytes()
+				assert.Len(t, bz, tc.length)
+
+				decoded, err := ECPointFromBytes(tc.curve, bz)
+				assert.NoError(t, err)
+				assert.True(t, point.Equals(decoded))
+				assert.True(t, decoded.ValidateBasic())
+			}
+
+			_, err := ECPointFromBytes(tc.curve, nil)
+			assert.Error(t, err)
+			_, err = ECPointFromBytes(tc.curve, make([]byte, tc.length+1))
+			assert.Error(t, err)
+		})
+	}
+
+}
+
+func TestECPointNegSub(t *testing.T) {
+	for _, ec := range []elliptic.Curve{tss.S256(), elliptic.P256(), tss.Edwards()} {
+		N := ec.Params().N
+		a := common.GetRandomPositiveInt(rand.Reader, N)
+		b := common.GetRandomPositiveInt(rand.Reader, N)
+		P, Q := ScalarBaseMult(ec, a), ScalarBaseMult(ec, b)
+
+		negP := P.Neg()
+		assert.True(t, negP.ValidateBasic())
+		assert.True(t, negP.Equals(P.ScalarMult(new(big.Int).Sub(N, big.NewInt(1)))), "-P should equal (N-1)P")
+		assert.True(t, negP.Neg().Equals(P))
+
+		diff, err := P.Sub(Q)
+		assert.NoError(t, err)
+		assert.True(t, diff.Equals(ScalarBaseMult(ec, common.ModInt(N).Sub(a, b))))
+
+		zero, err := P.Sub(P)
+		zero2, err2 := P.Add(P.Neg())
+		if _, ok := ec.(*edwards.TwistedEdwardsCurve); ok {
+			// the identity of the Edwards curve is the point (0, 1)
+			identity := NewECPointNoCurveCheck(ec, big.NewInt(0), big.NewInt(1))
+			assert.NoError(t, err)
+			assert.NoError(t, err2)
+			assert.True(t, zero.Equals(identity))
+			assert.True(t, zero2.Equals(identity))
+		} else {
+			assert.ErrorIs(t, err, ErrPointAtInfinity)
+			assert.ErrorIs(t, err2, ErrPointAtInfinity)
+		}
+	}
+}
+
+func TestEcpointGobSerialization(t *testing.T) {
+	assert.Equal(t, tss.S256(), tss.EC(), "the global curve should be secp256k1")
+
+	k := common.GetRandomPositiveInt(rand.Reader, tss.Edwards().Params().N)
+	for _, point := range []*ECPoint{ScalarBaseMult(tss.Edwards(), k), ScalarBaseMult(tss.S256(), k)} {
+		buf := &bytes.Buffer{}
+		assert.NoError(t, gob.NewEncoder(buf).Encode(point))
+		decoded := new(ECPoint)
+		assert.NoError(t, gob.NewDecoder(buf).Decode(decoded))
+		assert.True(t, point.Equals(decoded))
+		assert.True(t, reflect.TypeOf(point.Curve()) == reflect.TypeOf(decoded.Curve()))
+	}
+
+	// encodings without a curve name use the global curve
+	point := ScalarBaseMult(tss.S256(), k)
+	bz, err := point.GobEncode()
+	assert.NoError(t, err)
+	legacy := bz[:len(bz)-4-len(tss.Secp256k1)]
+	decoded := new(ECPoint)
+	assert.NoError(t, decoded.GobDecode(legacy))
+	assert.True(t, point.Equals(decoded))
+	assert.Equal(t, tss.EC(), decoded.Curve())
+
+	// a point on a curve that is not registered is encoded without a curve name
+	unregistered := *elliptic.P256().Params()
+	point = ScalarBaseMult(&unregistered, k)
+	bz, err = point.GobEncode()
+	assert.NoError(t, err)
+	x, _ := point.X().GobEncode()
+	y, _ := point.Y().GobEncode()
+	assert.Len(t, bz, 4+len(x)+4+len(y))
+	assert.Error(t, new(ECPoint).GobDecode(bz), "the point is not on the global curve")
+}
+
+func TestECPointBinaryRoundTrip(t *testing.T) {
+	assert.Equal(t, tss.S256(), tss.EC(), "the global curve should be secp256k1")
+
+	for _, name := range []tss.CurveName{tss.Secp256k1, tss.Ed25519, tss.P256, tss.P384, tss.P521} {
+		ec, ok := tss.GetCurveByName(name)
+		assert.True(t, ok, name)
+		point := ScalarBaseMult(ec, common.GetRandomPositiveInt(rand.Reader, ec.Params().N))
+
+		var marshaler encoding.BinaryMarshaler = point
+		bz, err := marshaler.MarshalBinary()
+		assert.NoError(t, err, name)
+		assert.Len(t, bz, 1+len(name)+len(point.Bytes()), name)
+
+		decoded := new(ECPoint)
+		var unmarshaler encoding.BinaryUnmarshaler = decoded
+		assert.NoError(t, unmarshaler.UnmarshalBinary(bz), name)
+		assert.True(t, point.Equals(decoded), name)
+		assert.Equal(t, ec, decoded.Curve(), name)
+
+		// the name and the point must be complete
+		assert.Error(t, new(ECPoint).UnmarshalBinary(bz[:len(bz)-1]), name)
+		assert.Error(t, new(ECPoint).UnmarshalBinary(bz[:1+len(name)]), name)
+		assert.Error(t, new(ECPoint).UnmarshalBinary(bz[:len(name)]), name)
+	}
+	assert.Error(t, new(ECPoint).UnmarshalBinary(nil))
+	assert.Error(t, new(ECPoint).UnmarshalBinary(append([]byte{3}, "foo"...)), "unregistered curve")
+}
+
+func TestMultiScalarMult(t *testing.T) {
+	for _, curve := range []elliptic.Curve{tss.S256(), tss.Edwards()} {
+		for _, n := range []int{1, 2, 5, 16, 40} {
+			points := make([]*ECPoint, n)
+			scalars := make([]*big.Int, n)
+			var expected *ECPoint
+			for i := range points {
+				points[i] = ScalarBaseMult(curve, common.GetRandomPositiveInt(rand.Reader, curve.Params().N))
+				scalars[i] = common.GetRandomPositiveInt(rand.Reader, curve.Params().N)
+				term := points[i].ScalarMult(scalars[i])
+				if expected == nil {
+					expected = term
+					continue
+				}
+				var err error
+				expected, err = expected.Add(term)
+				assert.NoError(t, err)
+			}
+			got, err := MultiScalarMult(points, scalars)
+			assert.NoError(t, err)
+			assert.True(t, expected.Equals(got), "MultiScalarMult should equal the sum of ScalarMult for %d points", n)
+		}
+	}
+
+	G := ScalarBaseMult(tss.S256(), big.NewInt(1))
+	_, err := MultiScalarMult([]*ECPoint{G, G}, []*big.Int{big.NewInt(1), new(big.Int).Sub(tss.S256().Params().N, big.NewInt(1))})
+	assert.ErrorIs(t, err, ErrPointAtInfinity)
+	_, err = MultiScalarMult([]*ECPoint{G}, []*big.Int{big.NewInt(1), big.NewInt(2)})
+	assert.Error(t, err)
+	_, err = MultiScalarMult([]*ECPoint{G, ScalarBaseMult(tss.Edwards(), big.NewInt(1))}, []*big.Int{big.NewInt(1), big.NewInt(2)})
+	assert.Error(t, err)
+}
+
+func BenchmarkMultiScalarMult(b *testing.B) {
+	curve := tss.S256()
+	points := make([]*ECPoint, 64)
+	scalars := make([]*big.Int, len(points))
+	for i := range points {
+		points[i] = ScalarBaseMult(curve, common.GetRandomPositiveInt(rand.Reader, curve.Params().N))
+		scalars[i] = common.GetRandomPositiveInt(rand.Reader, curve.Params().N)
+	}
+	b.Run("MultiScalarMult", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			_, _ = MultiScalarMult(points, scalars)
+		}
+	})
+	b.Run("ScalarMultAndAdd", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			sum := points[0].ScalarMult(scalars[0])
+			for i := 1; i < len(points); i++ {
+				sum, _ = sum.Add(points[i].ScalarMult(scalars[i]))
+			}
+		}
+	})
+}
+
+func TestBaseMultTable(t *testing.T) {
+	for _, curve := range []elliptic.Curve{tss.Edwards(), tss.S256()} {
+		table := BaseMultTable(curve)
+		assert.True(t, table == BaseMultTable(curve), "the table should be cached per curve")
+		N := curve.Params().N
+		scalars := []*big.Int{
+			big.NewInt(1),
+			big.NewInt(15),
+			big.NewInt(16),
+			new(big.Int).Sub(N, big.NewInt(1)),
+			new(big.Int).Add(N, big.NewInt(5)),
+			new(big.Int).Lsh(big.NewInt(1), 300),
+			big.NewInt(-12345),
+		}
+		count := 10000
+		if testing.Short() {
+			count = 1000
+		}
+		for i := 0; i < count; i++ {
+			scalars = append(scalars, common.GetRandomPositiveInt(rand.Reader, N))
+		}
+		for _, k := range scalars {
+			expected, got := ScalarBaseMult(curve, k), table.VarTimeScalarBaseMult(k)
+			if !assert.True(t, expected.Equals(got), "k = %s", k) {
+				break
+			}
+		}
+	}
+	assert.True(t, BaseMultTable(tss.Edwards()) == BaseMultTable(tss.Edwards()), "the table should be cached across curve instances")
+	identity := BaseMultTable(tss.Edwards()).VarTimeScalarBaseMult(tss.Edwards().Params().N)
+	assert.True(t, ScalarBaseMult(tss.Edwards(), big.NewInt(0)).Equals(identity))
+}
+
+func BenchmarkVarTimeScalarBaseMult(b *testing.B) {
+	for name, curve := range map[string]elliptic.Curve{"ed25519": tss.Edwards(), "secp256k1": tss.S256()} {
+		k := common.GetRandomPositiveInt(rand.Reader, curve.Params().N)
+		table := BaseMultTable(curve)
+		b.Run(name+"/ScalarBaseMult", func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				ScalarBaseMult(curve, k)
+			}
+		})
+		b.Run(name+"/VarTimeScalarBaseMult", func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				table.VarTimeScalarBaseMult(k)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright © 2019-2023 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package facproof
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+// ProofFacInput is a ProofFac with the public inputs it is verified against, for BatchVerify.
+// NCap, S and T are the verifier's own ring-Pedersen parameters.
+type ProofFacInput struct {
+	Proof          *ProofFac
+	Session        []byte
+	N0, NCap, S, T *big.Int
+}
+
+// BatchVerify verifies the proofs concurrently as ProofFac.Verify does, see crypto.BatchVerify.
+func BatchVerify(ec elliptic.Curve, inputs []ProofFacInput, concurrency int) []int {
+	return crypto.BatchVerify(len(inputs), concurrency, func(i int) bool {
+		in := inputs[i]
+		return in.Proof.Verify(in.Session, ec, in.N0, in.NCap, in.S, in.T)
+	})
+}
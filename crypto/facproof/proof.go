@@ -14,6 +14,7 @@ import (
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 )
 
 const (
@@ -198,6 +199,18 @@ func (pf *ProofFac) ValidateBasic() bool {
 		pf.V != nil
 }
 
+func (pf *ProofFac) ExpectedParts() int {
+	return ProofFacBytesParts
+}
+
+// ExpectedMaxPartLen bounds the parts: P, Q, A, B, T < NCap; Sigma < q*N0*NCap; Z1, Z2 < 2q^3*sqrt(N0);
+// W1, W2 < 2q^3*NCap; V < 2q^3*N0*NCap. Here N0 is the Paillier modulus and NCap is the verifier's NTilde.
+func (pf *ProofFac) ExpectedMaxPartLen(params crypto.ProofSizeParams) int {
+	q := params.QBits()
+	return crypto.BitsToBytes(params.NTildeBits, q+params.PaillierNBits+params.NTildeBits, 3*q+params.PaillierNBits/2+1,
+		3*q+params.NTildeBits+1, 3*q+params.PaillierNBits+params.NTildeBits+1)
+}
+
 func (pf *ProofFac) Bytes() [ProofFacBytesParts][]byte {
 	return [...][]byte{
 		pf.P.Bytes(),
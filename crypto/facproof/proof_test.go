@@ -7,6 +7,7 @@
 package facproof_test
 
 import (
+	"crypto/elliptic"
 	"crypto/rand"
 	"math/big"
 	"testing"
@@ -16,6 +17,7 @@ import (
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
 	. "github.com/bnb-chain/tss-lib/v2/crypto/facproof"
+	"github.com/bnb-chain/tss-lib/v2/test"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
@@ -42,6 +44,11 @@ func TestFac(test *testing.T) {
 	ok := proof.Verify(Session, ec, N0, NCap, s, t)
 	assert.True(test, ok, "proof must verify")
 
+	sizes := crypto.ProofSizeParams{EC: ec, PaillierNBits: 2 * testSafePrimeBits, NTildeBits: NCap.BitLen()}
+	proofBzs := proof.Bytes()
+	assert.True(test, crypto.ValidateProofBytes(proof, sizes, proofBzs[:]))
+	assert.False(test, crypto.ValidateProofBytes(proof, sizes, proofBzs[1:]))
+
 	N0p = common.GetRandomPrimeInt(rand.Reader, 1024)
 	N0q = common.GetRandomPrimeInt(rand.Reader, 1024)
 	N0 = new(big.Int).Mul(N0p, N0q)
@@ -52,3 +59,78 @@ func TestFac(test *testing.T) {
 	ok = proof.Verify(Session, ec, N0, NCap, s, t)
 	assert.True(test, ok, "proof must verify")
 }
+
+func TestFacLargeModulus(test *testing.T) {
+	ec := tss.EC()
+
+	// a 4096-bit Paillier modulus proven against a 2048-bit NCap
+	N0p := common.GetRandomPrimeInt(rand.Reader, 2*testSafePrimeBits)
+	N0q := common.GetRandomPrimeInt(rand.Reader, 2*testSafePrimeBits)
+	N0 := new(big.Int).Mul(N0p, N0q)
+
+	primes := [2]*big.Int{common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits), common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits)}
+	NCap, s, t, err := crypto.GenerateNTildei(rand.Reader, primes)
+	assert.NoError(test, err)
+	proof, err := NewProof(Session, ec, N0, NCap, s, t, N0p, N0q, rand.Reader)
+	assert.NoError(test, err)
+	assert.True(test, proof.Verify(Session, ec, N0, NCap, s, t), "proof must verify")
+
+	sizes := crypto.ProofSizeParams{EC: ec, PaillierNBits: N0.BitLen(), NTildeBits: NCap.BitLen()}
+	proofBzs := proof.Bytes()
+	assert.True(test, crypto.ValidateProofBytes(proof, sizes, proofBzs[:]))
+}
+
+func TestBatchVerify(t *testing.T) {
+	ec := tss.EC()
+	inputs := makeProofFacInputs(t, 6)
+
+	// corrupt some of the inputs
+	inputs[1].Session = []byte("another session")
+	inputs[3].N0 = new(big.Int).Add(inputs[3].N0, big.NewInt(2))
+	inputs[4].Proof = nil
+
+	test.CheckBatchVerify(t, len(inputs), batchVerifyFunc(ec, inputs), verifyFunc(ec, inputs), []int{1, 3, 4})
+	assert.Empty(t, BatchVerify(ec, nil, 0))
+}
+
+func BenchmarkVerify20Parties(b *testing.B) {
+	ec := tss.EC()
+	inputs := makeProofFacInputs(b, 20)
+	test.BenchmarkBatchVerify(b, len(inputs), batchVerifyFunc(ec, inputs), verifyFunc(ec, inputs))
+}
+
+func batchVerifyFunc(ec elliptic.Curve, inputs []ProofFacInput) func(concurrency int) []int {
+	return func(concurrency int) []int {
+		return BatchVerify(ec, inputs, concurrency)
+	}
+}
+
+func verifyFunc(ec elliptic.Curve, inputs []ProofFacInput) func(i int) bool {
+	return func(i int) bool {
+		in := inputs[i]
+		return in.Proof.Verify(in.Session, ec, in.N0, in.NCap, in.S, in.T)
+	}
+}
+
+// makeProofFacInputs returns the inputs of `n` valid proofs, as received by one party from its `n` counterparties
+func makeProofFacInputs(tb testing.TB, n int) []ProofFacInput {
+	ec := tss.EC()
+	primes := [2]*big.Int{common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits), common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits)}
+	NCap, s, t, err := crypto.GenerateNTildei(rand.Reader, primes)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	N0p := common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits)
+	N0q := common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits)
+	N0 := new(big.Int).Mul(N0p, N0q)
+	inputs := make([]ProofFacInput, n)
+	for j := range inputs {
+		session := append(append([]byte{}, Session...), byte(j))
+		proof, err := NewProof(session, ec, N0, NCap, s, t, N0p, N0q, rand.Reader)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		inputs[j] = ProofFacInput{Proof: proof, Session: session, N0: N0, NCap: NCap, S: s, T: t}
+	}
+	return inputs
+}
@@ -0,0 +1,215 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package crypto
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+const (
+	// hashToCurveDST is the domain separation tag of HashToCurve on secp256k1, in the format of RFC 9380
+	hashToCurveDST = "TSS-LIB-V01-CS01-with-secp256k1_XMD:SHA-256_SSWU_RO_"
+
+	// hashToCurveTAIDomain separates the try-and-increment hashes of HashToCurve on the other curves
+	hashToCurveTAIDomain = "tss-lib/hash-to-curve/try-and-increment"
+)
+
+var (
+	// the curve E' of the simplified SWU map for secp256k1: y^2 = x^3 + A'x + B', 3-isogenous to secp256k1, RFC 9380 8.7
+	sswuA = hexToBigInt("3f8731abdd661adca08a5558f0f5d272e953d363cb6f0e5d405447c01a444533")
+	sswuB = big.NewInt(1771)
+	sswuZ = big.NewInt(-11)
+
+	// the coefficients of the 3-isogeny map from E' to secp256k1, RFC 9380 E.1
+	isoXNum = []*big.Int{
+		hexToBigInt("8e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38daaaaa8c7"),
+		hexToBigInt("07d3d4c80bc321d5b9f315cea7fd44c5d595d2fc0bf63b92dfff1044f17c6581"),
+		hexToBigInt("534c328d23f234e6e2a413deca25caece4506144037c40314ecbd0b53d9dd262"),
+		hexToBigInt("8e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38daaaaa88c"),
+	}
+	isoXDen = []*big.Int{
+		hexToBigInt("d35771193d94918a9ca34ccbb7b640dd86cd409542f8487d9fe6b745781eb49b"),
+		hexToBigInt("edadc6f64383dc1df7c4b2d51b54225406d36b641f5e41bbc52a56612a8c6d14"),
+		big.NewInt(1),
+	}
+	isoYNum = []*big.Int{
+		hexToBigInt("4bda12f684bda12f684bda12f684bda12f684bda12f684bda12f684b8e38e23c"),
+		hexToBigInt("c75e0c32d5cb7c0fa9d0a54b12a0a6d5647ab046d686da6fdffc90fc201d71a3"),
+		hexToBigInt("29a6194691f91a73715209ef6512e576722830a201be2018a765e85a9ecee931"),
+		hexToBigInt("2f684bda12f684bda12f684bda12f684bda12f684bda12f684bda12f38e38d84"),
+	}
+	isoYDen = []*big.Int{
+		hexToBigInt("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffff93b"),
+		hexToBigInt("7a06534bb8bdb49fd5e9e6632722c2989467c1bfc8e8d978dfb425d2685c2573"),
+		hexToBigInt("6484aa716545ca2cf3a70c3fa8fe337e0a3d21162f0d6299a7bf8192bfd2a76f"),
+		big.NewInt(1),
+	}
+)
+
+// HashToCurve deterministically maps `data` to a point on `curve` whose discrete logarithm to the generator is unknown,
+// e.g. to derive a second generator for Pedersen commitments: h := HashToCurve(curve, []byte("tss-lib/h")).
+// On secp256k1 it is the hash_to_curve of RFC 9380 with the suite secp256k1_XMD:SHA-256_SSWU_RO_ and a tss-lib tag.
+// The other curves use try-and-increment, whose running time depends on `data`; both are meant for public inputs.
+// On twisted Edwards curves the candidates are RFC 8032 encodings, and the cofactor of the point found is cleared so
+// that it is in the subgroup of the generator.
+func HashToCurve(curve elliptic.Curve, data []byte) (*ECPoint, error) {
+	if curve.Params() == btcec.S256().Params() {
+		return hashToCurveS256(data, []byte(hashToCurveDST))
+	}
+	return hashToCurveTryAndIncrement(curve, data)
+}
+
+func hashToCurveS256(msg, dst []byte) (*ECPoint, error) {
+	ec := btcec.S256()
+	p := ec.Params().P
+	uniform, err := expandMessageXMD(msg, dst, 2*48)
+	if err != nil {
+		return nil, fmt.Errorf("HashToCurve: %v", err)
+	}
+	var Q [2]*ECPoint
+	for i := range Q {
+		u := new(big.Int).Mod(new(big.Int).SetBytes(uniform[i*48:(i+1)*48]), p)
+		x, y := mapToCurveSSWU(u, p)
+		if Q[i], err = isoMap(ec, x, y); err != nil {
+			return nil, err
+		}
+	}
+	return Q[0].Add(Q[1]) // the cofactor of secp256k1 is 1
+}
+
+// mapToCurveSSWU is the simplified SWU map to E' of RFC 9380 6.6.2, for p = 3 mod 4
+func mapToCurveSSWU(u, p *big.Int) (x, y *big.Int) {
+	modP := func(i *big.Int) *big.Int { return i.Mod(i, p) }
+	g := func(x *big.Int) *big.Int { // x^3 + A'x + B'
+		gx := new(big.Int).Mul(x, x)
+		gx.Add(gx, sswuA).Mul(gx, x).Add(gx, sswuB)
+		return modP(gx)
+	}
+	zu2 := modP(new(big.Int).Mul(sswuZ, new(big.Int).Mul(u, u)))
+	tv1 := modP(new(big.Int).Add(new(big.Int).Mul(zu2, zu2), zu2)) // Z^2 u^4 + Z u^2
+	if tv1.Sign() == 0 {
+		// x1 = B' / (Z A')
+		x = modP(new(big.Int).Mul(sswuB, new(big.Int).ModInverse(modP(new(big.Int).Mul(sswuZ, sswuA)), p)))
+	} else {
+		// x1 = (-B' / A') (1 + 1/tv1)
+		x = new(big.Int).Add(big.NewInt(1), new(big.Int).ModInverse(tv1, p))
+		x.Mul(x, new(big.Int).Neg(sswuB)).Mul(x, new(big.Int).ModInverse(sswuA, p))
+		x = modP(x)
+	}
+	if y = new(big.Int).ModSqrt(g(x), p); y == nil {
+		// x2 = Z u^2 x1, for which g(x2) is a square when g(x1) is not
+		x = modP(new(big.Int).Mul(zu2, x))
+		y = new(big.Int).ModSqrt(g(x), p)
+	}
+	if u.Bit(0) != y.Bit(0) {
+		y = modP(y.Neg(y))
+	}
+	return
+}
+
+// isoMap maps a point of E' to secp256k1 with the 3-isogeny of RFC 9380 E.1
+func isoMap(ec elliptic.Curve, x, y *big.Int) (*ECPoint, error) {
+	p := ec.Params().P
+	eval := func(coeffs []*big.Int) *big.Int { // Horner's method
+		res := new(big.Int)
+		for i := len(coeffs) - 1; i >= 0; i-- {
+			res.Mul(res, x).Add(res, coeffs[i]).Mod(res, p)
+		}
+		return res
+	}
+	xDen, yDen := eval(isoXDen), eval(isoYDen)
+	if xDen.Sign() == 0 || yDen.Sign() == 0 {
+		return nil, errors.New("HashToCurve: the isogeny maps to the point at infinity")
+	}
+	xOut := new(big.Int).Mul(eval(isoXNum), xDen.ModInverse(xDen, p))
+	yOut := new(big.Int).Mul(eval(isoYNum), yDen.ModInverse(yDen, p))
+	yOut.Mul(yOut, y)
+	return NewECPoint(ec, xOut.Mod(xOut, p), yOut.Mod(yOut, p))
+}
+
+// expandMessageXMD is expand_message_xmd of RFC 9380 5.3.1 with SHA-256
+func expandMessageXMD(msg, dst []byte, lenInBytes int) ([]byte, error) {
+	ell := (lenInBytes + sha256.Size - 1) / sha256.Size
+	if ell > 255 || lenInBytes > 65535 || len(dst) > 255 {
+		return nil, errors.New("expand_message_xmd: the output or the tag is too long")
+	}
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+	h := sha256.New()
+	h.Write(make([]byte, sha256.BlockSize)) // Z_pad
+	h.Write(msg)
+	h.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes), 0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+
+	out := make([]byte, 0, ell*sha256.Size)
+	bi := make([]byte, sha256.Size)
+	for i := 1; i <= ell; i++ {
+		for j := range bi {
+			bi[j] ^= b0[j] // b_1 hashes b_0 itself, as bi starts out as zeroes
+		}
+		h.Reset()
+		h.Write(bi)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(nil)
+		out = append(out, bi...)
+	}
+	return out[:lenInBytes], nil
+}
+
+// hashToCurveTryAndIncrement hashes `data` with a counter to an x coordinate until one is on the curve, and picks the
+// even y of it; about half of the candidates succeed. On twisted Edwards curves the hash is decoded as a point instead.
+func hashToCurveTryAndIncrement(curve elliptic.Curve, data []byte) (*ECPoint, error) {
+	p := curve.Params().P
+	coordLen := (p.BitLen() + 7) / 8
+	dst := []byte(hashToCurveTAIDomain)
+	for ctr := 0; ctr < 256; ctr++ {
+		msg := append(append([]byte{}, data...), byte(ctr))
+		if isEdwards(curve) {
+			encoded, err := expandMessageXMD(msg, dst, coordLen)
+			if err != nil {
+				return nil, fmt.Errorf("HashToCurve: %v", err)
+			}
+			point, err := ECPointFromBytes(curve, encoded)
+			if err != nil {
+				continue
+			}
+			// clear the cofactor; a point of small order becomes the identity (0, 1)
+			if point = point.EightInvEight(); point.X().Sign() != 0 {
+				return point, nil
+			}
+			continue
+		}
+		// 16 more bytes than the field, so that the reduction mod p is close to uniform
+		uniform, err := expandMessageXMD(msg, dst, coordLen+16)
+		if err != nil {
+			return nil, fmt.Errorf("HashToCurve: %v", err)
+		}
+		x := new(big.Int).Mod(new(big.Int).SetBytes(uniform), p)
+		candidate := make([]byte, 1+coordLen)
+		candidate[0] = 0x02
+		x.FillBytes(candidate[1:])
+		if point, err := ECPointFromBytes(curve, candidate); err == nil {
+			return point, nil
+		}
+	}
+	return nil, errors.New("HashToCurve: could not hash to a point on the curve")
+}
+
+func hexToBigInt(s string) *big.Int {
+	i, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic(fmt.Errorf("hexToBigInt: invalid hex %q", s))
+	}
+	return i
+}
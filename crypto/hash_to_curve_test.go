@@ -0,0 +1,73 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package crypto
+
+import (
+	"crypto/elliptic"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestHashToCurve(t *testing.T) {
+	for _, ec := range []elliptic.Curve{btcec.S256(), elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		h, err := HashToCurve(ec, []byte("tss-lib/h"))
+		assert.NoError(t, err, ec.Params().Name)
+		assert.True(t, h.ValidateBasic() && ec.IsOnCurve(h.X(), h.Y()), ec.Params().Name)
+
+		h2, err := HashToCurve(ec, []byte("tss-lib/h"))
+		assert.NoError(t, err)
+		assert.True(t, h.Equals(h2), "deterministic on %s", ec.Params().Name)
+
+		G := NewECPointNoCurveCheck(ec, ec.Params().Gx, ec.Params().Gy)
+		assert.False(t, h.Equals(G), ec.Params().Name)
+		other, err := HashToCurve(ec, []byte("tss-lib/h2"))
+		assert.NoError(t, err)
+		assert.False(t, h.Equals(other), ec.Params().Name)
+	}
+
+	// on Edwards curves the point is in the subgroup of the generator
+	ec := tss.Edwards()
+	h, err := HashToCurve(ec, []byte("tss-lib/h"))
+	assert.NoError(t, err)
+	assert.True(t, h.ValidateBasic())
+	assert.NotZero(t, h.X().Sign(), "the point should not be the identity")
+	identity := h.ScalarMult(ec.Params().N)
+	assert.Zero(t, identity.X().Sign())
+	assert.Zero(t, identity.Y().Cmp(big.NewInt(1)))
+	h2, err := HashToCurve(tss.Edwards(), []byte("tss-lib/h"))
+	assert.NoError(t, err)
+	assert.True(t, h.Equals(h2))
+}
+
+// the test vectors of RFC 9380 J.8.1 and K.1
+func TestHashToCurveRFC9380Vectors(t *testing.T) {
+	out, err := expandMessageXMD(nil, []byte("QUUX-V01-CS02-with-expander-SHA256-128"), 0x20)
+	assert.NoError(t, err)
+	assert.Equal(t, "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235", hex.EncodeToString(out))
+
+	dst := []byte("QUUX-V01-CS02-with-secp256k1_XMD:SHA-256_SSWU_RO_")
+	vectors := []struct{ msg, x, y string }{
+		{"",
+			"c1cae290e291aee617ebaef1be6d73861479c48b841eaba9b7b5852ddfeb1346",
+			"64fa678e07ae116126f08b022a94af6de15985c996c3a91b64c406a960e51067"},
+		{"abc",
+			"3377e01eab42db296b512293120c6cee72b6ecf9f9205760bd9ff11fb3cb2c4b",
+			"7f95890f33efebd1044d382a01b1bee0900fb6116f94688d487c6c7b9c8371f6"},
+	}
+	for _, v := range vectors {
+		P, err := hashToCurveS256([]byte(v.msg), dst)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, P.X().Cmp(hexToBigInt(v.x)), v.msg)
+		assert.Equal(t, 0, P.Y().Cmp(hexToBigInt(v.y)), v.msg)
+	}
+}
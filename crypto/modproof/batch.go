@@ -0,0 +1,28 @@
+// Copyright © 2019-2023 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package modproof
+
+import (
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+// ProofModInput is a ProofMod with the public inputs it is verified against, for BatchVerify.
+type ProofModInput struct {
+	Proof   *ProofMod
+	Session []byte
+	N       *big.Int
+}
+
+// BatchVerify verifies the proofs concurrently as ProofMod.Verify does, see crypto.BatchVerify.
+func BatchVerify(inputs []ProofModInput, concurrency int) []int {
+	return crypto.BatchVerify(len(inputs), concurrency, func(i int) bool {
+		in := inputs[i]
+		return in.Proof.Verify(in.Session, in.N)
+	})
+}
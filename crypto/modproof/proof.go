@@ -12,11 +12,15 @@ import (
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 )
 
 const (
 	Iterations         = 80
 	ProofModBytesParts = Iterations*2 + 3
+
+	// the byte length of the bit masks A and B
+	maskBytes = (Iterations + 7) / 8
 )
 
 var one = big.NewInt(1)
@@ -223,6 +227,15 @@ func (pf *ProofMod) ValidateBasic() bool {
 	return true
 }
 
+func (pf *ProofMod) ExpectedParts() int {
+	return ProofModBytesParts
+}
+
+// ExpectedMaxPartLen bounds the parts: W, X_i, Z_i < N, where N is the Paillier modulus; A and B are bit masks.
+func (pf *ProofMod) ExpectedMaxPartLen(params crypto.ProofSizeParams) int {
+	return crypto.BitsToBytes(params.PaillierNBits, Iterations)
+}
+
 func (pf *ProofMod) Bytes() [ProofModBytesParts][]byte {
 	bzs := [ProofModBytesParts][]byte{}
 	bzs[0] = pf.W.Bytes()
@@ -231,8 +244,8 @@ func (pf *ProofMod) Bytes() [ProofModBytesParts][]byte {
 			bzs[1+i] = pf.X[i].Bytes()
 		}
 	}
-	bzs[Iterations+1] = pf.A.Bytes()
-	bzs[Iterations+2] = pf.B.Bytes()
+	bzs[Iterations+1] = common.PadBytes(pf.A.Bytes(), maskBytes)
+	bzs[Iterations+2] = common.PadBytes(pf.B.Bytes(), maskBytes)
 	for i := range pf.Z {
 		if pf.Z[i] != nil {
 			bzs[Iterations+3+i] = pf.Z[i].Bytes()
@@ -8,11 +8,16 @@ package modproof_test
 
 import (
 	"crypto/rand"
+	"math/big"
 	"testing"
 	"time"
 
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	. "github.com/bnb-chain/tss-lib/v2/crypto/modproof"
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -28,9 +33,99 @@ func TestMod(test *testing.T) {
 	assert.NoError(test, err)
 
 	proofBzs := proof.Bytes()
+	sizes := crypto.ProofSizeParams{EC: tss.EC(), PaillierNBits: N.BitLen(), NTildeBits: preParams.NTildei.BitLen()}
+	assert.True(test, crypto.ValidateProofBytes(proof, sizes, proofBzs[:]))
+	assert.False(test, crypto.ValidateProofBytes(proof, sizes, proofBzs[1:]))
 	proof, err = NewProofFromBytes(proofBzs[:])
 	assert.NoError(test, err)
 
 	ok := proof.Verify(Session, N)
 	assert.True(test, ok, "proof must verify")
 }
+
+func TestModLargeModulus(test *testing.T) {
+	// Blum primes for a 4096-bit modulus
+	blumPrime := func() *big.Int {
+		for {
+			if p := common.GetRandomPrimeInt(rand.Reader, 2048); p.Bit(1) == 1 {
+				return p
+			}
+		}
+	}
+	P, Q := blumPrime(), blumPrime()
+	N := new(big.Int).Mul(P, Q)
+
+	proof, err := NewProof(Session, N, P, Q, rand.Reader)
+	assert.NoError(test, err)
+	assert.True(test, proof.Verify(Session, N), "proof must verify")
+
+	sizes := crypto.ProofSizeParams{EC: tss.EC(), PaillierNBits: N.BitLen(), NTildeBits: 2048}
+	proofBzs := proof.Bytes()
+	assert.True(test, crypto.ValidateProofBytes(proof, sizes, proofBzs[:]))
+}
+
+func TestModBytesLeadingZeros(test *testing.T) {
+	in := makeProofModInputs(test, 1)[0]
+	assert.True(test, in.Proof.Verify(in.Session, in.N))
+
+	// bit masks with leading zero bytes, or all zero, keep the length of Iterations bits
+	proof := *in.Proof
+	proof.A, proof.B = big.NewInt(1), big.NewInt(0)
+	bzs := proof.Bytes()
+	assert.Len(test, bzs[Iterations+1], (Iterations+7)/8)
+	assert.Len(test, bzs[Iterations+2], (Iterations+7)/8)
+	proof2, err := NewProofFromBytes(bzs[:])
+	if assert.NoError(test, err) {
+		assert.Equal(test, 0, proof2.A.Cmp(proof.A))
+		assert.Equal(test, 0, proof2.B.Cmp(proof.B))
+		assert.Equal(test, bzs, proof2.Bytes(), "the encoding should be stable across a round trip")
+	}
+}
+
+func TestBatchVerify(t *testing.T) {
+	inputs := makeProofModInputs(t, 6)
+
+	// corrupt some of the inputs
+	inputs[1].Session = []byte("another session")
+	inputs[3].N = inputs[0].N
+	inputs[4].Proof = nil
+
+	test.CheckBatchVerify(t, len(inputs), batchVerifyFunc(inputs), verifyFunc(inputs), []int{1, 3, 4})
+	assert.Empty(t, BatchVerify(nil, 0))
+}
+
+func BenchmarkVerify20Parties(b *testing.B) {
+	inputs := makeProofModInputs(b, 20)
+	test.BenchmarkBatchVerify(b, len(inputs), batchVerifyFunc(inputs), verifyFunc(inputs))
+}
+
+func batchVerifyFunc(inputs []ProofModInput) func(concurrency int) []int {
+	return func(concurrency int) []int {
+		return BatchVerify(inputs, concurrency)
+	}
+}
+
+func verifyFunc(inputs []ProofModInput) func(i int) bool {
+	return func(i int) bool {
+		return inputs[i].Proof.Verify(inputs[i].Session, inputs[i].N)
+	}
+}
+
+// makeProofModInputs returns the inputs of `n` valid proofs over the Paillier moduli of the keygen fixtures
+func makeProofModInputs(tb testing.TB, n int) []ProofModInput {
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(test.TestParticipants)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	inputs := make([]ProofModInput, n)
+	for j := range inputs {
+		sk := fixtures[j%len(fixtures)].PaillierSK
+		session := append(append([]byte{}, Session...), byte(j))
+		proof, err := NewProof(session, sk.N, sk.P, sk.Q, rand.Reader)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		inputs[j] = ProofModInput{Proof: proof, Session: session, N: sk.N}
+	}
+	return inputs
+}
@@ -0,0 +1,51 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package mta
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+)
+
+type (
+	// ProofBobInput is a ProofBob with the public inputs it is verified against, for BatchVerifyProofBob.
+	ProofBobInput struct {
+		Proof                  *ProofBob
+		Session                []byte
+		PK                     *paillier.PublicKey
+		NTilde, H1, H2, C1, C2 *big.Int
+	}
+
+	// ProofBobWCInput is a ProofBobWC with the public inputs it is verified against, for BatchVerifyProofBobWC.
+	ProofBobWCInput struct {
+		Proof                  *ProofBobWC
+		Session                []byte
+		PK                     *paillier.PublicKey
+		NTilde, H1, H2, C1, C2 *big.Int
+		X                      *crypto.ECPoint
+	}
+)
+
+// BatchVerifyProofBob verifies the proofs concurrently as ProofBob.Verify does, see crypto.BatchVerify.
+func BatchVerifyProofBob(ec elliptic.Curve, inputs []ProofBobInput, concurrency int) []int {
+	return crypto.BatchVerify(len(inputs), concurrency, func(i int) bool {
+		in := inputs[i]
+		return in.Proof.Verify(in.Session, ec, in.PK, in.NTilde, in.H1, in.H2, in.C1, in.C2)
+	})
+}
+
+// BatchVerifyProofBobWC verifies the proofs concurrently as ProofBobWC.Verify does, see crypto.BatchVerify.
+func BatchVerifyProofBobWC(ec elliptic.Curve, inputs []ProofBobWCInput, concurrency int) []int {
+	return crypto.BatchVerify(len(inputs), concurrency, func(i int) bool {
+		in := inputs[i]
+		return in.Proof != nil && in.Proof.ProofBob != nil &&
+			in.Proof.Verify(in.Session, ec, in.PK, in.NTilde, in.H1, in.H2, in.C1, in.C2, in.X)
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package mta
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestBatchVerifyProofBobWC(t *testing.T) {
+	inputs := makeProofBobWCInputs(t, 6)
+
+	// corrupt some of the inputs
+	inputs[1].Session = []byte("another session")
+	inputs[4].C2 = new(big.Int).Add(inputs[4].C2, big.NewInt(1))
+	inputs[5].Proof = nil
+
+	test.CheckBatchVerify(t, len(inputs), batchVerifyWCFunc(inputs), verifyWCFunc(inputs), []int{1, 4, 5})
+	assert.Empty(t, BatchVerifyProofBobWC(tss.EC(), nil, 0))
+
+	// proofs without the X consistency check
+	bobInputs := make([]ProofBobInput, 3)
+	for j, in := range inputs[:len(bobInputs)] {
+		bj := common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N)
+		_, cB, _, pf, err := bobMid(in.Session, tss.EC(), in.PK, bj, in.C1, in.NTilde, in.H1, in.H2, rand.Reader)
+		assert.NoError(t, err)
+		bobInputs[j] = ProofBobInput{Proof: pf, Session: in.Session, PK: in.PK, NTilde: in.NTilde, H1: in.H1, H2: in.H2, C1: in.C1, C2: cB}
+	}
+	bobInputs[0].Session = Session
+	bobInputs[2].H1 = bobInputs[2].H2
+	assert.Equal(t, []int{0, 2}, BatchVerifyProofBob(tss.EC(), bobInputs, 2))
+}
+
+func BenchmarkVerifyProofBobWC20Parties(b *testing.B) {
+	inputs := makeProofBobWCInputs(b, 19)
+	test.BenchmarkBatchVerify(b, len(inputs), batchVerifyWCFunc(inputs), verifyWCFunc(inputs))
+}
+
+func batchVerifyWCFunc(inputs []ProofBobWCInput) func(concurrency int) []int {
+	return func(concurrency int) []int {
+		return BatchVerifyProofBobWC(tss.EC(), inputs, concurrency)
+	}
+}
+
+func verifyWCFunc(inputs []ProofBobWCInput) func(i int) bool {
+	return func(i int) bool {
+		in := inputs[i]
+		return in.Proof != nil && in.Proof.Verify(in.Session, tss.EC(), in.PK, in.NTilde, in.H1, in.H2, in.C1, in.C2, in.X)
+	}
+}
+
+// makeProofBobWCInputs returns the inputs of `n` valid proofs, as received by one party from its `n` counterparties
+func makeProofBobWCInputs(tb testing.TB, n int) []ProofBobWCInput {
+	q := tss.EC().Params().N
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	_, pk, err := paillier.GenerateKeyPair(ctx, rand.Reader, testPaillierKeyLength)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	NTilde, h1, h2, err := keygen.LoadNTildeH1H2FromTestFixture(0)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	cA, err := pk.Encrypt(rand.Reader, common.GetRandomPositiveInt(rand.Reader, q))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	inputs := make([]ProofBobWCInput, n)
+	for j := range inputs {
+		bj := common.GetRandomPositiveInt(rand.Reader, q)
+		Bj := crypto.ScalarBaseMult(tss.EC(), bj)
+		session := append(append([]byte{}, Session...), byte(j))
+		_, cB, _, pf, err := bobMidWC(session, tss.EC(), pk, bj, cA, NTilde, h1, h2, Bj, rand.Reader)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		inputs[j] = ProofBobWCInput{Proof: pf, Session: session, PK: pk, NTilde: NTilde, H1: h1, H2: h2, C1: cA, C2: cB, X: Bj}
+	}
+	return inputs
+}
@@ -0,0 +1,48 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package mta
+
+import (
+	"crypto/elliptic"
+	"io"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+)
+
+// The functions in this file are INSECURE: they skip the verification of the counterparty's proof.
+// They exist only so that the cost of each proof can be measured in benchmarks (see
+// tss.Parameters.SetInsecureSkipProofsForBenchmarkOnly) and must never be used with real keys.
+
+// BobMidInsecureSkipVerify is BobMid without the verification of Alice's range proof. For benchmarking ONLY.
+func BobMidInsecureSkipVerify(
+	Session []byte,
+	ec elliptic.Curve,
+	pkA *paillier.PublicKey,
+	b, cA, NTildeA, h1A, h2A *big.Int,
+	rand io.Reader,
+) (beta, cB, betaPrm *big.Int, piB *ProofBob, err error) {
+	return bobMid(Session, ec, pkA, b, cA, NTildeA, h1A, h2A, rand)
+}
+
+// BobMidWCInsecureSkipVerify is BobMidWC without the verification of Alice's range proof. For benchmarking ONLY.
+func BobMidWCInsecureSkipVerify(
+	Session []byte,
+	ec elliptic.Curve,
+	pkA *paillier.PublicKey,
+	b, cA, NTildeA, h1A, h2A *big.Int,
+	B *crypto.ECPoint,
+	rand io.Reader,
+) (beta, cB, betaPrm *big.Int, piB *ProofBobWC, err error) {
+	return bobMidWC(Session, ec, pkA, b, cA, NTildeA, h1A, h2A, B, rand)
+}
+
+// AliceEndInsecureSkipVerify is AliceEnd and AliceEndWC without the verification of Bob's proof. For benchmarking ONLY.
+func AliceEndInsecureSkipVerify(ec elliptic.Curve, cB *big.Int, sk *paillier.PrivateKey) (*big.Int, error) {
+	return aliceEnd(ec, cB, sk)
+}
@@ -101,14 +101,18 @@ func ProveBobWC(Session []byte, ec elliptic.Curve, pk *paillier.PublicKey, NTild
 	// 11-12. e'
 	var e *big.Int
 	{ // must use RejectionSample
-		var eHash *big.Int
+		tr := common.NewTranscript(Session)
+		tr.Append("pk", pk.AsInts()...)
 		// X is nil if called by ProveBob (Bob's proof "without check")
-		if X == nil {
-			eHash = common.SHA512_256i_TAGGED(Session, append(pk.AsInts(), c1, c2, z, zPrm, t, v, w)...)
-		} else {
-			eHash = common.SHA512_256i_TAGGED(Session, append(pk.AsInts(), X.X(), X.Y(), c1, c2, u.X(), u.Y(), z, zPrm, t, v, w)...)
+		if X != nil {
+			tr.Append("X", X.X(), X.Y())
 		}
-		e = common.RejectionSample(q, eHash)
+		tr.Append("c", c1, c2)
+		if X != nil {
+			tr.Append("u", u.X(), u.Y())
+		}
+		tr.Append("z", z, zPrm, t, v, w)
+		e = tr.Challenge(q)
 	}
 
 	// 13.
@@ -272,17 +276,21 @@ func (pf *ProofBobWC) Verify(Session []byte, ec elliptic.Curve, pk *paillier.Pub
 	// 1-2. e'
 	var e *big.Int
 	{ // must use RejectionSample
-		var eHash *big.Int
+		tr := common.NewTranscript(Session)
+		tr.Append("pk", pk.AsInts()...)
 		// X is nil if called on a ProveBob (Bob's proof "without check")
-		if X == nil {
-			eHash = common.SHA512_256i_TAGGED(Session, append(pk.AsInts(), c1, c2, pf.Z, pf.ZPrm, pf.T, pf.V, pf.W)...)
-		} else {
+		if X != nil {
 			if !tss.SameCurve(ec, X.Curve()) {
 				return false
 			}
-			eHash = common.SHA512_256i_TAGGED(Session, append(pk.AsInts(), X.X(), X.Y(), c1, c2, pf.U.X(), pf.U.Y(), pf.Z, pf.ZPrm, pf.T, pf.V, pf.W)...)
+			tr.Append("X", X.X(), X.Y())
+		}
+		tr.Append("c", c1, c2)
+		if X != nil {
+			tr.Append("u", pf.U.X(), pf.U.Y())
 		}
-		e = common.RejectionSample(q, eHash)
+		tr.Append("z", pf.Z, pf.ZPrm, pf.T, pf.V, pf.W)
+		e = tr.Challenge(q)
 	}
 
 	var left, right *big.Int // for the following conditionals
@@ -366,6 +374,28 @@ func (pf *ProofBobWC) ValidateBasic() bool {
 	return pf.ProofBob.ValidateBasic() && pf.U != nil
 }
 
+func (pf *ProofBob) ExpectedParts() int {
+	return ProofBobBytesParts
+}
+
+// ExpectedMaxPartLen bounds the parts: Z, Z', T, W < NTilde; V < N^2; S < N; S1 < 2q^3; S2, T2 < 2q^3*NTilde;
+// T1 < 2q^7.
+func (pf *ProofBob) ExpectedMaxPartLen(params crypto.ProofSizeParams) int {
+	q := params.QBits()
+	return crypto.BitsToBytes(params.NTildeBits, 2*params.PaillierNBits, 3*q+1, 3*q+params.NTildeBits+1, 7*q+1)
+}
+
+func (pf *ProofBobWC) ExpectedParts() int {
+	return ProofBobWCBytesParts
+}
+
+// ExpectedMaxPartLen bounds the parts as for ProofBob, plus the coordinates of U.
+func (pf *ProofBobWC) ExpectedMaxPartLen(params crypto.ProofSizeParams) int {
+	q := params.QBits()
+	return crypto.BitsToBytes(params.NTildeBits, 2*params.PaillierNBits, 3*q+1, 3*q+params.NTildeBits+1, 7*q+1,
+		params.EC.Params().BitSize)
+}
+
 func (pf *ProofBob) Bytes() [ProofBobBytesParts][]byte {
 	return [...][]byte{
 		pf.Z.Bytes(),
@@ -385,8 +415,8 @@ func (pf *ProofBobWC) Bytes() [ProofBobWCBytesParts][]byte {
 	var out [ProofBobWCBytesParts][]byte
 	bobBzs := pf.ProofBob.Bytes()
 	bobBzsSlice := bobBzs[:]
-	bobBzsSlice = append(bobBzsSlice, pf.U.X().Bytes())
-	bobBzsSlice = append(bobBzsSlice, pf.U.Y().Bytes())
+	uX, uY := pf.U.CoordinateBytes()
+	bobBzsSlice = append(bobBzsSlice, uX, uY)
 	copy(out[:], bobBzsSlice[:12])
 	return out
 }
@@ -14,6 +14,7 @@ import (
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
 )
 
@@ -205,6 +206,16 @@ func (pf *RangeProofAlice) ValidateBasic() bool {
 		pf.S2 != nil
 }
 
+func (pf *RangeProofAlice) ExpectedParts() int {
+	return RangeProofAliceBytesParts
+}
+
+// ExpectedMaxPartLen bounds the parts: Z, W < NTilde; U < N^2; S < N; S1 < 2q^3; S2 < 2q^3*NTilde.
+func (pf *RangeProofAlice) ExpectedMaxPartLen(params crypto.ProofSizeParams) int {
+	q := params.QBits()
+	return crypto.BitsToBytes(params.NTildeBits, 2*params.PaillierNBits, 3*q+1, 3*q+params.NTildeBits+1)
+}
+
 func (pf *RangeProofAlice) Bytes() [RangeProofAliceBytesParts][]byte {
 	return [...][]byte{
 		pf.Z.Bytes(),
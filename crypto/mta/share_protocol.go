@@ -9,6 +9,7 @@ package mta
 import (
 	"crypto/elliptic"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
 
@@ -43,10 +44,23 @@ func BobMid(
 		err = errors.New("RangeProofAlice.Verify() returned false")
 		return
 	}
+	return bobMid(Session, ec, pkA, b, cA, NTildeA, h1A, h2A, rand)
+}
+
+func bobMid(
+	Session []byte,
+	ec elliptic.Curve,
+	pkA *paillier.PublicKey,
+	b, cA, NTildeA, h1A, h2A *big.Int,
+	rand io.Reader,
+) (beta, cB, betaPrm *big.Int, piB *ProofBob, err error) {
 	q := ec.Params().N
 	q5 := new(big.Int).Mul(q, q)  // q^2
 	q5 = new(big.Int).Mul(q5, q5) // q^4
 	q5 = new(big.Int).Mul(q5, q)  // q^5
+	if err = checkModulusLen(q, q5, pkA); err != nil {
+		return
+	}
 	betaPrm = common.GetRandomPositiveInt(rand, q5)
 	cBetaPrm, cRand, err := pkA.EncryptAndReturnRandomness(rand, betaPrm)
 	if err != nil {
@@ -65,6 +79,17 @@ func BobMid(
 	return
 }
 
+// checkModulusLen returns an error if Alice's Paillier modulus is too small for the curve order q: the plaintext
+// a*b + beta' of Bob's ciphertext, below q^2 + q^5, must not wrap around it. 2048-bit moduli fit curves of up to 384
+// bits, P-521 needs 3072 bits.
+func checkModulusLen(q, q5 *big.Int, pkA *paillier.PublicKey) error {
+	bound := new(big.Int).Add(q5, new(big.Int).Mul(q, q))
+	if pkA == nil || pkA.N == nil || pkA.N.Cmp(bound) <= 0 {
+		return fmt.Errorf("the paillier modulus must be greater than q^5+q^2, a %d-bit number", bound.BitLen())
+	}
+	return nil
+}
+
 func BobMidWC(
 	Session []byte,
 	ec elliptic.Curve,
@@ -78,10 +103,24 @@ func BobMidWC(
 		err = errors.New("RangeProofAlice.Verify() returned false")
 		return
 	}
+	return bobMidWC(Session, ec, pkA, b, cA, NTildeA, h1A, h2A, B, rand)
+}
+
+func bobMidWC(
+	Session []byte,
+	ec elliptic.Curve,
+	pkA *paillier.PublicKey,
+	b, cA, NTildeA, h1A, h2A *big.Int,
+	B *crypto.ECPoint,
+	rand io.Reader,
+) (beta, cB, betaPrm *big.Int, piB *ProofBobWC, err error) {
 	q := ec.Params().N
 	q5 := new(big.Int).Mul(q, q)  // q^2
 	q5 = new(big.Int).Mul(q5, q5) // q^4
 	q5 = new(big.Int).Mul(q5, q)  // q^5
+	if err = checkModulusLen(q, q5, pkA); err != nil {
+		return
+	}
 	betaPrm = common.GetRandomPositiveInt(rand, q5)
 	cBetaPrm, cRand, err := pkA.EncryptAndReturnRandomness(rand, betaPrm)
 	if err != nil {
@@ -111,12 +150,7 @@ func AliceEnd(
 	if !pf.Verify(Session, ec, pkA, NTildeA, h1A, h2A, cA, cB) {
 		return nil, errors.New("ProofBob.Verify() returned false")
 	}
-	alphaPrm, err := sk.Decrypt(cB)
-	if err != nil {
-		return nil, err
-	}
-	q := ec.Params().N
-	return new(big.Int).Mod(alphaPrm, q), nil
+	return aliceEnd(ec, cB, sk)
 }
 
 func AliceEndWC(
@@ -131,6 +165,10 @@ func AliceEndWC(
 	if !pf.Verify(Session, ec, pkA, NTildeA, h1A, h2A, cA, cB, B) {
 		return nil, errors.New("ProofBobWC.Verify() returned false")
 	}
+	return aliceEnd(ec, cB, sk)
+}
+
+func aliceEnd(ec elliptic.Curve, cB *big.Int, sk *paillier.PrivateKey) (*big.Int, error) {
 	alphaPrm, err := sk.Decrypt(cB)
 	if err != nil {
 		return nil, err
@@ -8,6 +8,7 @@ package mta
 
 import (
 	"context"
+	"crypto/elliptic"
 	"crypto/rand"
 	"math/big"
 	"testing"
@@ -52,6 +53,14 @@ func TestShareProtocol(t *testing.T) {
 	_, cB, betaPrm, pfB, err := BobMid(Session, tss.EC(), pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, rand.Reader)
 	assert.NoError(t, err)
 
+	sizes := crypto.ProofSizeParams{EC: tss.EC(), PaillierNBits: testPaillierKeyLength, NTildeBits: NTildei.BitLen()}
+	pfBzs, pfBBzs := pf.Bytes(), pfB.Bytes()
+	assert.True(t, crypto.ValidateProofBytes(pf, sizes, pfBzs[:]))
+	assert.True(t, crypto.ValidateProofBytes(pfB, sizes, pfBBzs[:]))
+	assert.False(t, crypto.ValidateProofBytes(pfB, sizes, pfBBzs[1:]), "a part is missing")
+	pfBBzs[3] = make([]byte, pfB.ExpectedMaxPartLen(sizes)+1)
+	assert.False(t, crypto.ValidateProofBytes(pfB, sizes, pfBBzs[:]), "a part is too long")
+
 	alpha, err := AliceEnd(Session, tss.EC(), pk, pfB, h1i, h2i, cA, cB, NTildei, sk)
 	assert.NoError(t, err)
 
@@ -88,6 +97,11 @@ func TestShareProtocolWC(t *testing.T) {
 	_, cB, betaPrm, pfB, err := BobMidWC(Session, tss.EC(), pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, gBPoint, rand.Reader)
 	assert.NoError(t, err)
 
+	sizes := crypto.ProofSizeParams{EC: tss.EC(), PaillierNBits: testPaillierKeyLength, NTildeBits: NTildei.BitLen()}
+	pfBBzs := pfB.Bytes()
+	assert.True(t, crypto.ValidateProofBytes(pfB, sizes, pfBBzs[:]))
+	assert.False(t, crypto.ValidateProofBytes(pfB.ProofBob, sizes, pfBBzs[:]), "ProofBob has fewer parts")
+
 	alpha, err := AliceEndWC(Session, tss.EC(), pk, pfB, gBPoint, cA, cB, NTildei, h1i, h2i, sk)
 	assert.NoError(t, err)
 
@@ -97,3 +111,143 @@ func TestShareProtocolWC(t *testing.T) {
 	aTimesBPlusBetaModQ := new(big.Int).Mod(aTimesBPlusBeta, q)
 	assert.Equal(t, 0, alpha.Cmp(aTimesBPlusBetaModQ))
 }
+
+func TestProveBobWCFixedRandomness(t *testing.T) {
+	q := tss.EC().Params().N
+	q5 := new(big.Int).Exp(q, big.NewInt(5), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	sk, pk, err := paillier.GenerateKeyPair(ctx, rand.Reader, testPaillierKeyLength)
+	assert.NoError(t, err)
+	NTilde, h1, h2, err := keygen.LoadNTildeH1H2FromTestFixture(0)
+	assert.NoError(t, err)
+
+	// fixed inputs, as in a test vector
+	a, b, betaPrm := big.NewInt(11), big.NewInt(22), new(big.Int).Sub(q5, big.NewInt(33))
+	rA, rB := big.NewInt(44), big.NewInt(55)
+	B := crypto.ScalarBaseMult(tss.EC(), b)
+
+	cA, err := pk.EncryptWithRandomness(a, rA)
+	assert.NoError(t, err)
+	cBetaPrm, err := pk.EncryptWithRandomness(betaPrm, rB)
+	assert.NoError(t, err)
+	cB, err := pk.HomoMult(b, cA)
+	assert.NoError(t, err)
+	cB, err = pk.HomoAdd(cB, cBetaPrm)
+	assert.NoError(t, err)
+
+	// the ciphertexts are reproducible
+	cA2, err := pk.EncryptWithRandomness(a, rA)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cA.Cmp(cA2))
+
+	pfB, err := ProveBobWC(Session, tss.EC(), pk, NTilde, h1, h2, cA, cB, b, betaPrm, rB, B, rand.Reader)
+	assert.NoError(t, err)
+	assert.True(t, pfB.Verify(Session, tss.EC(), pk, NTilde, h1, h2, cA, cB, B))
+	assert.False(t, pfB.Verify(Session, tss.EC(), pk, NTilde, h1, h2, cA, cBetaPrm, B))
+
+	alpha, err := sk.Decrypt(cB)
+	assert.NoError(t, err)
+	expected := new(big.Int).Add(new(big.Int).Mul(a, b), betaPrm)
+	assert.Equal(t, 0, alpha.Cmp(expected))
+}
+
+func TestProofBobWCBytesLeadingZeros(t *testing.T) {
+	// find a U whose X has a leading zero byte, which big.Int.Bytes() would drop
+	var U *crypto.ECPoint
+	for k := int64(1); U == nil; k++ {
+		if P := crypto.ScalarBaseMult(tss.EC(), big.NewInt(k)); P.X().BitLen() <= 248 {
+			U = P
+		}
+	}
+	bob := &ProofBob{}
+	for i, v := range []**big.Int{&bob.Z, &bob.ZPrm, &bob.T, &bob.V, &bob.W, &bob.S, &bob.S1, &bob.S2, &bob.T1, &bob.T2} {
+		*v = big.NewInt(int64(i + 1))
+	}
+	pf := &ProofBobWC{ProofBob: bob, U: U}
+
+	bzs := pf.Bytes()
+	assert.Len(t, bzs[10], 32, "the X of U should keep its leading zeros")
+	assert.Len(t, bzs[11], 32)
+	pf2, err := ProofBobWCFromBytes(tss.EC(), bzs[:])
+	if assert.NoError(t, err) {
+		assert.True(t, U.Equals(pf2.U))
+		assert.Equal(t, bzs, pf2.Bytes(), "the encoding should be stable across a round trip")
+	}
+}
+
+func TestShareProtocolLargerCurves(t *testing.T) {
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(2)
+	if !assert.NoError(t, err, "the keygen fixtures are required") {
+		return
+	}
+	sk := fixtures[0].PaillierSK
+	NTildei, h1i, h2i := fixtures[0].NTildei, fixtures[0].H1i, fixtures[0].H2i
+	NTildej, h1j, h2j := fixtures[1].NTildei, fixtures[1].H1i, fixtures[1].H2i
+
+	// q^5 fits a 2048-bit modulus for P-384
+	ec := elliptic.P384()
+	q := ec.Params().N
+	a := common.GetRandomPositiveInt(rand.Reader, q)
+	b := common.GetRandomPositiveInt(rand.Reader, q)
+	cA, pf, err := AliceInit(ec, &sk.PublicKey, a, NTildej, h1j, h2j, rand.Reader)
+	assert.NoError(t, err)
+	_, cB, betaPrm, pfB, err := BobMid(Session, ec, &sk.PublicKey, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, rand.Reader)
+	if !assert.NoError(t, err) {
+		return
+	}
+	alpha, err := AliceEnd(Session, ec, &sk.PublicKey, pfB, h1i, h2i, cA, cB, NTildei, sk)
+	assert.NoError(t, err)
+	aTimesBPlusBeta := new(big.Int).Add(new(big.Int).Mul(a, b), betaPrm)
+	assert.Equal(t, 0, alpha.Cmp(new(big.Int).Mod(aTimesBPlusBeta, q)))
+
+	// but not for P-521, whose shares would wrap around the modulus
+	ec = elliptic.P521()
+	a = common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+	b = common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+	cA, pf, err = AliceInit(ec, &sk.PublicKey, a, NTildej, h1j, h2j, rand.Reader)
+	assert.NoError(t, err)
+	_, _, _, _, err = BobMid(Session, ec, &sk.PublicKey, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, rand.Reader)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "the paillier modulus must be greater than q^5+q^2")
+	}
+	gBPoint := crypto.ScalarBaseMult(ec, b)
+	_, _, _, _, err = BobMidWC(Session, ec, &sk.PublicKey, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, gBPoint, rand.Reader)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "the paillier modulus must be greater than q^5+q^2")
+	}
+}
+
+func BenchmarkProveBobWC(b *testing.B) {
+	q := tss.EC().Params().N
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	_, pk, err := paillier.GenerateKeyPair(ctx, rand.Reader, testPaillierKeyLength)
+	if err != nil {
+		b.Fatal(err)
+	}
+	NTilde, h1, h2, err := keygen.LoadNTildeH1H2FromTestFixture(0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	cA, err := pk.Encrypt(rand.Reader, common.GetRandomPositiveInt(rand.Reader, q))
+	if err != nil {
+		b.Fatal(err)
+	}
+	x := common.GetRandomPositiveInt(rand.Reader, q)
+	X := crypto.ScalarBaseMult(tss.EC(), x)
+	y := common.GetRandomPositiveInt(rand.Reader, new(big.Int).Exp(q, big.NewInt(5), nil))
+	cB, r, err := pk.EncryptAndReturnRandomness(rand.Reader, y)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := ProveBobWC(Session, tss.EC(), pk, NTilde, h1, h2, cA, cB, x, y, r, X, rand.Reader); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package crypto
+
+import (
+	"crypto/elliptic"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type (
+	// msmElement is a point accumulator used by MultiScalarMult; nil represents the point at infinity,
+	// which ECPoint cannot hold
+	msmElement interface {
+		add(msmElement) msmElement
+		double() msmElement
+	}
+
+	// affine coordinates on any elliptic.Curve
+	msmAffine struct {
+		curve elliptic.Curve
+		x, y  *big.Int
+	}
+
+	// jacobian coordinates on secp256k1, avoiding a field inversion per addition
+	msmS256 struct {
+		p btcec.JacobianPoint
+	}
+)
+
+// MultiScalarMult computes sum(scalars[i] * points[i]) using the Pippenger (bucket) method.
+// This is faster than summing individual ScalarMult results once there are more than a handful of points,
+// e.g. in the Lagrange-in-the-exponent computations of keygen and resharing.
+// All points must be on the same curve; an error is returned if the result is the point at infinity.
+func MultiScalarMult(points []*ECPoint, scalars []*big.Int) (*ECPoint, error) {
+	if len(points) == 0 || len(points) != len(scalars) {
+		return nil, fmt.Errorf("MultiScalarMult: expected the same non-zero number of points and scalars (%d, %d)", len(points), len(scalars))
+	}
+	curve := points[0].Curve()
+	N := curve.Params().N
+	isS256 := curve.Params() == btcec.S256().Params()
+	ks := make([]*big.Int, len(scalars))
+	elems := make([]msmElement, len(points))
+	for i, p := range points {
+		if !p.ValidateBasic() || scalars[i] == nil {
+			return nil, fmt.Errorf("MultiScalarMult: invalid point or scalar at index %d", i)
+		}
+		if !sameCurve(p.Curve(), curve) {
+			return nil, fmt.Errorf("MultiScalarMult: point at index %d is on a different curve", i)
+		}
+		ks[i] = new(big.Int).Mod(scalars[i], N)
+		if isS256 {
+			elems[i] = newMSMS256(p)
+		} else {
+			elems[i] = &msmAffine{curve, p.coords[0], p.coords[1]}
+		}
+	}
+
+	c := msmWindowBits(len(points))
+	buckets := make([]msmElement, (1<<c)-1)
+	var acc msmElement
+	for w := (N.BitLen() + c - 1) / c; w > 0; w-- {
+		for d := 0; d < c && acc != nil; d++ {
+			acc = acc.double()
+		}
+		for b := range buckets {
+			buckets[b] = nil
+		}
+		for i, elem := range elems {
+			if idx := msmWindow(ks[i], (w-1)*c, c); idx > 0 {
+				buckets[idx-1] = msmAdd(buckets[idx-1], elem)
+			}
+		}
+		// running sum: bucket b is counted (b+1) times
+		var sum, windowSum msmElement
+		for b := len(buckets) - 1; b >= 0; b-- {
+			sum = msmAdd(sum, buckets[b])
+			windowSum = msmAdd(windowSum, sum)
+		}
+		acc = msmAdd(acc, windowSum)
+	}
+	return msmToECPoint(curve, acc)
+}
+
+// msmWindowBits picks the bucket window size, which grows roughly with log2 of the number of points
+// sameCurve tells whether two curves are the same: by their registered names when both are registered, as e.g.
+// tss.Edwards() returns a new instance on each call, and otherwise by the values of their parameters.
+func sameCurve(lhs, rhs elliptic.Curve) bool {
+	lName, lOk := tss.GetCurveName(lhs)
+	rName, rOk := tss.GetCurveName(rhs)
+	if lOk && rOk {
+		return lName == rName
+	}
+	l, r := lhs.Params(), rhs.Params()
+	return l == r || (l.P.Cmp(r.P) == 0 && l.N.Cmp(r.N) == 0 && l.Gx.Cmp(r.Gx) == 0 && l.Gy.Cmp(r.Gy) == 0)
+}
+
+func msmWindowBits(n int) int {
+	if n < 4 {
+		return 1
+	}
+	if c := bits.Len(uint(n)) - 1; c < 16 {
+		return c
+	}
+	return 16
+}
+
+func msmWindow(k *big.Int, offset, c int) int {
+	idx := 0
+	for b := c - 1; b >= 0; b-- {
+		idx = idx<<1 | int(k.Bit(offset+b))
+	}
+	return idx
+}
+
+// msmToECPoint converts an accumulator to an ECPoint without modifying it
+func msmToECPoint(curve elliptic.Curve, acc msmElement) (*ECPoint, error) {
+	switch res := acc.(type) {
+	case *msmAffine:
+		return NewECPoint(curve, res.x, res.y)
+	case *msmS256:
+		p := res.p
+		p.ToAffine()
+		x, y := new(big.Int).SetBytes(p.X.Bytes()[:]), new(big.Int).SetBytes(p.Y.Bytes()[:])
+		return NewECPoint(curve, x, y)
+	}
+	return nil, fmt.Errorf("MultiScalarMult: %w", ErrPointAtInfinity)
+}
+
+func msmAdd(a, b msmElement) msmElement {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	return a.add(b)
+}
+
+// ----- //
+
+func (a *msmAffine) add(b msmElement) msmElement {
+	x, y := a.curve.Add(a.x, a.y, b.(*msmAffine).x, b.(*msmAffine).y)
+	return newMSMAffine(a.curve, x, y)
+}
+
+func (a *msmAffine) double() msmElement {
+	x, y := a.curve.Double(a.x, a.y)
+	return newMSMAffine(a.curve, x, y)
+}
+
+// the short Weierstrass implementations return (0, 0) for the point at infinity
+func newMSMAffine(curve elliptic.Curve, x, y *big.Int) msmElement {
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return nil
+	}
+	return &msmAffine{curve, x, y}
+}
+
+func newMSMS256(p *ECPoint) *msmS256 {
+	res := new(msmS256)
+	res.p.X.SetByteSlice(p.coords[0].Bytes())
+	res.p.Y.SetByteSlice(p.coords[1].Bytes())
+	res.p.Z.SetInt(1)
+	return res
+}
+
+func (a *msmS256) add(b msmElement) msmElement {
+	res := new(msmS256)
+	btcec.AddNonConst(&a.p, &b.(*msmS256).p, &res.p)
+	if res.p.Z.IsZero() {
+		return nil
+	}
+	return res
+}
+
+func (a *msmS256) double() msmElement {
+	res := new(msmS256)
+	btcec.DoubleNonConst(&a.p, &res.p)
+	if res.p.Z.IsZero() {
+		return nil
+	}
+	return res
+}
@@ -0,0 +1,37 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package crypto
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestSameCurve(t *testing.T) {
+	assert.True(t, sameCurve(tss.Edwards(), tss.Edwards()), "registered curves are compared by name")
+	assert.False(t, sameCurve(tss.S256(), tss.Edwards()))
+	assert.False(t, sameCurve(elliptic.P256(), elliptic.P384()))
+
+	// curves that are not registered are compared by their parameters
+	params1, params2 := *elliptic.P256().Params(), *elliptic.P256().Params()
+	c1, c2 := &params1, &params2
+	_, ok := tss.GetCurveName(c1)
+	assert.False(t, ok)
+	assert.True(t, sameCurve(c1, c2))
+	assert.False(t, sameCurve(c1, elliptic.P384()))
+
+	G1 := NewECPointNoCurveCheck(c1, params1.Gx, params1.Gy)
+	G2 := NewECPointNoCurveCheck(c2, params2.Gx, params2.Gy)
+	sum, err := MultiScalarMult([]*ECPoint{G1, G2}, []*big.Int{big.NewInt(2), big.NewInt(3)})
+	assert.NoError(t, err, "MultiScalarMult should accept points on a curve that is not registered")
+	assert.True(t, sum.Equals(ScalarBaseMult(elliptic.P256(), big.NewInt(5))))
+}
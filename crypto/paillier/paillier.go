@@ -17,13 +17,19 @@ package paillier
 
 import (
 	"context"
+	"crypto"
+	_ "crypto/sha512"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	gmath "math"
 	"math/big"
 	"runtime"
 	"strconv"
+	"sync"
+	"sync/atomic"
 
 	"github.com/otiai10/primes"
 
@@ -47,6 +53,16 @@ type (
 		LambdaN, // lcm(p-1, q-1)
 		PhiN *big.Int // (p-1) * (q-1)
 		P, Q *big.Int
+
+		crt atomic.Value // *crtValues, computed on the first Decrypt
+	}
+
+	// crtValues are precomputed for decryption modulo P^2 and Q^2
+	crtValues struct {
+		pSquare, qSquare,
+		pMinus1, qMinus1,
+		hp, hq, // L_p(Gamma^(p-1) mod p^2)^-1 mod p, and the same for q
+		qInv *big.Int // q^-1 mod p
 	}
 
 	// Proof uses the new GenerateXs method in GG18Spec (6)
@@ -54,8 +70,12 @@ type (
 )
 
 var (
-	ErrMessageTooLong   = fmt.Errorf("the message is too large or < 0")
-	ErrMessageMalFormed = fmt.Errorf("the message is mal-formed")
+	ErrMessageTooLong    = fmt.Errorf("the message is too large or < 0")
+	ErrMessageMalFormed  = fmt.Errorf("the message is mal-formed")
+	ErrInvalidRandomness = fmt.Errorf("the randomness is not in Z_N*")
+
+	// validModulusBitLens are the lengths of N accepted when a key is unmarshaled, the same as keygen generates
+	validModulusBitLens = []int{2048, 3072, 4096}
 
 	zero = big.NewInt(0)
 	one  = big.NewInt(1)
@@ -116,14 +136,27 @@ func (publicKey *PublicKey) EncryptAndReturnRandomness(rand io.Reader, m *big.In
 		return nil, nil, ErrMessageTooLong
 	}
 	x = common.GetRandomPositiveRelativelyPrimeInt(rand, publicKey.N)
+	c, err = publicKey.EncryptWithRandomness(m, x)
+	return
+}
+
+// EncryptWithRandomness encrypts m with the caller-supplied randomness x, so that c = gamma^m * x^N mod N^2 is
+// reproducible, e.g. for test vectors or to re-derive the ciphertext used in an MtA proof.
+// m must be in [0, N) and x in Z_N*. x must never be reused to encrypt a different message under the same key.
+func (publicKey *PublicKey) EncryptWithRandomness(m, x *big.Int) (c *big.Int, err error) {
+	if m == nil || m.Cmp(zero) == -1 || m.Cmp(publicKey.N) != -1 { // m < 0 || m >= N ?
+		return nil, ErrMessageTooLong
+	}
+	if !common.IsNumberInMultiplicativeGroup(publicKey.N, x) {
+		return nil, ErrInvalidRandomness
+	}
 	N2 := publicKey.NSquare()
 	// 1. gamma^m mod N2
 	Gm := new(big.Int).Exp(publicKey.Gamma(), m, N2)
 	// 2. x^N mod N2
 	xN := new(big.Int).Exp(x, publicKey.N, N2)
 	// 3. (1) * (2) mod N2
-	c = common.ModInt(N2).Mul(Gm, xN)
-	return
+	return common.ModInt(N2).Mul(Gm, xN), nil
 }
 
 func (publicKey *PublicKey) Encrypt(rand io.Reader, m *big.Int) (c *big.Int, err error) {
@@ -180,6 +213,9 @@ func (privateKey *PrivateKey) Decrypt(c *big.Int) (m *big.Int, err error) {
 	if cg.Cmp(one) == 1 {
 		return nil, ErrMessageMalFormed
 	}
+	if crt := privateKey.crtValues(); crt != nil {
+		return privateKey.decryptCRT(crt, c), nil
+	}
 	// 1. L(u) = (c^LambdaN-1 mod N2) / N
 	Lc := L(new(big.Int).Exp(c, privateKey.LambdaN, N2), privateKey.N)
 	// 2. L(u) = (Gamma^LambdaN-1 mod N2) / N
@@ -190,6 +226,166 @@ func (privateKey *PrivateKey) Decrypt(c *big.Int) (m *big.Int, err error) {
 	return
 }
 
+// decryptCRT decrypts c modulo P^2 and Q^2 with exponents of half the size, then recombines the results:
+// m_p = L_p(c^(p-1) mod p^2) * hp mod p, m_q likewise, and m = m_q + q * ((m_p - m_q) * q^-1 mod p).
+func (privateKey *PrivateKey) decryptCRT(crt *crtValues, c *big.Int) *big.Int {
+	mp := L(new(big.Int).Exp(c, crt.pMinus1, crt.pSquare), privateKey.P)
+	mp = common.ModInt(privateKey.P).Mul(mp, crt.hp)
+	mq := L(new(big.Int).Exp(c, crt.qMinus1, crt.qSquare), privateKey.Q)
+	mq = common.ModInt(privateKey.Q).Mul(mq, crt.hq)
+	h := common.ModInt(privateKey.P).Mul(new(big.Int).Sub(mp, mq), crt.qInv)
+	return h.Mul(h, privateKey.Q).Add(h, mq)
+}
+
+// crtValues returns the values used by decryptCRT, computing them on the first call.
+// It returns nil when the key does not hold its primes, e.g. for old save data, so that Decrypt uses LambdaN instead.
+func (privateKey *PrivateKey) crtValues() *crtValues {
+	if crt, ok := privateKey.crt.Load().(*crtValues); ok {
+		return crt
+	}
+	P, Q := privateKey.P, privateKey.Q
+	if P == nil || Q == nil || P.Sign() <= 0 || Q.Sign() <= 0 || new(big.Int).Mul(P, Q).Cmp(privateKey.N) != 0 {
+		return nil
+	}
+	crt := &crtValues{
+		pSquare: new(big.Int).Mul(P, P),
+		qSquare: new(big.Int).Mul(Q, Q),
+		pMinus1: new(big.Int).Sub(P, one),
+		qMinus1: new(big.Int).Sub(Q, one),
+		qInv:    new(big.Int).ModInverse(Q, P),
+	}
+	crt.hp = new(big.Int).ModInverse(L(new(big.Int).Exp(privateKey.Gamma(), crt.pMinus1, crt.pSquare), P), P)
+	crt.hq = new(big.Int).ModInverse(L(new(big.Int).Exp(privateKey.Gamma(), crt.qMinus1, crt.qSquare), Q), Q)
+	if crt.qInv == nil || crt.hp == nil || crt.hq == nil {
+		return nil
+	}
+	privateKey.crt.Store(crt)
+	return crt
+}
+
+// ----- //
+
+// publicKeyJSON and privateKeyJSON hold the key ints as hex strings. P and Q are omitted when the key does not hold them.
+type (
+	publicKeyJSON struct {
+		N jsonInt
+	}
+
+	privateKeyJSON struct {
+		N, LambdaN, PhiN jsonInt
+		P                *jsonInt `json:",omitempty"`
+		Q                *jsonInt `json:",omitempty"`
+	}
+
+	// jsonInt is encoded as a hex string, and also decodes from a plain JSON number as written by earlier versions.
+	jsonInt struct {
+		*big.Int
+	}
+)
+
+func (i jsonInt) MarshalJSON() ([]byte, error) {
+	if i.Int == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(i.Text(16))
+}
+
+func (i *jsonInt) UnmarshalJSON(payload []byte) error {
+	i.Int = nil
+	if string(payload) == "null" {
+		return nil
+	}
+	if len(payload) > 0 && payload[0] == '"' {
+		var s string
+		if err := json.Unmarshal(payload, &s); err != nil {
+			return err
+		}
+		n, ok := new(big.Int).SetString(s, 16)
+		if !ok {
+			return fmt.Errorf("invalid hex integer %q", s)
+		}
+		i.Int = n
+		return nil
+	}
+	n := new(big.Int)
+	if err := n.UnmarshalJSON(payload); err != nil {
+		return err
+	}
+	i.Int = n
+	return nil
+}
+
+// validateModulus checks that N is odd and of one of the lengths accepted by keygen.
+func validateModulus(N *big.Int) error {
+	if N == nil {
+		return errors.New("N is missing")
+	}
+	if N.Bit(0) == 0 {
+		return errors.New("N must be odd")
+	}
+	for _, bits := range validModulusBitLens {
+		if N.BitLen() == bits {
+			return nil
+		}
+	}
+	return fmt.Errorf("N has %d bits, expected one of %v", N.BitLen(), validModulusBitLens)
+}
+
+func (publicKey *PublicKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&publicKeyJSON{N: jsonInt{publicKey.N}})
+}
+
+func (publicKey *PublicKey) UnmarshalJSON(payload []byte) error {
+	aux := new(publicKeyJSON)
+	if err := json.Unmarshal(payload, aux); err != nil {
+		return fmt.Errorf("paillier.PublicKey.UnmarshalJSON: %v", err)
+	}
+	if err := validateModulus(aux.N.Int); err != nil {
+		return fmt.Errorf("paillier.PublicKey.UnmarshalJSON: %v", err)
+	}
+	publicKey.N = aux.N.Int
+	return nil
+}
+
+// MarshalJSON must be declared for the PrivateKey too, as the method promoted from the embedded PublicKey would only write N.
+func (privateKey *PrivateKey) MarshalJSON() ([]byte, error) {
+	aux := &privateKeyJSON{
+		N:       jsonInt{privateKey.N},
+		LambdaN: jsonInt{privateKey.LambdaN},
+		PhiN:    jsonInt{privateKey.PhiN},
+	}
+	if privateKey.P != nil && privateKey.Q != nil {
+		aux.P, aux.Q = &jsonInt{privateKey.P}, &jsonInt{privateKey.Q}
+	}
+	return json.Marshal(aux)
+}
+
+func (privateKey *PrivateKey) UnmarshalJSON(payload []byte) error {
+	aux := new(privateKeyJSON)
+	if err := json.Unmarshal(payload, aux); err != nil {
+		return fmt.Errorf("paillier.PrivateKey.UnmarshalJSON: %v", err)
+	}
+	if err := validateModulus(aux.N.Int); err != nil {
+		return fmt.Errorf("paillier.PrivateKey.UnmarshalJSON: %v", err)
+	}
+	LambdaN, PhiN := aux.LambdaN.Int, aux.PhiN.Int
+	if LambdaN == nil || PhiN == nil || LambdaN.Sign() <= 0 || PhiN.Sign() <= 0 {
+		return errors.New("paillier.PrivateKey.UnmarshalJSON: LambdaN and PhiN must be positive")
+	}
+	if new(big.Int).Mod(PhiN, LambdaN).Sign() != 0 {
+		return errors.New("paillier.PrivateKey.UnmarshalJSON: LambdaN does not divide PhiN")
+	}
+	var P, Q *big.Int
+	if aux.P != nil && aux.Q != nil {
+		P, Q = aux.P.Int, aux.Q.Int
+		if P == nil || Q == nil || new(big.Int).Mul(P, Q).Cmp(aux.N.Int) != 0 {
+			return errors.New("paillier.PrivateKey.UnmarshalJSON: P * Q does not equal N")
+		}
+	}
+	*privateKey = PrivateKey{PublicKey: PublicKey{N: aux.N.Int}, LambdaN: LambdaN, PhiN: PhiN, P: P, Q: Q}
+	return nil
+}
+
 // ----- //
 
 // Proof is an implementation of Gennaro, R., Micciancio, D., Rabin, T.:
@@ -208,6 +404,12 @@ func (privateKey *PrivateKey) Proof(k *big.Int, ecdsaPub *crypto2.ECPoint) Proof
 }
 
 func (pf Proof) Verify(pkN, k *big.Int, ecdsaPub *crypto2.ECPoint) (bool, error) {
+	return pf.VerifyWithConcurrency(pkN, k, ecdsaPub, 0)
+}
+
+// VerifyWithConcurrency is Verify generating the challenges with GenerateXsWithConcurrency on at most `concurrency`
+// workers (runtime.NumCPU() when `concurrency` < 1), e.g. to share the CPUs between the proofs of several parties.
+func (pf Proof) VerifyWithConcurrency(pkN, k *big.Int, ecdsaPub *crypto2.ECPoint, concurrency int) (bool, error) {
 	iters := ProofIters
 	pch, xch := make(chan bool, 1), make(chan []*big.Int, 1) // buffered to allow early exit
 	prms := primes.Until(verifyPrimesUntil).List()           // uses cache primed in init()
@@ -222,7 +424,7 @@ func (pf Proof) Verify(pkN, k *big.Int, ecdsaPub *crypto2.ECPoint) (bool, error)
 		ch <- true
 	}(pch)
 	go func(ch chan<- []*big.Int) {
-		ch <- GenerateXs(iters, k, pkN, ecdsaPub)
+		ch <- GenerateXsWithConcurrency(iters, k, pkN, ecdsaPub, concurrency)
 	}(xch)
 	for j := 0; j < 2; j++ {
 		select {
@@ -255,33 +457,63 @@ func L(u, N *big.Int) *big.Int {
 
 // GenerateXs generates the challenges used in Paillier key Proof
 func GenerateXs(m int, k, N *big.Int, ecdsaPub *crypto2.ECPoint) []*big.Int {
+	return GenerateXsWithConcurrency(m, k, N, ecdsaPub, 0)
+}
+
+// GenerateXsWithConcurrency is GenerateXs hashing the 256-bit blocks of each challenge on at most `concurrency`
+// workers (runtime.NumCPU() when `concurrency` < 1), which live for the whole call and reuse their hash states.
+// The challenges do not depend on `concurrency`.
+func GenerateXsWithConcurrency(m int, k, N *big.Int, ecdsaPub *crypto2.ECPoint, concurrency int) []*big.Int {
 	var i, n int
 	ret := make([]*big.Int, m)
 	sX, sY := ecdsaPub.X(), ecdsaPub.Y()
 	kb, sXb, sYb, Nb := k.Bytes(), sX.Bytes(), sY.Bytes(), N.Bytes()
 	bits := N.BitLen()
 	blocks := int(gmath.Ceil(float64(bits) / 256))
-	chs := make([]chan []byte, blocks)
-	for k := range chs {
-		chs[k] = make(chan []byte)
+	hashBlock := func(state hash.Hash, xi, ib, nb []byte, j int) {
+		jBz := []byte(strconv.Itoa(j))
+		rx := common.SHA512_256With(state, ib, jBz, nb, kb, sXb, sYb, Nb)
+		if rx == nil { // this should never happen. see: https://golang.org/pkg/hash/#Hash
+			panic(errors.New("GenerateXs hash write error!"))
+		}
+		copy(xi[j*32:], rx) // xi1||···||xib
+	}
+
+	type block struct {
+		xi, ib, nb []byte
+		j          int
+		wg         *sync.WaitGroup
+	}
+	var jobs chan block
+	if workers := generateXsWorkers(concurrency, blocks); workers > 1 {
+		jobs = make(chan block, blocks)
+		defer close(jobs) // stops the workers
+		for w := 0; w < workers; w++ {
+			go func() {
+				state := crypto.SHA512_256.New()
+				for b := range jobs {
+					hashBlock(state, b.xi, b.ib, b.nb, b.j)
+					b.wg.Done()
+				}
+			}()
+		}
 	}
+	state := crypto.SHA512_256.New()
+	wg := new(sync.WaitGroup)
 	for i < m {
-		xi := make([]byte, 0, blocks*32)
+		xi := make([]byte, blocks*32)
 		ib := []byte(strconv.Itoa(i))
 		nb := []byte(strconv.Itoa(n))
-		for j := 0; j < blocks; j++ {
-			go func(j int) {
-				jBz := []byte(strconv.Itoa(j))
-				hash := common.SHA512_256(ib, jBz, nb, kb, sXb, sYb, Nb)
-				chs[j] <- hash
-			}(j)
-		}
-		for _, ch := range chs { // must be in order
-			rx := <-ch
-			if rx == nil { // this should never happen. see: https://golang.org/pkg/hash/#Hash
-				panic(errors.New("GenerateXs hash write error!"))
+		if jobs == nil {
+			for j := 0; j < blocks; j++ {
+				hashBlock(state, xi, ib, nb, j)
+			}
+		} else {
+			wg.Add(blocks)
+			for j := 0; j < blocks; j++ {
+				jobs <- block{xi: xi, ib: ib, nb: nb, j: j, wg: wg}
 			}
-			xi = append(xi, rx...) // xi1||···||xib
+			wg.Wait()
 		}
 		ret[i] = new(big.Int).SetBytes(xi)
 		if common.IsNumberInMultiplicativeGroup(N, ret[i]) {
@@ -292,3 +524,14 @@ func GenerateXs(m int, k, N *big.Int, ecdsaPub *crypto2.ECPoint) []*big.Int {
 	}
 	return ret
 }
+
+// generateXsWorkers returns the number of workers of GenerateXsWithConcurrency, which needs no more than one per block
+func generateXsWorkers(concurrency, blocks int) int {
+	if concurrency < 1 {
+		concurrency = runtime.NumCPU()
+	}
+	if blocks < concurrency {
+		return blocks
+	}
+	return concurrency
+}
@@ -9,7 +9,11 @@ package paillier_test
 import (
 	"context"
 	"crypto/rand"
+	"encoding/json"
+	"fmt"
 	"math/big"
+	"runtime"
+	"strconv"
 	"testing"
 	"time"
 
@@ -59,6 +63,30 @@ func TestEncrypt(t *testing.T) {
 	t.Log(cipher)
 }
 
+func TestEncryptWithRandomness(t *testing.T) {
+	setUp(t)
+	m := common.GetRandomPositiveInt(rand.Reader, publicKey.N)
+	c, x, err := publicKey.EncryptAndReturnRandomness(rand.Reader, m)
+	assert.NoError(t, err)
+	c2, err := publicKey.EncryptWithRandomness(m, x)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, c.Cmp(c2))
+	m2, err := privateKey.Decrypt(c2)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, m.Cmp(m2))
+
+	_, err = publicKey.EncryptWithRandomness(new(big.Int).Neg(m), x)
+	assert.Equal(t, ErrMessageTooLong, err)
+	_, err = publicKey.EncryptWithRandomness(publicKey.N, x)
+	assert.Equal(t, ErrMessageTooLong, err)
+	_, err = publicKey.EncryptWithRandomness(nil, x)
+	assert.Equal(t, ErrMessageTooLong, err)
+	for _, bad := range []*big.Int{nil, big.NewInt(0), privateKey.P, publicKey.N, new(big.Int).Add(publicKey.N, x)} {
+		_, err = publicKey.EncryptWithRandomness(m, bad)
+		assert.Equal(t, ErrInvalidRandomness, err)
+	}
+}
+
 func TestEncryptDecrypt(t *testing.T) {
 	setUp(t)
 	exp := big.NewInt(100)
@@ -76,6 +104,100 @@ func TestEncryptDecrypt(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestDecryptCRT(t *testing.T) {
+	setUp(t)
+	// without its primes the key decrypts with LambdaN
+	noPrimes := &PrivateKey{PublicKey: privateKey.PublicKey, LambdaN: privateKey.LambdaN, PhiN: privateKey.PhiN}
+	N2 := privateKey.NSquare()
+	for i := 0; i < 20; i++ {
+		var c *big.Int
+		if i%2 == 0 {
+			m := common.GetRandomPositiveInt(rand.Reader, privateKey.N)
+			var err error
+			c, err = publicKey.Encrypt(rand.Reader, m)
+			assert.NoError(t, err)
+		} else {
+			c = common.GetRandomPositiveRelativelyPrimeInt(rand.Reader, N2)
+		}
+		got, err := privateKey.Decrypt(c)
+		assert.NoError(t, err)
+		want, err := noPrimes.Decrypt(c)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, want.Cmp(got), "the CRT decryption must match")
+	}
+	_, err := privateKey.Decrypt(new(big.Int).Set(privateKey.P))
+	assert.Equal(t, ErrMessageMalFormed, err)
+	_, err = privateKey.Decrypt(N2)
+	assert.Equal(t, ErrMessageTooLong, err)
+}
+
+func BenchmarkDecrypt(b *testing.B) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	sk, pk, err := GenerateKeyPair(ctx, rand.Reader, testPaillierKeyLength)
+	if err != nil {
+		b.Fatal(err)
+	}
+	c, err := pk.Encrypt(rand.Reader, big.NewInt(42))
+	if err != nil {
+		b.Fatal(err)
+	}
+	noPrimes := &PrivateKey{PublicKey: sk.PublicKey, LambdaN: sk.LambdaN, PhiN: sk.PhiN}
+	b.Run("LambdaN", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = noPrimes.Decrypt(c)
+		}
+	})
+	b.Run("CRT", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = sk.Decrypt(c)
+		}
+	})
+}
+
+func TestKeysJSON(t *testing.T) {
+	setUp(t)
+	bz, err := json.Marshal(privateKey)
+	assert.NoError(t, err)
+	sk := new(PrivateKey)
+	assert.NoError(t, json.Unmarshal(bz, sk))
+	assert.Equal(t, 0, sk.N.Cmp(privateKey.N))
+	assert.Equal(t, 0, sk.LambdaN.Cmp(privateKey.LambdaN))
+	assert.Equal(t, 0, sk.PhiN.Cmp(privateKey.PhiN))
+	assert.Equal(t, 0, sk.P.Cmp(privateKey.P))
+	assert.Equal(t, 0, sk.Q.Cmp(privateKey.Q))
+
+	bz, err = json.Marshal(publicKey)
+	assert.NoError(t, err)
+	pk := new(PublicKey)
+	assert.NoError(t, json.Unmarshal(bz, pk))
+	assert.Equal(t, 0, pk.N.Cmp(publicKey.N))
+
+	// save data written by earlier versions holds the ints as JSON numbers
+	legacy := fmt.Sprintf(`{"N":%s,"LambdaN":%s,"PhiN":%s}`, privateKey.N, privateKey.LambdaN, privateKey.PhiN)
+	sk = new(PrivateKey)
+	assert.NoError(t, json.Unmarshal([]byte(legacy), sk))
+	assert.Equal(t, 0, sk.LambdaN.Cmp(privateKey.LambdaN))
+	assert.Nil(t, sk.P)
+}
+
+func TestKeysJSONInvalid(t *testing.T) {
+	setUp(t)
+	hexN := privateKey.N.Text(16)
+	truncated := fmt.Sprintf(`{"N":"%s"}`, hexN[:len(hexN)-2])
+	assert.Error(t, json.Unmarshal([]byte(truncated), new(PublicKey)), "a truncated N must be rejected")
+	even := fmt.Sprintf(`{"N":"%s"}`, new(big.Int).Add(privateKey.N, big.NewInt(1)).Text(16))
+	assert.Error(t, json.Unmarshal([]byte(even), new(PublicKey)), "an even N must be rejected")
+	assert.Error(t, json.Unmarshal([]byte(`{"N":"not hex"}`), new(PublicKey)))
+
+	mismatched := fmt.Sprintf(`{"N":"%s","LambdaN":"%s","PhiN":"%s"}`,
+		hexN, new(big.Int).Add(privateKey.LambdaN, big.NewInt(2)).Text(16), privateKey.PhiN.Text(16))
+	assert.Error(t, json.Unmarshal([]byte(mismatched), new(PrivateKey)), "a LambdaN which does not divide PhiN must be rejected")
+	wrongP := fmt.Sprintf(`{"N":"%s","LambdaN":"%s","PhiN":"%s","P":"%s","Q":"%s"}`,
+		hexN, privateKey.LambdaN.Text(16), privateKey.PhiN.Text(16), privateKey.Q.Text(16), privateKey.Q.Text(16))
+	assert.Error(t, json.Unmarshal([]byte(wrongP), new(PrivateKey)))
+}
+
 func TestHomoMul(t *testing.T) {
 	setUp(t)
 	three, err := privateKey.Encrypt(rand.Reader, big.NewInt(3))
@@ -155,3 +277,85 @@ func TestGenerateXs(t *testing.T) {
 		assert.True(t, common.IsNumberInMultiplicativeGroup(N, xi))
 	}
 }
+
+// generateXsPerBlock is GenerateXs as it was before the worker pool, with a goroutine for each block of each
+// candidate, counting the goroutines into `spawned`
+func generateXsPerBlock(m int, k, N *big.Int, ecdsaPub *crypto.ECPoint, spawned *int64) []*big.Int {
+	var i, n int
+	ret := make([]*big.Int, m)
+	kb, sXb, sYb, Nb := k.Bytes(), ecdsaPub.X().Bytes(), ecdsaPub.Y().Bytes(), N.Bytes()
+	blocks := (N.BitLen() + 255) / 256
+	chs := make([]chan []byte, blocks)
+	for k := range chs {
+		chs[k] = make(chan []byte)
+	}
+	for i < m {
+		xi := make([]byte, 0, blocks*32)
+		ib, nb := []byte(strconv.Itoa(i)), []byte(strconv.Itoa(n))
+		for j := 0; j < blocks; j++ {
+			*spawned++
+			go func(j int) {
+				chs[j] <- common.SHA512_256(ib, []byte(strconv.Itoa(j)), nb, kb, sXb, sYb, Nb)
+			}(j)
+		}
+		for _, ch := range chs {
+			xi = append(xi, <-ch...)
+		}
+		ret[i] = new(big.Int).SetBytes(xi)
+		if common.IsNumberInMultiplicativeGroup(N, ret[i]) {
+			i++
+		} else {
+			n++
+		}
+	}
+	return ret
+}
+
+func TestGenerateXsWithConcurrencyIsUnchanged(t *testing.T) {
+	k := common.MustGetRandomInt(rand.Reader, 256)
+	sX := common.MustGetRandomInt(rand.Reader, 256)
+	sY := common.MustGetRandomInt(rand.Reader, 256)
+	pub := crypto.NewECPointNoCurveCheck(tss.EC(), sX, sY)
+	for _, bits := range []int{2048, 3072} {
+		N := common.MustGetRandomInt(rand.Reader, bits) // the rejections of the non-coprime candidates are exercised too
+		var spawned int64
+		expected := generateXsPerBlock(ProofIters, k, N, pub, &spawned)
+		for _, concurrency := range []int{0, 1, 2, 3, runtime.NumCPU(), 64} {
+			actual := GenerateXsWithConcurrency(ProofIters, k, N, pub, concurrency)
+			assert.Equal(t, expected, actual, "%d bits, concurrency %d", bits, concurrency)
+		}
+	}
+}
+
+func BenchmarkGenerateXs(b *testing.B) {
+	k := common.MustGetRandomInt(rand.Reader, 256)
+	pub := crypto.ScalarBaseMult(tss.EC(), k)
+	for _, bits := range []int{2048, 3072} {
+		N := common.MustGetRandomInt(rand.Reader, bits)
+		N.SetBit(N, bits-1, 1).SetBit(N, 0, 1)
+		blocks := (bits + 255) / 256
+		b.Run(fmt.Sprintf("%d/per-block", bits), func(b *testing.B) {
+			var spawned int64
+			for i := 0; i < b.N; i++ {
+				generateXsPerBlock(ProofIters, k, N, pub, &spawned)
+			}
+			b.ReportMetric(float64(spawned)/float64(b.N), "goroutines/op")
+		})
+		for _, concurrency := range []int{1, 2, 4} {
+			b.Run(fmt.Sprintf("%d/pool-%d", bits, concurrency), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					GenerateXsWithConcurrency(ProofIters, k, N, pub, concurrency)
+				}
+				// the workers; a single one runs on the calling goroutine
+				workers := concurrency
+				if blocks < workers {
+					workers = blocks
+				}
+				if workers == 1 {
+					workers = 0
+				}
+				b.ReportMetric(float64(workers), "goroutines/op")
+			})
+		}
+	}
+}
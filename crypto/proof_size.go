@@ -0,0 +1,60 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package crypto
+
+import (
+	"crypto/elliptic"
+)
+
+type (
+	// ProofSizeParams holds the sizes that bound the serialized length of the proofs.
+	// Keygen generates 2048-bit Paillier and NTilde moduli by default, and accepts 3072 and 4096 bits.
+	ProofSizeParams struct {
+		EC            elliptic.Curve
+		PaillierNBits int // bit length of the Paillier modulus N
+		NTildeBits    int // bit length of the NTilde modulus
+	}
+
+	// ProofSchema describes the wire structure of a proof that is serialized as byte parts, so that it can be checked
+	// without being parsed, e.g. by a message filter in front of the parties.
+	ProofSchema interface {
+		// ExpectedParts returns the number of byte parts of the serialized proof
+		ExpectedParts() int
+		// ExpectedMaxPartLen returns the maximum byte length of any part of a proof produced by an honest prover
+		ExpectedMaxPartLen(params ProofSizeParams) int
+	}
+)
+
+// QBits returns the bit length of the curve order q.
+func (params ProofSizeParams) QBits() int {
+	return params.EC.Params().N.BitLen()
+}
+
+// ValidateProofBytes checks that `bzs` has the number of parts expected by `schema` and that no part is too long.
+func ValidateProofBytes(schema ProofSchema, params ProofSizeParams, bzs [][]byte) bool {
+	if len(bzs) != schema.ExpectedParts() {
+		return false
+	}
+	maxLen := schema.ExpectedMaxPartLen(params)
+	for _, bz := range bzs {
+		if len(bz) > maxLen {
+			return false
+		}
+	}
+	return true
+}
+
+// BitsToBytes returns the byte length of the largest of the given bit lengths.
+func BitsToBytes(bits ...int) int {
+	max := 0
+	for _, b := range bits {
+		if b > max {
+			max = b
+		}
+	}
+	return (max + 7) / 8
+}
@@ -7,7 +7,9 @@
 package schnorr
 
 import (
+	"crypto/elliptic"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
 
@@ -15,6 +17,11 @@ import (
 	"github.com/bnb-chain/tss-lib/v2/crypto"
 )
 
+const (
+	ZKProofBytesParts  = 3
+	ZKVProofBytesParts = 4
+)
+
 type (
 	ZKProof struct {
 		Alpha *crypto.ECPoint
@@ -66,7 +73,7 @@ func (pf *ZKProof) Verify(Session []byte, X *crypto.ECPoint) bool {
 		cHash := common.SHA512_256i_TAGGED(Session, X.X(), X.Y(), g.X(), g.Y(), pf.Alpha.X(), pf.Alpha.Y())
 		c = common.RejectionSample(q, cHash)
 	}
-	tG := crypto.ScalarBaseMult(ec, pf.T)
+	tG := crypto.BaseMultTable(ec).VarTimeScalarBaseMult(pf.T)
 	Xc := X.ScalarMult(c)
 	aXc, err := pf.Alpha.Add(Xc)
 	if err != nil {
@@ -79,6 +86,37 @@ func (pf *ZKProof) ValidateBasic() bool {
 	return pf.T != nil && pf.Alpha != nil
 }
 
+// Bytes returns the parts of the proof: the coordinates of Alpha, padded to the byte length of the curve, and T.
+func (pf *ZKProof) Bytes() [ZKProofBytesParts][]byte {
+	alphaX, alphaY := pf.Alpha.CoordinateBytes()
+	return [...][]byte{alphaX, alphaY, pf.T.Bytes()}
+}
+
+func ZKProofFromBytes(ec elliptic.Curve, bzs [][]byte) (*ZKProof, error) {
+	if !common.NonEmptyMultiBytes(bzs, ZKProofBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ZKProof", ZKProofBytesParts)
+	}
+	point, err := crypto.NewECPoint(ec,
+		new(big.Int).SetBytes(bzs[0]),
+		new(big.Int).SetBytes(bzs[1]))
+	if err != nil {
+		return nil, err
+	}
+	return &ZKProof{
+		Alpha: point,
+		T:     new(big.Int).SetBytes(bzs[2]),
+	}, nil
+}
+
+func (pf *ZKProof) ExpectedParts() int {
+	return ZKProofBytesParts
+}
+
+// ExpectedMaxPartLen bounds the parts: the coordinates of Alpha; T < q.
+func (pf *ZKProof) ExpectedMaxPartLen(params crypto.ProofSizeParams) int {
+	return crypto.BitsToBytes(params.EC.Params().BitSize, params.QBits())
+}
+
 // NewZKProof constructs a new Schnorr ZK proof of knowledge s_i, l_i such that V_i = R^s_i, g^l_i (GG18Spec Fig. 17)
 func NewZKVProof(Session []byte, V, R *crypto.ECPoint, s, l *big.Int, rand io.Reader) (*ZKVProof, error) {
 	if V == nil || R == nil || s == nil || l == nil || !V.ValidateBasic() || !R.ValidateBasic() {
@@ -121,7 +159,7 @@ func (pf *ZKVProof) Verify(Session []byte, V, R *crypto.ECPoint) bool {
 		c = common.RejectionSample(q, cHash)
 	}
 	tR := R.ScalarMult(pf.T)
-	uG := crypto.ScalarBaseMult(ec, pf.U)
+	uG := crypto.BaseMultTable(ec).VarTimeScalarBaseMult(pf.U)
 	tRuG, _ := tR.Add(uG) // already on the curve.
 
 	Vc := V.ScalarMult(c)
@@ -135,3 +173,35 @@ func (pf *ZKVProof) Verify(Session []byte, V, R *crypto.ECPoint) bool {
 func (pf *ZKVProof) ValidateBasic() bool {
 	return pf.Alpha != nil && pf.T != nil && pf.U != nil && pf.Alpha.ValidateBasic()
 }
+
+// Bytes returns the parts of the proof: the coordinates of Alpha, padded to the byte length of the curve, T and U.
+func (pf *ZKVProof) Bytes() [ZKVProofBytesParts][]byte {
+	alphaX, alphaY := pf.Alpha.CoordinateBytes()
+	return [...][]byte{alphaX, alphaY, pf.T.Bytes(), pf.U.Bytes()}
+}
+
+func ZKVProofFromBytes(ec elliptic.Curve, bzs [][]byte) (*ZKVProof, error) {
+	if !common.NonEmptyMultiBytes(bzs, ZKVProofBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ZKVProof", ZKVProofBytesParts)
+	}
+	point, err := crypto.NewECPoint(ec,
+		new(big.Int).SetBytes(bzs[0]),
+		new(big.Int).SetBytes(bzs[1]))
+	if err != nil {
+		return nil, err
+	}
+	return &ZKVProof{
+		Alpha: point,
+		T:     new(big.Int).SetBytes(bzs[2]),
+		U:     new(big.Int).SetBytes(bzs[3]),
+	}, nil
+}
+
+func (pf *ZKVProof) ExpectedParts() int {
+	return ZKVProofBytesParts
+}
+
+// ExpectedMaxPartLen bounds the parts: the coordinates of Alpha; T, U < q.
+func (pf *ZKVProof) ExpectedMaxPartLen(params crypto.ProofSizeParams) int {
+	return crypto.BitsToBytes(params.EC.Params().BitSize, params.QBits())
+}
@@ -103,3 +103,57 @@ func TestSchnorrVProofVerifyBadS(t *testing.T) {
 
 	assert.False(t, res, "verify result must be false")
 }
+
+func TestSchnorrProofBytesRoundTrip(t *testing.T) {
+	ec := tss.EC()
+	q := ec.Params().N
+	u := common.GetRandomPositiveInt(rand.Reader, q)
+	X := crypto.ScalarBaseMult(ec, u)
+	proof, err := NewZKProof(Session, u, X, rand.Reader)
+	assert.NoError(t, err)
+
+	bzs := proof.Bytes()
+	sizes := crypto.ProofSizeParams{EC: ec}
+	assert.True(t, crypto.ValidateProofBytes(proof, sizes, bzs[:]))
+	proof2, err := ZKProofFromBytes(ec, bzs[:])
+	if assert.NoError(t, err) {
+		assert.True(t, proof.Alpha.Equals(proof2.Alpha))
+		assert.Equal(t, proof.T, proof2.T)
+		assert.True(t, proof2.Verify(Session, X))
+	}
+
+	_, err = ZKProofFromBytes(ec, bzs[:2])
+	assert.Error(t, err, "a part is missing")
+	_, err = ZKProofFromBytes(ec, [][]byte{bzs[0], bzs[0], bzs[2]})
+	assert.Error(t, err, "alpha is not on the curve")
+}
+
+func TestSchnorrVProofBytesRoundTrip(t *testing.T) {
+	ec := tss.EC()
+	q := ec.Params().N
+	k := common.GetRandomPositiveInt(rand.Reader, q)
+	s := common.GetRandomPositiveInt(rand.Reader, q)
+	l := common.GetRandomPositiveInt(rand.Reader, q)
+	R := crypto.ScalarBaseMult(ec, k)
+	Rs := R.ScalarMult(s)
+	lG := crypto.ScalarBaseMult(ec, l)
+	V, _ := Rs.Add(lG)
+	proof, err := NewZKVProof(Session, V, R, s, l, rand.Reader)
+	assert.NoError(t, err)
+
+	bzs := proof.Bytes()
+	sizes := crypto.ProofSizeParams{EC: ec}
+	assert.True(t, crypto.ValidateProofBytes(proof, sizes, bzs[:]))
+	proof2, err := ZKVProofFromBytes(ec, bzs[:])
+	if assert.NoError(t, err) {
+		assert.True(t, proof.Alpha.Equals(proof2.Alpha))
+		assert.Equal(t, proof.T, proof2.T)
+		assert.Equal(t, proof.U, proof2.U)
+		assert.True(t, proof2.Verify(Session, V, R))
+	}
+
+	_, err = ZKVProofFromBytes(ec, bzs[:3])
+	assert.Error(t, err, "a part is missing")
+	_, err = ZKVProofFromBytes(ec, [][]byte{bzs[0], bzs[0], bzs[2], bzs[3]})
+	assert.Error(t, err, "alpha is not on the curve")
+}
@@ -92,8 +92,54 @@ func Create(ec elliptic.Curve, threshold int, secret *big.Int, indexes []*big.In
 	return v, shares, nil
 }
 
+// ValidateCommitments checks that a published polynomial commitment [v_0, ..., v_t] is well-formed: it must hold
+// exactly threshold+1 points, all on the same curve. Shares should only be verified against a commitment that passes.
+func ValidateCommitments(vs []*crypto.ECPoint, threshold int) error {
+	if threshold < 1 {
+		return errors.New("vss threshold < 1")
+	}
+	if len(vs) != threshold+1 {
+		return fmt.Errorf("vss commitment has %d points but the threshold %d requires %d", len(vs), threshold, threshold+1)
+	}
+	for i, v := range vs {
+		if !v.ValidateBasic() {
+			return fmt.Errorf("vss commitment point %d is not a valid curve point", i)
+		}
+		if v.Curve().Params() != vs[0].Curve().Params() {
+			return fmt.Errorf("vss commitment point %d is on a different curve", i)
+		}
+	}
+	return nil
+}
+
+// Verify checks the share against the polynomial commitment vs = [v_0, ..., v_t] published by its dealer,
+// i.e. that share*G == sum(v_j * id^j). It returns false for a share that does not match, and also for
+// a malformed share or a commitment that does not pass ValidateCommitments for the threshold.
+// It is safe to call on shares and commitments received from untrusted parties.
 func (share *Share) Verify(ec elliptic.Curve, threshold int, vs Vs) bool {
-	if share.Threshold != threshold || vs == nil || len(vs) != threshold+1 {
+	if err := ValidateCommitments(vs, threshold); err != nil {
+		return false
+	}
+	return share.verify(ec, threshold, vs)
+}
+
+// VerifyShares verifies each of the shares against the polynomial commitment vs, as Share.Verify does,
+// and returns the indices into shares of those that are invalid. An empty result means all shares are valid.
+// If vs itself is malformed every share is reported.
+func VerifyShares(ec elliptic.Curve, threshold int, shares Shares, vs Vs) []int {
+	invalid := make([]int, 0, len(shares))
+	vsErr := ValidateCommitments(vs, threshold)
+	for i, share := range shares {
+		if vsErr != nil || !share.verify(ec, threshold, vs) {
+			invalid = append(invalid, i)
+		}
+	}
+	return invalid
+}
+
+// verify expects vs to have passed ValidateCommitments
+func (share *Share) verify(ec elliptic.Curve, threshold int, vs Vs) bool {
+	if share == nil || share.ID == nil || share.Share == nil || share.Threshold != threshold {
 		return false
 	}
 	var err error
@@ -145,6 +191,36 @@ func (shares Shares) ReConstruct(ec elliptic.Curve) (secret *big.Int, err error)
 	return secret, nil
 }
 
+// InterpolatePublicShares returns the Lagrange interpolation at 0 of the public shares `bigXs` at the share IDs `ks`,
+// in the exponent: sum(BigXj * prod(kc / (kc - kj))). It is the public counterpart of ReConstruct, e.g. to check that
+// the public shares of a key interpolate to its public key.
+func InterpolatePublicShares(ks []*big.Int, bigXs []*crypto.ECPoint) (*crypto.ECPoint, error) {
+	if len(ks) == 0 || len(ks) != len(bigXs) {
+		return nil, fmt.Errorf("InterpolatePublicShares: expected the same non-zero number of share IDs and points, got %d and %d", len(ks), len(bigXs))
+	}
+	for j := range ks {
+		if ks[j] == nil || bigXs[j] == nil {
+			return nil, fmt.Errorf("InterpolatePublicShares: the share ID or the public share of index %d is missing", j)
+		}
+	}
+	modN := common.ModInt(bigXs[0].Curve().Params().N)
+	coefs := make([]*big.Int, len(ks))
+	for j := range ks {
+		coefs[j] = one
+		for c, kc := range ks {
+			if c == j {
+				continue
+			}
+			sub := modN.Sub(kc, ks[j])
+			if sub.Sign() == 0 {
+				return nil, errors.New("InterpolatePublicShares: the share IDs are not unique")
+			}
+			coefs[j] = modN.Mul(coefs[j], modN.Mul(kc, modN.ModInverse(sub)))
+		}
+	}
+	return crypto.MultiScalarMult(bigXs, coefs)
+}
+
 func samplePolynomial(ec elliptic.Curve, threshold int, secret *big.Int, rand io.Reader) []*big.Int {
 	q := ec.Params().N
 	v := make([]*big.Int, threshold+1)
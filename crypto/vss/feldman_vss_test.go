@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	. "github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
@@ -90,6 +91,71 @@ func TestVerify(t *testing.T) {
 	}
 }
 
+func TestVerifyShares(t *testing.T) {
+	num, threshold := 10, 4
+
+	secret := common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N)
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N))
+	}
+
+	vs, shares, err := Create(tss.EC(), threshold, secret, ids, rand.Reader)
+	assert.NoError(t, err)
+	assert.Empty(t, VerifyShares(tss.EC(), threshold, shares, vs))
+
+	// one bad share in a set of ten
+	shares[6] = &Share{Threshold: threshold, ID: shares[6].ID, Share: new(big.Int).Add(shares[6].Share, big.NewInt(1))}
+	assert.Equal(t, []int{6}, VerifyShares(tss.EC(), threshold, shares, vs))
+	assert.False(t, shares[6].Verify(tss.EC(), threshold, vs))
+
+	// malformed shares are reported rather than panicking
+	shares[2] = &Share{Threshold: threshold, ID: shares[2].ID}
+	shares[8] = nil
+	assert.Equal(t, []int{2, 6, 8}, VerifyShares(tss.EC(), threshold, shares, vs))
+	assert.False(t, shares[8].Verify(tss.EC(), threshold, vs))
+
+	// a malformed commitment fails every share
+	withNil := append(Vs{}, vs...)
+	withNil[1] = nil
+	assert.Len(t, VerifyShares(tss.EC(), threshold, shares, withNil), num)
+	assert.False(t, shares[0].Verify(tss.EC(), threshold, withNil))
+	assert.Len(t, VerifyShares(tss.EC(), threshold-1, shares[:1], vs), 1)
+}
+
+func TestValidateCommitments(t *testing.T) {
+	num, threshold := 5, 3
+
+	secret := common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N)
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N))
+	}
+
+	vs, _, err := Create(tss.EC(), threshold, secret, ids, rand.Reader)
+	assert.NoError(t, err)
+	assert.NoError(t, ValidateCommitments(vs, threshold))
+
+	assert.Error(t, ValidateCommitments(vs, threshold-1), "too many points for the threshold")
+	assert.Error(t, ValidateCommitments(vs[:threshold], threshold), "too few points for the threshold")
+	assert.Error(t, ValidateCommitments(nil, threshold))
+	assert.Error(t, ValidateCommitments(vs[:1], 0), "threshold below 1")
+
+	withNil := append(Vs{}, vs...)
+	withNil[2] = nil
+	assert.Error(t, ValidateCommitments(withNil, threshold))
+
+	otherCurve := append(Vs{}, vs...)
+	otherCurve[1] = crypto.ScalarBaseMult(tss.Edwards(), big.NewInt(42))
+	assert.Error(t, ValidateCommitments(otherCurve, threshold))
+
+	offCurve := append(Vs{}, vs...)
+	offCurve[3] = crypto.NewECPointNoCurveCheck(tss.EC(), big.NewInt(1), big.NewInt(1))
+	assert.Error(t, ValidateCommitments(offCurve, threshold))
+}
+
 func TestReconstruct(t *testing.T) {
 	num, threshold := 5, 3
 
@@ -115,3 +181,77 @@ func TestReconstruct(t *testing.T) {
 	assert.NoError(t, err4)
 	assert.NotZero(t, secret4)
 }
+
+func TestInterpolatePublicShares(t *testing.T) {
+	num, threshold := 5, 3
+
+	secret := common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N)
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N))
+	}
+
+	_, shares, err := Create(tss.EC(), threshold, secret, ids, rand.Reader)
+	assert.NoError(t, err)
+	ks, bigXs := make([]*big.Int, num), make([]*crypto.ECPoint, num)
+	for j, share := range shares {
+		ks[j], bigXs[j] = share.ID, crypto.ScalarBaseMult(tss.EC(), share.Share)
+	}
+	pub := crypto.ScalarBaseMult(tss.EC(), secret)
+
+	// any threshold+1 of the public shares interpolate to the public key
+	y, err := InterpolatePublicShares(ks[1:threshold+2], bigXs[1:threshold+2])
+	assert.NoError(t, err)
+	assert.True(t, pub.Equals(y))
+	y, err = InterpolatePublicShares(ks, bigXs)
+	assert.NoError(t, err)
+	assert.True(t, pub.Equals(y))
+	y, err = InterpolatePublicShares(ks[:threshold], bigXs[:threshold])
+	assert.NoError(t, err)
+	assert.False(t, pub.Equals(y), "threshold shares should not be enough")
+
+	_, err = InterpolatePublicShares(ks[:2], bigXs[:3])
+	assert.Error(t, err)
+	_, err = InterpolatePublicShares(nil, nil)
+	assert.Error(t, err)
+	_, err = InterpolatePublicShares([]*big.Int{ks[0], ks[0]}, bigXs[:2])
+	assert.Error(t, err, "duplicate share IDs should be rejected")
+	_, err = InterpolatePublicShares(ks[:2], []*crypto.ECPoint{bigXs[0], nil})
+	assert.Error(t, err)
+}
+
+func TestCreatePedersen(t *testing.T) {
+	num, threshold := 5, 3
+
+	secret := common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N)
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N))
+	}
+
+	vs, shares, blindings, err := CreatePedersen(tss.EC(), threshold, secret, ids, rand.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, threshold+1, len(vs))
+	assert.Equal(t, num, len(shares))
+	assert.Equal(t, num, len(blindings))
+
+	// the commitment to the secret is hiding
+	assert.False(t, vs[0].Equals(crypto.ScalarBaseMult(tss.EC(), secret)))
+
+	assert.True(t, shares.VerifyPedersen(tss.EC(), threshold, vs, blindings))
+	secret2, err := shares[:threshold+1].ReConstruct(tss.EC())
+	assert.NoError(t, err)
+	assert.Equal(t, secret, secret2)
+
+	// a single tampered share fails
+	tampered := &Share{Threshold: threshold, ID: shares[2].ID, Share: new(big.Int).Add(shares[2].Share, big.NewInt(1))}
+	assert.False(t, tampered.VerifyPedersen(tss.EC(), threshold, vs, blindings[2]))
+	assert.False(t, shares[2].VerifyPedersen(tss.EC(), threshold, vs, blindings[3]))
+	shares[2] = tampered
+	assert.False(t, shares.VerifyPedersen(tss.EC(), threshold, vs, blindings))
+	for i, share := range shares {
+		assert.Equal(t, i != 2, share.VerifyPedersen(tss.EC(), threshold, vs, blindings[i]))
+	}
+}
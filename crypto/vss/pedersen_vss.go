@@ -0,0 +1,115 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Pedersen VSS, based on Torben Pryds Pedersen, 1991., Non-interactive and information-theoretic secure verifiable
+// secret sharing. In Advances in Cryptology — CRYPTO '91, 129–140
+//
+
+package vss
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+)
+
+// CreatePedersen shares `secret` like Create, but commits to the coefficients a_i of the polynomial with the Pedersen
+// commitments C_i = a_i*G + b_i*H, where the b_i are the coefficients of a random blinding polynomial and H is
+// commitments.PedersenH. Unlike the Feldman commitments of Create, C_0 reveals nothing about the secret.
+// Each party gets its share of the secret and its share of the blinding polynomial, at the same index.
+func CreatePedersen(ec elliptic.Curve, threshold int, secret *big.Int, indexes []*big.Int, rand io.Reader) (Vs, Shares, Shares, error) {
+	if secret == nil || indexes == nil {
+		return nil, nil, nil, fmt.Errorf("vss secret or indexes == nil: %v %v", secret, indexes)
+	}
+	if threshold < 1 {
+		return nil, nil, nil, errors.New("vss threshold < 1")
+	}
+
+	ids, err := CheckIndexes(ec, indexes)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	num := len(indexes)
+	if num < threshold {
+		return nil, nil, nil, ErrNumSharesBelowThreshold
+	}
+
+	poly := samplePolynomial(ec, threshold, secret, rand)
+	blindingPoly := samplePolynomial(ec, threshold, common.GetRandomPositiveInt(rand, ec.Params().N), rand)
+
+	H, err := commitments.PedersenH(ec)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	G := crypto.NewECPointNoCurveCheck(ec, ec.Params().Gx, ec.Params().Gy)
+	v := make(Vs, len(poly))
+	for i, ai := range poly {
+		if v[i], err = crypto.MultiScalarMult([]*crypto.ECPoint{G, H}, []*big.Int{ai, blindingPoly[i]}); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	shares, blindings := make(Shares, num), make(Shares, num)
+	for i := 0; i < num; i++ {
+		share := evaluatePolynomial(ec, threshold, poly, ids[i])
+		blinding := evaluatePolynomial(ec, threshold, blindingPoly, ids[i])
+		shares[i] = &Share{Threshold: threshold, ID: ids[i], Share: share}
+		blindings[i] = &Share{Threshold: threshold, ID: ids[i], Share: blinding}
+	}
+	return v, shares, blindings, nil
+}
+
+// VerifyPedersen checks the share and its share of the blinding polynomial against the Pedersen commitments `vs` of
+// CreatePedersen: share*G + blinding*H == sum(C_j * id^j).
+func (share *Share) VerifyPedersen(ec elliptic.Curve, threshold int, vs Vs, blinding *Share) bool {
+	if share.Threshold != threshold || vs == nil || len(vs) != threshold+1 ||
+		blinding == nil || blinding.ID == nil || share.ID == nil || blinding.ID.Cmp(share.ID) != 0 {
+		return false
+	}
+	H, err := commitments.PedersenH(ec)
+	if err != nil {
+		return false
+	}
+	modQ := common.ModInt(ec.Params().N)
+	points := make([]*crypto.ECPoint, len(vs))
+	powers := make([]*big.Int, len(vs))
+	t := one
+	for j, vj := range vs {
+		if vj == nil {
+			return false
+		}
+		points[j], powers[j] = vj.SetCurve(ec), t
+		// t = k_i^(j+1)
+		t = modQ.Mul(t, share.ID)
+	}
+	v, err := crypto.MultiScalarMult(points, powers)
+	if err != nil {
+		return false
+	}
+	G := crypto.NewECPointNoCurveCheck(ec, ec.Params().Gx, ec.Params().Gy)
+	committed, err := crypto.MultiScalarMult([]*crypto.ECPoint{G, H}, []*big.Int{share.Share, blinding.Share})
+	return err == nil && committed.Equals(v)
+}
+
+// VerifyPedersen checks each share with Share.VerifyPedersen against the blinding share at the same position.
+func (shares Shares) VerifyPedersen(ec elliptic.Curve, threshold int, vs Vs, blindings Shares) bool {
+	if len(shares) == 0 || len(shares) != len(blindings) {
+		return false
+	}
+	for i, share := range shares {
+		if share == nil || !share.VerifyPedersen(ec, threshold, vs, blindings[i]) {
+			return false
+		}
+	}
+	return true
+}
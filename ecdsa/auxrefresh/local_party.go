@@ -0,0 +1,139 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package auxrefresh
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// Implements Party
+// Implements Stringer
+var (
+	_ tss.Party    = (*LocalParty)(nil)
+	_ fmt.Stringer = (*LocalParty)(nil)
+)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp localTempData
+		data keygen.LocalPartySaveData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *keygen.LocalPartySaveData
+	}
+
+	localMessageStore struct {
+		arRound1Messages,
+		arRound2Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after the refresh)
+		preParams     keygen.LocalPreParams
+		keyPartyCount int
+		ssid          []byte
+		ssidNonce     *big.Int
+	}
+)
+
+// NewLocalParty returns a party that refreshes the auxiliary data of `key`: its Paillier key and its NTilde, h1, h2
+// for the range proofs, with the mod, fac and DLN proofs of keygen. The share Xi, the public keys BigXj and ECDSAPub
+// are left untouched, so the key stays the same while the material that protects the shares during signing changes.
+// Every party of the key must take part. The refreshed save data are sent to `end` and replace `key` for signing.
+// When `optionalPreParams` is provided, it is used instead of generating new pre-params; it must differ from those
+// of the key.
+func NewLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *keygen.LocalPartySaveData,
+	optionalPreParams ...keygen.LocalPreParams,
+) tss.Party {
+	partyCount := params.PartyCount()
+	p := &LocalParty{
+		BaseParty: tss.NewBaseParty(func(tss.ParsedMessage) int { return partyCount }),
+		params:    params,
+		temp:      localTempData{},
+		data:      keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		out:       out,
+		end:       end,
+	}
+	if 0 < len(optionalPreParams) {
+		if 1 < len(optionalPreParams) {
+			panic(errors.New("auxrefresh.NewLocalParty expected 0 or 1 item in `optionalPreParams`"))
+		}
+		if !optionalPreParams[0].ValidateWithProof() {
+			panic(errors.New("`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib"))
+		}
+		p.temp.preParams = optionalPreParams[0]
+	}
+	p.temp.keyPartyCount = len(key.Ks)
+	// msgs init
+	p.temp.arRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.arRound2Messages = make([]tss.ParsedMessage, partyCount)
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.data, &p.temp, p.out, p.end)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// replays are only rejected with Parameters.EnableReplayProtection. we expect the caller to apply spoofing protection.
+	switch msg.Content().(type) {
+	case *ARRound1Message:
+		p.temp.arRound1Messages[fromPIdx] = msg
+	case *ARRound2Message:
+		p.temp.arRound2Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		p.params.Logger().Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,200 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package auxrefresh_test
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	. "github.com/bnb-chain/tss-lib/v2/ecdsa/auxrefresh"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+// refresh runs an aux refresh of `keys` by `pIDs`, with the pre-params `preParams` when given, and returns the
+// refreshed save data by party index
+func refresh(keys []keygen.LocalPartySaveData, pIDs tss.SortedPartyIDs, preParams []keygen.LocalPreParams) ([]keygen.LocalPartySaveData, *tss.Error) {
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs)*len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+
+	for j, pID := range pIDs {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pID, len(pIDs), testThreshold)
+		var P *LocalParty
+		if preParams != nil {
+			P = NewLocalParty(params, keys[j], outCh, endCh, preParams[j]).(*LocalParty)
+		} else {
+			P = NewLocalParty(params, keys[j], outCh, endCh).(*LocalParty)
+		}
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	newKeys := make([]keygen.LocalPartySaveData, len(pIDs))
+	for ended := 0; ended < len(pIDs); {
+		select {
+		case err := <-errCh:
+			return nil, err
+		case msg := <-outCh:
+			if dest := msg.GetTo(); dest != nil {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+				continue
+			}
+			for _, P := range parties {
+				if P.PartyID().Index != msg.GetFrom().Index {
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+			}
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			if err != nil {
+				return nil, tss.NewError(err, TaskName, 3, nil)
+			}
+			newKeys[index] = *save
+			ended++
+		}
+	}
+	return newKeys, nil
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	oldKeys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// re-use the fixture pre-params of another party for speed
+	preParams := make([]keygen.LocalPreParams, len(oldKeys))
+	for j := range oldKeys {
+		preParams[j] = oldKeys[(j+1)%len(oldKeys)].LocalPreParams
+	}
+	newKeys, tssErr := refresh(oldKeys, pIDs, preParams)
+	if !assert.Nil(t, tssErr) {
+		return
+	}
+
+	for i, key := range newKeys {
+		// the key share is untouched
+		assert.Equal(t, 0, oldKeys[i].Xi.Cmp(key.Xi))
+		assert.Equal(t, 0, oldKeys[i].ShareID.Cmp(key.ShareID))
+		assert.True(t, oldKeys[i].ECDSAPub.Equals(key.ECDSAPub))
+		for j := range key.BigXj {
+			assert.True(t, oldKeys[i].BigXj[j].Equals(key.BigXj[j]))
+		}
+		assert.Equal(t, oldKeys[i].ChainCode, key.ChainCode)
+		assert.Equal(t, oldKeys[i].KeyThreshold, key.KeyThreshold)
+
+		// the aux data are refreshed
+		assert.Equal(t, preParams[i].NTildei, key.NTildei)
+		assert.Equal(t, preParams[i].PaillierSK.N, key.PaillierSK.N)
+		assert.NotEqual(t, 0, oldKeys[i].NTildei.Cmp(key.NTildei))
+		for j, other := range newKeys {
+			assert.Equal(t, 0, other.NTildei.Cmp(key.NTildej[j]))
+			assert.Equal(t, 0, other.H1i.Cmp(key.H1j[j]))
+			assert.Equal(t, 0, other.H2i.Cmp(key.H2j[j]))
+			assert.Equal(t, 0, other.PaillierSK.N.Cmp(key.PaillierPKs[j].N))
+		}
+	}
+	// the old save data are not modified
+	assert.NotEqual(t, 0, oldKeys[0].NTildej[1].Cmp(newKeys[0].NTildej[1]))
+
+	// PHASE: signing with the refreshed aux data
+	signPIDs := pIDs[:testThreshold+1]
+	signP2pCtx := tss.NewPeerContext(signPIDs)
+	signParties := make([]*signing.LocalParty, 0, len(signPIDs))
+
+	signErrCh := make(chan *tss.Error, len(signPIDs))
+	signOutCh := make(chan tss.Message, len(signPIDs))
+	signEndCh := make(chan *common.SignatureData, len(signPIDs))
+
+	for j, signPID := range signPIDs {
+		params := tss.NewParameters(tss.S256(), signP2pCtx, signPID, len(signPIDs), testThreshold)
+		P := signing.NewLocalParty(big.NewInt(42), params, newKeys[j], signOutCh, signEndCh).(*signing.LocalParty)
+		signParties = append(signParties, P)
+		go func(P *signing.LocalParty) {
+			if err := P.Start(); err != nil {
+				signErrCh <- err
+			}
+		}(P)
+	}
+
+	for signEnded := 0; signEnded < len(signPIDs); {
+		select {
+		case err := <-signErrCh:
+			assert.FailNow(t, err.Error())
+			return
+		case msg := <-signOutCh:
+			if dest := msg.GetTo(); dest != nil {
+				go test.SharedPartyUpdater(signParties[dest[0].Index], msg, signErrCh)
+				continue
+			}
+			for _, P := range signParties {
+				if P.PartyID().Index != msg.GetFrom().Index {
+					go test.SharedPartyUpdater(P, msg, signErrCh)
+				}
+			}
+		case data := <-signEndCh:
+			pk := ecdsa.PublicKey{
+				Curve: tss.S256(),
+				X:     newKeys[0].ECDSAPub.X(),
+				Y:     newKeys[0].ECDSAPub.Y(),
+			}
+			ok := ecdsa.Verify(&pk, big.NewInt(42).Bytes(), new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S))
+			assert.True(t, ok, "ecdsa verify must pass")
+			signEnded++
+		}
+	}
+}
+
+func TestRefreshRequiresNewPreParamsAndAllParties(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// the pre-params of the key cannot be used again
+	preParams := make([]keygen.LocalPreParams, len(keys))
+	for j := range keys {
+		preParams[j] = keys[j].LocalPreParams
+	}
+	_, tssErr := refresh(keys, pIDs, preParams)
+	if assert.NotNil(t, tssErr) {
+		assert.Contains(t, tssErr.Error(), "must differ")
+	}
+
+	// every party of the key must take part
+	subsetPIDs := pIDs[:testParticipants-1]
+	_, tssErr = refresh(keys[:testParticipants-1], subsetPIDs, preParams[1:])
+	if assert.NotNil(t, tssErr) {
+		assert.Contains(t, tssErr.Error(), "all the")
+	}
+}
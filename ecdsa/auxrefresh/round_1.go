@@ -0,0 +1,141 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package auxrefresh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto/dlnproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+var zero = big.NewInt(0)
+
+// round 1 generates the new Paillier key and NTilde, h1, h2 of this party and broadcasts them with their proofs
+func newRound1(params *tss.Parameters, save *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) tss.Round {
+	return &round1{
+		&base{params, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	if err := round.save.CheckCurve(round.EC()); err != nil {
+		return round.WrapError(err)
+	}
+	if round.temp.keyPartyCount != round.PartyCount() {
+		return round.WrapError(fmt.Errorf("the aux data must be refreshed by all the %d parties of the key, got %d parties",
+			round.temp.keyPartyCount, round.PartyCount()))
+	}
+	if round.save.ECDSAPub == nil || round.save.Xi == nil || round.save.ShareID == nil ||
+		round.save.ShareID.Cmp(round.save.Ks[i]) != 0 {
+		return round.WrapError(errors.New("the save data of this party are not those of a key share"), Pi)
+	}
+
+	// 1. generate the new Paillier key and safe primes for NTilde, h1, h2, unless they were provided
+	var preParams *keygen.LocalPreParams
+	if round.temp.preParams.ValidateWithProof() {
+		preParams = &round.temp.preParams
+	} else {
+		ctx, cancel := context.WithTimeout(round.Context(), round.SafePrimeGenTimeout())
+		defer cancel()
+		var err error
+		preParams, err = keygen.GeneratePreParamsWithContextAndRandom(ctx, round.Rand(), round.Concurrency())
+		if err != nil {
+			return round.WrapError(errors.New("pre-params generation failed"), Pi)
+		}
+	}
+	if old := round.save.LocalPreParams; (old.PaillierSK != nil && old.PaillierSK.N.Cmp(preParams.PaillierSK.N) == 0) ||
+		(old.NTildei != nil && old.NTildei.Cmp(preParams.NTildei) == 0) {
+		return round.WrapError(errors.New("the new pre-params must differ from those of the key"), Pi)
+	}
+
+	round.temp.ssidNonce = round.SSIDNonce()
+	ssid, err := round.getSSID()
+	if err != nil {
+		return round.WrapError(errors.New("failed to generate ssid"))
+	}
+	round.temp.ssid = ssid
+
+	// 2. prove h1, h2 and the Paillier modulus
+	h1i, h2i, alpha, beta, p, q, NTildei := preParams.H1i,
+		preParams.H2i,
+		preParams.Alpha,
+		preParams.Beta,
+		preParams.P,
+		preParams.Q,
+		preParams.NTildei
+	dlnProof1 := dlnproof.NewDLNProof(h1i, h2i, alpha, p, q, NTildei, round.Rand())
+	dlnProof2 := dlnproof.NewDLNProof(h2i, h1i, beta, p, q, NTildei, round.Rand())
+
+	modProof := &modproof.ProofMod{W: zero, X: *new([80]*big.Int), A: zero, B: zero, Z: *new([80]*big.Int)}
+	ContextI := append(round.temp.ssid, big.NewInt(int64(i)).Bytes()...)
+	if !round.Parameters.NoProofMod() {
+		modProof, err = modproof.NewProof(ContextI, preParams.PaillierSK.N, preParams.PaillierSK.P, preParams.PaillierSK.Q, round.Rand())
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+	}
+
+	// for this P: SAVE the new pre-params and public material
+	round.save.LocalPreParams = *preParams
+	round.save.PaillierPKs[i] = &preParams.PaillierSK.PublicKey
+	round.save.NTildej[i] = preParams.NTildei
+	round.save.H1j[i], round.save.H2j[i] = preParams.H1i, preParams.H2i
+
+	// BROADCAST the new paillier pk, NTilde, h1, h2 and proofs; round 1 message
+	r1msg, err := NewARRound1Message(
+		round.PartyID(), &preParams.PaillierSK.PublicKey, modProof, preParams.NTildei, preParams.H1i, preParams.H2i, dlnProof1, dlnProof2)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	round.temp.arRound1Messages[i] = r1msg
+	round.out <- r1msg
+	return nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*ARRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.arRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		// proof checks are in round 2
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
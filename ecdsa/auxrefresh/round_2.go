@@ -0,0 +1,190 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package auxrefresh
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/facproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	ctx, cancel := round.RoundContext()
+	defer cancel()
+	dlnVerifier := keygen.NewDlnProofVerifier(ctx, round.Concurrency())
+
+	i := round.PartyID().Index
+	Ps := round.Parties().IDs()
+
+	// 1. verify the dln and mod proofs, ensure uniqueness of h1j, h2j, NTildej and the paillier N
+	h1H2Map := make(map[string]struct{}, len(Ps)*2)
+	nTildeMap := make(map[string]struct{}, len(Ps))
+	paillierNMap := make(map[string]struct{}, len(Ps))
+	proofFailed := make([]bool, len(Ps))
+	modInputs, modIdxs := make([]modproof.ProofModInput, 0, len(Ps)), make([]int, 0, len(Ps))
+	mtx, wg := new(sync.Mutex), new(sync.WaitGroup)
+	for j, msg := range round.temp.arRound1Messages {
+		r1msg := msg.Content().(*ARRound1Message)
+		H1j, H2j, NTildej, paillierPKj := r1msg.UnmarshalH1(),
+			r1msg.UnmarshalH2(),
+			r1msg.UnmarshalNTilde(),
+			r1msg.UnmarshalPaillierPK()
+		if !keygen.IsSupportedModulusLen(paillierPKj.N.BitLen()) {
+			return round.WrapError(errors.New("got paillier modulus with insufficient bits for this party"), msg.GetFrom())
+		}
+		if H1j.Cmp(H2j) == 0 {
+			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom())
+		}
+		if !keygen.IsSupportedModulusLen(NTildej.BitLen()) {
+			return round.WrapError(errors.New("got NTildej with insufficient bits for this party"), msg.GetFrom())
+		}
+		h1JHex, h2JHex := hex.EncodeToString(H1j.Bytes()), hex.EncodeToString(H2j.Bytes())
+		if _, found := h1H2Map[h1JHex]; found {
+			return round.WrapError(errors.New("this h1j was already used by another party"), msg.GetFrom())
+		}
+		if _, found := h1H2Map[h2JHex]; found {
+			return round.WrapError(errors.New("this h2j was already used by another party"), msg.GetFrom())
+		}
+		h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
+		nTildeJHex, paillierNJHex := hex.EncodeToString(NTildej.Bytes()), hex.EncodeToString(paillierPKj.N.Bytes())
+		if _, found := nTildeMap[nTildeJHex]; found {
+			return round.WrapError(errors.New("this NTildej was already used by another party"), msg.GetFrom())
+		}
+		if _, found := paillierNMap[paillierNJHex]; found {
+			return round.WrapError(errors.New("this paillier N was already used by another party"), msg.GetFrom())
+		}
+		nTildeMap[nTildeJHex], paillierNMap[paillierNJHex] = struct{}{}, struct{}{}
+		if j == i {
+			continue
+		}
+
+		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		modProof, err := r1msg.UnmarshalModProof()
+		if err != nil && round.Parameters.NoProofMod() {
+			round.Params().Logger().Warningf("modProof not exist:%s", Ps[j])
+		} else if err != nil {
+			proofFailed[j] = true
+		} else {
+			modInputs = append(modInputs, modproof.ProofModInput{Proof: modProof, Session: ContextJ, N: paillierPKj.N})
+			modIdxs = append(modIdxs, j)
+		}
+
+		_j := j
+		onDone := func(isValid bool, err error) {
+			if !isValid && (err == nil || err != ctx.Err()) { // an aborted verification has no culprit
+				round.Params().Logger().Warningf("dln proof verify failed for party %s", Ps[_j])
+				mtx.Lock()
+				proofFailed[_j] = true
+				mtx.Unlock()
+			}
+			wg.Done()
+		}
+		wg.Add(2)
+		dlnVerifier.VerifyDLNProof1(r1msg, H1j, H2j, NTildej, onDone)
+		dlnVerifier.VerifyDLNProof2(r1msg, H2j, H1j, NTildej, onDone)
+	}
+	for _, k := range modproof.BatchVerify(modInputs, round.Concurrency()) {
+		round.Params().Logger().Warningf("modProof verify failed for party %s", Ps[modIdxs[k]])
+		mtx.Lock()
+		proofFailed[modIdxs[k]] = true
+		mtx.Unlock()
+	}
+	wg.Wait()
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, failed := range proofFailed {
+		if failed {
+			culprits = append(culprits, Ps[j])
+		}
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("dln or mod proof verification failed"), culprits...)
+	}
+	if err := ctx.Err(); err != nil {
+		return round.WrapError(fmt.Errorf("dln proof verification was aborted: %w", err))
+	}
+
+	// save the new paillier pk, NTilde_j, h1_j, h2_j of the other parties
+	for j, msg := range round.temp.arRound1Messages {
+		if j == i {
+			continue
+		}
+		r1msg := msg.Content().(*ARRound1Message)
+		round.save.PaillierPKs[j] = r1msg.UnmarshalPaillierPK()
+		round.save.NTildej[j] = r1msg.UnmarshalNTilde()
+		round.save.H1j[j], round.save.H2j[j] = r1msg.UnmarshalH1(), r1msg.UnmarshalH2()
+	}
+
+	// 2. p2p send the proof of the factors of the new paillier N under the new NTilde_j, h1_j, h2_j of each Pj
+	ContextI := append(round.temp.ssid, big.NewInt(int64(i)).Bytes()...)
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		facProof := &facproof.ProofFac{
+			P: zero, Q: zero, A: zero, B: zero, T: zero, Sigma: zero,
+			Z1: zero, Z2: zero, W1: zero, W2: zero, V: zero,
+		}
+		if !round.Params().NoProofFac() {
+			var err error
+			facProof, err = facproof.NewProof(ContextI, round.EC(), round.save.PaillierSK.N, round.save.NTildej[j],
+				round.save.H1j[j], round.save.H2j[j], round.save.PaillierSK.P, round.save.PaillierSK.Q, round.Rand())
+			if err != nil {
+				return round.WrapError(err, round.PartyID())
+			}
+		}
+		round.out <- NewARRound2Message(Pj, round.PartyID(), facProof)
+	}
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*ARRound2Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.arRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if j == round.PartyID().Index {
+			// this party does not send a fac proof to itself
+			round.ok[j] = true
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		// proof check is in round 3
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
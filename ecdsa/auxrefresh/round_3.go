@@ -0,0 +1,74 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package auxrefresh
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/facproof"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	Ps := round.Parties().IDs()
+
+	// 1. verify the fac proofs of the other parties under the new NTilde_i, h1_i, h2_i of this party
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	facInputs, facIdxs := make([]facproof.ProofFacInput, 0, len(Ps)), make([]int, 0, len(Ps))
+	for j, msg := range round.temp.arRound2Messages {
+		if j == i {
+			continue
+		}
+		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		facProof, err := msg.Content().(*ARRound2Message).UnmarshalFacProof()
+		if err != nil && round.NoProofFac() {
+			round.Params().Logger().Warningf("facProof not exist:%s", Ps[j])
+		} else if err != nil {
+			culprits = append(culprits, Ps[j])
+		} else {
+			facInputs = append(facInputs, facproof.ProofFacInput{Proof: facProof, Session: ContextJ,
+				N0: round.save.PaillierPKs[j].N, NCap: round.save.NTildei, S: round.save.H1i, T: round.save.H2i})
+			facIdxs = append(facIdxs, j)
+		}
+	}
+	for _, k := range facproof.BatchVerify(round.EC(), facInputs, round.Concurrency()) {
+		round.Params().Logger().Warningf("facProof verify failed for party %s", Ps[facIdxs[k]])
+		culprits = append(culprits, Ps[facIdxs[k]])
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("fac proof verification failed"), culprits...)
+	}
+
+	// 2. the save data now hold the refreshed aux data along with the unchanged key share
+	round.end <- round.save
+
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	return nil // finished!
+}
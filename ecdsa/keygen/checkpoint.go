@@ -0,0 +1,180 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	checkpointVersion = 2
+	checkpointDomain  = "tss-lib ecdsa keygen checkpoint"
+)
+
+// checkpointState holds everything a keygen party needs to continue from the round it was in.
+// The pre-parameters are left out of the save data, as they must be given again to resume.
+type checkpointState struct {
+	tss.Checkpoint
+
+	Save LocalPartySaveData
+
+	Ui            *big.Int
+	Vs            vss.Vs
+	Shares        vss.Shares
+	KGCs          []cmt.HashCommitment
+	DeCommitPolyG cmt.HashDeCommitment
+	R1EchoHashes  [][]byte
+}
+
+// Checkpoint captures the state of a running keygen party so that it can be continued later with ResumeFromCheckpoint,
+// e.g. after a process restart. The format is versioned. The checkpoint contains the party's secrets and is sealed
+// with a key derived from the party's pre-parameters and the session id (ssid). The pre-parameters must have been given
+// to NewLocalParty, as they must be given again to resume. The ssid covers the parties and the nonce set with
+// tss.Parameters.SetSSIDNonce; set a fresh nonce for each keygen so that pre-parameters reused across sessions with
+// the same parties do not open the checkpoints of an earlier session. Each checkpoint can be resumed only once, see
+// tss.CheckpointLedger. Once round 2 has sent the shares of the other parties, they are left out of the checkpoint.
+func (p *LocalParty) Checkpoint() ([]byte, error) {
+	return tss.BaseSnapshot(p, func(round tss.Round, run []byte) ([]byte, error) {
+		if !p.preParamsGiven {
+			return nil, errors.New("could not checkpoint. the party generated its own pre-parameters, give them to NewLocalParty")
+		}
+		rnd, ok := round.(interface{ baseRound() *base })
+		if !ok || !rnd.baseRound().started {
+			return nil, errors.New("could not checkpoint. the current round has not started")
+		}
+		b := rnd.baseRound()
+		i := p.PartyID().Index
+		shared, err := tss.NewCheckpoint(p.params, run, b.number, b.ok, p.temp.localMessageStore.all()...)
+		if err != nil {
+			return nil, err
+		}
+		state := &checkpointState{
+			Checkpoint:    shared,
+			Save:          p.data,
+			Ui:            p.temp.ui,
+			Vs:            p.temp.vs,
+			Shares:        p.temp.shares,
+			KGCs:          p.temp.KGCs,
+			DeCommitPolyG: p.temp.deCommitPolyG,
+			R1EchoHashes:  p.temp.r1EchoHashes,
+		}
+		state.Save.LocalPreParams = LocalPreParams{}
+		if 2 <= b.number {
+			// only the share of this party is still needed
+			state.Shares = make(vss.Shares, len(p.temp.shares))
+			state.Shares[i] = p.temp.shares[i]
+		}
+		plain, err := json.Marshal(state)
+		if err != nil {
+			return nil, err
+		}
+		return tss.SealSnapshot(p.params.Rand(), checkpointKey(p.data.LocalPreParams, p.temp.ssid), checkpointVersion, plain)
+	})
+}
+
+// ResumeFromCheckpoint restores a keygen party from a checkpoint taken with LocalParty.Checkpoint.
+// `params` and `preParams` must be the same as those given to the party that took the checkpoint.
+// The resume is recorded in `ledger`, which refuses to resume the checkpoint, or one taken before it, a second time.
+// The returned party is already running in the checkpointed round and must not be started again;
+// feed it the remaining messages for that round with Update.
+func ResumeFromCheckpoint(
+	state []byte,
+	params *tss.Parameters,
+	ledger tss.CheckpointLedger,
+	out chan<- tss.Message,
+	end chan<- *LocalPartySaveData,
+	preParams LocalPreParams,
+) (tss.Party, error) {
+	if err := preParams.checkWithProof(); err != nil {
+		return nil, err
+	}
+	p := NewLocalParty(params, out, end, preParams).(*LocalParty)
+
+	// the key binds the checkpoint to the ssid of this session
+	round := p.FirstRound()
+	p.temp.ssidNonce = params.SSIDNonce()
+	ssid, err := round.(*round1).getSSID()
+	if err != nil {
+		return nil, err
+	}
+	plain, err := tss.OpenSnapshot(checkpointKey(preParams, ssid), checkpointVersion, state)
+	if err != nil {
+		return nil, err
+	}
+	cp := new(checkpointState)
+	if err = json.Unmarshal(plain, cp); err != nil {
+		return nil, fmt.Errorf("checkpoint could not be decoded: %v", err)
+	}
+	if err = cp.Check(params, 1, 3); err != nil {
+		return nil, err
+	}
+	partyCount := len(params.Parties().IDs())
+	if len(cp.Shares) != partyCount || len(cp.KGCs) != partyCount ||
+		len(cp.Save.Ks) != partyCount || len(cp.Save.NTildej) != partyCount || len(cp.Save.BigXj) != partyCount ||
+		len(cp.Save.PaillierPKs) != partyCount {
+		return nil, errors.New("checkpoint is inconsistent with the number of parties")
+	}
+	if cp.Ui == nil || cp.Vs == nil || cp.Shares[params.PartyID().Index] == nil {
+		return nil, errors.New("checkpoint is missing round 1 data")
+	}
+
+	p.data = cp.Save
+	p.data.LocalPreParams = preParams
+	p.temp.ui, p.temp.vs, p.temp.shares = cp.Ui, cp.Vs, cp.Shares
+	p.temp.KGCs, p.temp.deCommitPolyG, p.temp.r1EchoHashes = cp.KGCs, cp.DeCommitPolyG, cp.R1EchoHashes
+	p.temp.ssid = ssid
+
+	for n := 1; n < cp.Round; n++ {
+		round = round.NextRound()
+	}
+	b := round.(interface{ baseRound() *base }).baseRound()
+	b.number, b.started, b.ok = cp.Round, true, cp.OK
+
+	// the shares in the messages were already decrypted when they were first received
+	if err = cp.Resume(p, TaskName, round, ledger, p.restoreMessage); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ----- //
+
+func (store *localMessageStore) all() [][]tss.ParsedMessage {
+	return [][]tss.ParsedMessage{
+		store.kgRound1Messages,
+		store.kgRound2Message1s,
+		store.kgRound2Message2s,
+		store.kgRound3Messages,
+	}
+}
+
+// restoreMessage stores a message of a checkpoint like StoreMessage, without decrypting the share again
+func (p *LocalParty) restoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if _, ok := msg.Content().(*KGRound2Message1); !ok || p.params.ShareEncryptionKey() == nil {
+		return p.StoreMessage(msg)
+	}
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	p.temp.kgRound2Message1s[msg.GetFrom().Index] = msg
+	return true, nil
+}
+
+// checkpointKey derives the key that seals the checkpoints of a party in the session `ssid` from the secret primes of
+// its pre-parameters
+func checkpointKey(preParams LocalPreParams, ssid []byte) []byte {
+	return common.SHA512_256([]byte(checkpointDomain), ssid, preParams.PaillierSK.P.Bytes(), preParams.PaillierSK.Q.Bytes(),
+		preParams.P.Bytes(), preParams.Q.Bytes())
+}
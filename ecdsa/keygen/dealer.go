@@ -0,0 +1,112 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// NewSaveDataFromSecret acts as a trusted dealer: it splits an existing private key `secret` into Shamir shares for
+// `pIDs` and returns the save data of each party, in the order of `pIDs`, as keygen would have produced it.
+// `preParams` holds the pre-params of each party in the same order; any `threshold`+1 of the parties can then sign.
+//
+// SECURITY: unlike keygen, this trusts the dealer completely. The dealer knows the private key and every share, so the
+// key is only as safe as the machine that ran it. Use it for tests, or to migrate a key that already lived on a single
+// machine, then destroy `secret` and the save data of the other parties on that machine.
+func NewSaveDataFromSecret(ec elliptic.Curve, secret *big.Int, pIDs tss.SortedPartyIDs, threshold int, preParams []LocalPreParams, rand io.Reader) ([]LocalPartySaveData, error) {
+	partyCount := len(pIDs)
+	if secret == nil || secret.Sign() <= 0 || secret.Cmp(ec.Params().N) >= 0 {
+		return nil, errors.New("NewSaveDataFromSecret: the secret must be in [1, N-1]")
+	}
+	if threshold < 1 || partyCount <= threshold {
+		return nil, fmt.Errorf("NewSaveDataFromSecret: a threshold of %d needs more than %d parties", threshold, threshold)
+	}
+	if len(preParams) != partyCount {
+		return nil, fmt.Errorf("NewSaveDataFromSecret: expected %d pre-params, got %d", partyCount, len(preParams))
+	}
+	for j, pp := range preParams {
+		if !pp.ValidateWithProof() {
+			return nil, fmt.Errorf("NewSaveDataFromSecret: the pre-params at index %d are incomplete", j)
+		}
+	}
+	if collisions, err := CheckPreParamsDistinct(preParams); err != nil || len(collisions) > 0 {
+		return nil, fmt.Errorf("NewSaveDataFromSecret: the pre-params must be distinct (%v, %v)", collisions, err)
+	}
+
+	ids := pIDs.Keys()
+	_, shares, err := vss.Create(ec, threshold, secret, ids, rand)
+	if err != nil {
+		return nil, err
+	}
+	ecdsaPub := crypto.ScalarBaseMult(ec, secret)
+	chainCode := MasterChainCode(ecdsaPub)
+	bigXj := make([]*crypto.ECPoint, partyCount)
+	for j, share := range shares {
+		bigXj[j] = crypto.ScalarBaseMult(ec, share.Share)
+	}
+
+	saves := make([]LocalPartySaveData, partyCount)
+	for i := range pIDs {
+		save := NewLocalPartySaveData(partyCount)
+		save.LocalPreParams = preParams[i]
+		save.Xi, save.ShareID = shares[i].Share, ids[i]
+		save.ECDSAPub, save.ChainCode = ecdsaPub, chainCode
+		save.KeyThreshold = threshold
+		save.CurveName, _ = tss.GetCurveName(ec)
+		for j := range pIDs {
+			save.Ks[j] = ids[j]
+			save.NTildej[j] = preParams[j].NTildei
+			save.H1j[j], save.H2j[j] = preParams[j].H1i, preParams[j].H2i
+			save.BigXj[j] = bigXj[j]
+			save.PaillierPKs[j] = &preParams[j].PaillierSK.PublicKey
+		}
+		saves[i] = save
+	}
+	return saves, nil
+}
+
+// ReconstructPrivateKey recombines the shares of threshold+1 or more parties into the private key, e.g. to recover
+// from a disaster in an air-gapped environment. The result is checked against ECDSAPub, so an error is returned when
+// too few shares are given. Like NewSaveDataFromSecret, this gives up the threshold security of the key.
+func ReconstructPrivateKey(keys []LocalPartySaveData) (*big.Int, error) {
+	if len(keys) == 0 || keys[0].ECDSAPub == nil {
+		return nil, errors.New("ReconstructPrivateKey: no keys were given")
+	}
+	ecdsaPub := keys[0].ECDSAPub
+	ec := ecdsaPub.Curve()
+	shares := make(vss.Shares, len(keys))
+	ids := make([]*big.Int, len(keys))
+	for i, key := range keys {
+		if key.Xi == nil || key.ShareID == nil {
+			return nil, fmt.Errorf("ReconstructPrivateKey: the key at index %d has no share", i)
+		}
+		if key.ECDSAPub == nil || !key.ECDSAPub.Equals(ecdsaPub) {
+			return nil, fmt.Errorf("ReconstructPrivateKey: the key at index %d belongs to another public key", i)
+		}
+		shares[i] = &vss.Share{Threshold: len(keys) - 1, ID: key.ShareID, Share: key.Xi}
+		ids[i] = key.ShareID
+	}
+	if _, err := vss.CheckIndexes(ec, ids); err != nil {
+		return nil, fmt.Errorf("ReconstructPrivateKey: %v", err)
+	}
+	secret, err := shares.ReConstruct(ec)
+	if err != nil {
+		return nil, err
+	}
+	if !crypto.ScalarBaseMult(ec, secret).Equals(ecdsaPub) {
+		return nil, errors.New("ReconstructPrivateKey: the shares do not match ECDSAPub, at least threshold+1 are needed")
+	}
+	return secret, nil
+}
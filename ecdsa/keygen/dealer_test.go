@@ -0,0 +1,57 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestReconstructPrivateKey(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	secret, err := ReconstructPrivateKey(keys)
+	if assert.NoError(t, err) {
+		assert.True(t, crypto.ScalarBaseMult(tss.S256(), secret).Equals(keys[0].ECDSAPub))
+	}
+
+	// threshold shares are not enough
+	_, err = ReconstructPrivateKey(keys[:testThreshold])
+	assert.Error(t, err)
+	// nor is the same share twice
+	_, err = ReconstructPrivateKey(append(append([]LocalPartySaveData{}, keys[:testThreshold]...), keys[0]))
+	assert.Error(t, err)
+}
+
+func TestNewSaveDataFromSecretRoundTrip(t *testing.T) {
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	preParams := make([]LocalPreParams, len(fixtures))
+	for i, fixture := range fixtures {
+		preParams[i] = fixture.LocalPreParams
+	}
+	secret := common.GetRandomPositiveInt(rand.Reader, tss.S256().Params().N)
+	keys, err := NewSaveDataFromSecret(tss.S256(), secret, pIDs, testThreshold, preParams, rand.Reader)
+	if !assert.NoError(t, err) {
+		return
+	}
+	recovered, err := ReconstructPrivateKey(keys[1 : testThreshold+2])
+	assert.NoError(t, err)
+	assert.Equal(t, 0, secret.Cmp(recovered))
+
+	// the pre-params of a party may not be reused by another
+	preParams[1] = preParams[0]
+	_, err = NewSaveDataFromSecret(tss.S256(), secret, pIDs, testThreshold, preParams, rand.Reader)
+	assert.Error(t, err)
+}
@@ -7,13 +7,16 @@
 package keygen
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/crypto/dlnproof"
 )
 
 type DlnProofVerifier struct {
+	ctx       context.Context
 	semaphore chan interface{}
 }
 
@@ -22,52 +25,76 @@ type message interface {
 	UnmarshalDLNProof2() (*dlnproof.Proof, error)
 }
 
-func NewDlnProofVerifier(concurrency int) *DlnProofVerifier {
+// NewDlnProofVerifier returns a verifier that runs at most `concurrency` verifications at a time.
+// Once `ctx` is done, the verifications that have not started yet report ctx.Err() instead of running.
+func NewDlnProofVerifier(ctx context.Context, concurrency int) *DlnProofVerifier {
 	if concurrency == 0 {
 		panic(errors.New("NewDlnProofverifier: concurrency level must not be zero"))
 	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
 
 	semaphore := make(chan interface{}, concurrency)
 
 	return &DlnProofVerifier{
+		ctx:       ctx,
 		semaphore: semaphore,
 	}
 }
 
+// VerifyDLNProof1 verifies the first DLN proof of `m` and calls `onDone` exactly once with the result.
+// The error is set when the proof could not be verified: it is malformed, the verification panicked,
+// or the context of the verifier is done.
 func (dpv *DlnProofVerifier) VerifyDLNProof1(
 	m message,
 	h1, h2, n *big.Int,
-	onDone func(bool),
+	onDone func(bool, error),
 ) {
-	dpv.semaphore <- struct{}{}
-	go func() {
-		defer func() { <-dpv.semaphore }()
-
-		dlnProof, err := m.UnmarshalDLNProof1()
-		if err != nil {
-			onDone(false)
-			return
-		}
-
-		onDone(dlnProof.Verify(h1, h2, n))
-	}()
+	dpv.verify(m.UnmarshalDLNProof1, h1, h2, n, onDone)
 }
 
+// VerifyDLNProof2 verifies the second DLN proof of `m`, see VerifyDLNProof1.
 func (dpv *DlnProofVerifier) VerifyDLNProof2(
 	m message,
 	h1, h2, n *big.Int,
-	onDone func(bool),
+	onDone func(bool, error),
+) {
+	dpv.verify(m.UnmarshalDLNProof2, h1, h2, n, onDone)
+}
+
+func (dpv *DlnProofVerifier) verify(
+	unmarshal func() (*dlnproof.Proof, error),
+	h1, h2, n *big.Int,
+	onDone func(bool, error),
 ) {
-	dpv.semaphore <- struct{}{}
+	select {
+	case dpv.semaphore <- struct{}{}:
+	case <-dpv.ctx.Done():
+		onDone(false, dpv.ctx.Err())
+		return
+	}
 	go func() {
 		defer func() { <-dpv.semaphore }()
+		onDone(dpv.verifyProof(unmarshal, h1, h2, n))
+	}()
+}
 
-		dlnProof, err := m.UnmarshalDLNProof2()
-		if err != nil {
-			onDone(false)
-			return
+func (dpv *DlnProofVerifier) verifyProof(
+	unmarshal func() (*dlnproof.Proof, error),
+	h1, h2, n *big.Int,
+) (ok bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok, err = false, fmt.Errorf("dln proof verification panicked: %v", r)
 		}
-
-		onDone(dlnProof.Verify(h1, h2, n))
 	}()
+	if err = dpv.ctx.Err(); err != nil {
+		return false, err
+	}
+	dlnProof, err := unmarshal()
+	if err != nil {
+		return false, err
+	}
+	return dlnProof.Verify(h1, h2, n), nil
 }
@@ -7,10 +7,14 @@
 package keygen
 
 import (
+	"context"
 	"crypto/rand"
 	"math/big"
 	"runtime"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 
 	"github.com/bnb-chain/tss-lib/v2/crypto/dlnproof"
 )
@@ -45,12 +49,12 @@ func BenchmarkDlnVerifier_VerifyProof1(b *testing.B) {
 		Dlnproof_1: proof,
 	}
 
-	verifier := NewDlnProofVerifier(runtime.GOMAXPROCS(0))
+	verifier := NewDlnProofVerifier(context.Background(), runtime.GOMAXPROCS(0))
 
 	b.ResetTimer()
 	for n := 0; n < b.N; n++ {
 		resultChan := make(chan bool)
-		verifier.VerifyDLNProof1(message, preParams.H1i, preParams.H2i, preParams.NTildei, func(result bool) {
+		verifier.VerifyDLNProof1(message, preParams.H1i, preParams.H2i, preParams.NTildei, func(result bool, _ error) {
 			resultChan <- result
 		})
 		<-resultChan
@@ -63,12 +67,12 @@ func BenchmarkDlnVerifier_VerifyProof2(b *testing.B) {
 		Dlnproof_2: proof,
 	}
 
-	verifier := NewDlnProofVerifier(runtime.GOMAXPROCS(0))
+	verifier := NewDlnProofVerifier(context.Background(), runtime.GOMAXPROCS(0))
 
 	b.ResetTimer()
 	for n := 0; n < b.N; n++ {
 		resultChan := make(chan bool)
-		verifier.VerifyDLNProof2(message, preParams.H1i, preParams.H2i, preParams.NTildei, func(result bool) {
+		verifier.VerifyDLNProof2(message, preParams.H1i, preParams.H2i, preParams.NTildei, func(result bool, _ error) {
 			resultChan <- result
 		})
 		<-resultChan
@@ -81,11 +85,11 @@ func TestVerifyDLNProof1_Success(t *testing.T) {
 		Dlnproof_1: proof,
 	}
 
-	verifier := NewDlnProofVerifier(runtime.GOMAXPROCS(0))
+	verifier := NewDlnProofVerifier(context.Background(), runtime.GOMAXPROCS(0))
 
 	resultChan := make(chan bool)
 
-	verifier.VerifyDLNProof1(message, preParams.H1i, preParams.H2i, preParams.NTildei, func(result bool) {
+	verifier.VerifyDLNProof1(message, preParams.H1i, preParams.H2i, preParams.NTildei, func(result bool, _ error) {
 		resultChan <- result
 	})
 
@@ -101,11 +105,11 @@ func TestVerifyDLNProof1_MalformedMessage(t *testing.T) {
 		Dlnproof_1: proof[:len(proof)-1], // truncate
 	}
 
-	verifier := NewDlnProofVerifier(runtime.GOMAXPROCS(0))
+	verifier := NewDlnProofVerifier(context.Background(), runtime.GOMAXPROCS(0))
 
 	resultChan := make(chan bool)
 
-	verifier.VerifyDLNProof1(message, preParams.H1i, preParams.H2i, preParams.NTildei, func(result bool) {
+	verifier.VerifyDLNProof1(message, preParams.H1i, preParams.H2i, preParams.NTildei, func(result bool, _ error) {
 		resultChan <- result
 	})
 
@@ -121,12 +125,12 @@ func TestVerifyDLNProof1_IncorrectProof(t *testing.T) {
 		Dlnproof_1: proof,
 	}
 
-	verifier := NewDlnProofVerifier(runtime.GOMAXPROCS(0))
+	verifier := NewDlnProofVerifier(context.Background(), runtime.GOMAXPROCS(0))
 
 	resultChan := make(chan bool)
 
 	wrongH1i := preParams.H1i.Sub(preParams.H1i, big.NewInt(1))
-	verifier.VerifyDLNProof1(message, wrongH1i, preParams.H2i, preParams.NTildei, func(result bool) {
+	verifier.VerifyDLNProof1(message, wrongH1i, preParams.H2i, preParams.NTildei, func(result bool, _ error) {
 		resultChan <- result
 	})
 
@@ -142,11 +146,11 @@ func TestVerifyDLNProof2_Success(t *testing.T) {
 		Dlnproof_2: proof,
 	}
 
-	verifier := NewDlnProofVerifier(runtime.GOMAXPROCS(0))
+	verifier := NewDlnProofVerifier(context.Background(), runtime.GOMAXPROCS(0))
 
 	resultChan := make(chan bool)
 
-	verifier.VerifyDLNProof2(message, preParams.H1i, preParams.H2i, preParams.NTildei, func(result bool) {
+	verifier.VerifyDLNProof2(message, preParams.H1i, preParams.H2i, preParams.NTildei, func(result bool, _ error) {
 		resultChan <- result
 	})
 
@@ -162,11 +166,11 @@ func TestVerifyDLNProof2_MalformedMessage(t *testing.T) {
 		Dlnproof_2: proof[:len(proof)-1], // truncate
 	}
 
-	verifier := NewDlnProofVerifier(runtime.GOMAXPROCS(0))
+	verifier := NewDlnProofVerifier(context.Background(), runtime.GOMAXPROCS(0))
 
 	resultChan := make(chan bool)
 
-	verifier.VerifyDLNProof2(message, preParams.H1i, preParams.H2i, preParams.NTildei, func(result bool) {
+	verifier.VerifyDLNProof2(message, preParams.H1i, preParams.H2i, preParams.NTildei, func(result bool, _ error) {
 		resultChan <- result
 	})
 
@@ -182,12 +186,12 @@ func TestVerifyDLNProof2_IncorrectProof(t *testing.T) {
 		Dlnproof_2: proof,
 	}
 
-	verifier := NewDlnProofVerifier(runtime.GOMAXPROCS(0))
+	verifier := NewDlnProofVerifier(context.Background(), runtime.GOMAXPROCS(0))
 
 	resultChan := make(chan bool)
 
 	wrongH2i := preParams.H2i.Add(preParams.H2i, big.NewInt(1))
-	verifier.VerifyDLNProof2(message, preParams.H1i, wrongH2i, preParams.NTildei, func(result bool) {
+	verifier.VerifyDLNProof2(message, preParams.H1i, wrongH2i, preParams.NTildei, func(result bool, _ error) {
 		resultChan <- result
 	})
 
@@ -197,6 +201,69 @@ func TestVerifyDLNProof2_IncorrectProof(t *testing.T) {
 	}
 }
 
+func TestDlnVerifier_Cancel(t *testing.T) {
+	preParams, proof := prepareProofT(t)
+	message := &KGRound1Message{
+		Dlnproof_1: proof,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	verifier := NewDlnProofVerifier(ctx, 1)
+
+	const count = 20
+	type result struct {
+		ok  bool
+		err error
+	}
+	resultChan := make(chan result, count)
+	submitted := make(chan struct{})
+	go func() {
+		defer close(submitted)
+		for i := 0; i < count; i++ {
+			verifier.VerifyDLNProof1(message, preParams.H1i, preParams.H2i, preParams.NTildei, func(ok bool, err error) {
+				resultChan <- result{ok, err}
+			})
+		}
+	}()
+	// cancel once the first verification has completed
+	first := <-resultChan
+	assert.True(t, first.ok)
+	assert.NoError(t, first.err)
+	cancel()
+
+	aborted := 0
+	for i := 1; i < count; i++ {
+		res := <-resultChan
+		if res.err != nil {
+			assert.False(t, res.ok)
+			assert.ErrorIs(t, res.err, context.Canceled)
+			aborted++
+		}
+	}
+	assert.NotZero(t, aborted, "the queued verifications should have been aborted")
+
+	// every verification has run or was aborted, so submitting them must not block
+	select {
+	case <-submitted:
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "the verifications should not block after the cancellation")
+	}
+}
+
+type panickingMessage struct{}
+
+func (panickingMessage) UnmarshalDLNProof1() (*dlnproof.Proof, error) { panic("malformed") }
+func (panickingMessage) UnmarshalDLNProof2() (*dlnproof.Proof, error) { panic("malformed") }
+
+func TestDlnVerifier_Panic(t *testing.T) {
+	verifier := NewDlnProofVerifier(context.Background(), 1)
+	resultChan := make(chan error)
+	verifier.VerifyDLNProof2(panickingMessage{}, big.NewInt(2), big.NewInt(3), big.NewInt(5), func(ok bool, err error) {
+		assert.False(t, ok)
+		resultChan <- err
+	})
+	assert.Error(t, <-resultChan, "a panic should be reported to the callback")
+}
+
 func prepareProofT(t *testing.T) (*LocalPreParams, [][]byte) {
 	preParams, serialized, err := prepareProof()
 	if err != nil {
@@ -11,8 +11,8 @@ import (
 	"fmt"
 	"math/big"
 
-	"github.com/bnb-chain/tss-lib/v2/common"
 	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ecies"
 	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
@@ -31,6 +31,8 @@ type (
 
 		temp localTempData
 		data LocalPartySaveData
+		// whether the pre-parameters were given to NewLocalParty, which a checkpoint needs
+		preParamsGiven bool
 
 		// outbound messaging
 		out chan<- tss.Message
@@ -55,6 +57,8 @@ type (
 		ssidNonce     *big.Int
 		shares        vss.Shares
 		deCommitPolyG cmt.HashDeCommitment
+		// the hashes of the round 1 messages, when echo broadcast is enabled
+		r1EchoHashes [][]byte
 	}
 )
 
@@ -67,23 +71,22 @@ func NewLocalParty(
 ) tss.Party {
 	partyCount := params.PartyCount()
 	data := NewLocalPartySaveData(partyCount)
-	// when `optionalPreParams` is provided we'll use the pre-computed primes instead of generating them from scratch
+	// when `optionalPreParams` is provided we'll use the pre-computed primes instead of generating them from scratch.
+	// they are validated by Start
 	if 0 < len(optionalPreParams) {
 		if 1 < len(optionalPreParams) {
 			panic(errors.New("keygen.NewLocalParty expected 0 or 1 item in `optionalPreParams`"))
 		}
-		if !optionalPreParams[0].ValidateWithProof() {
-			panic(errors.New("`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib"))
-		}
 		data.LocalPreParams = optionalPreParams[0]
 	}
 	p := &LocalParty{
-		BaseParty: new(tss.BaseParty),
-		params:    params,
-		temp:      localTempData{},
-		data:      data,
-		out:       out,
-		end:       end,
+		BaseParty:      tss.NewBaseParty(func(tss.ParsedMessage) int { return partyCount }),
+		params:         params,
+		temp:           localTempData{},
+		data:           data,
+		preParamsGiven: 0 < len(optionalPreParams),
+		out:            out,
+		end:            end,
 	}
 	// msgs init
 	p.temp.kgRound1Messages = make([]tss.ParsedMessage, partyCount)
@@ -100,7 +103,15 @@ func (p *LocalParty) FirstRound() tss.Round {
 }
 
 func (p *LocalParty) Start() *tss.Error {
-	return tss.BaseStart(p, TaskName)
+	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
+		// pre-params missing a value would otherwise fail later on a nil pointer, or be replaced by new ones
+		if p.preParamsGiven {
+			if err := p.data.LocalPreParams.checkWithProof(); err != nil {
+				return round.WrapError(fmt.Errorf("%v; they might have been generated with an older version of tss-lib", err))
+			}
+		}
+		return nil
+	})
 }
 
 func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
@@ -115,18 +126,6 @@ func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroa
 	return p.Update(msg)
 }
 
-func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
-	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
-		return ok, err
-	}
-	// check that the message's "from index" will fit into the array
-	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
-		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
-			p.params.PartyCount(), msg.GetFrom().Index), msg.GetFrom())
-	}
-	return true, nil
-}
-
 func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
 	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
@@ -135,18 +134,25 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	fromPIdx := msg.GetFrom().Index
 
 	// switch/case is necessary to store any messages beyond current round
-	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	// replays are only rejected with Parameters.EnableReplayProtection. we expect the caller to apply spoofing protection.
 	switch msg.Content().(type) {
 	case *KGRound1Message:
 		p.temp.kgRound1Messages[fromPIdx] = msg
 	case *KGRound2Message1:
+		if p.params.ShareEncryptionKey() != nil {
+			decrypted, err := ecies.DecryptShareMessage(p.params, msg)
+			if err != nil {
+				return false, p.WrapError(err, msg.GetFrom())
+			}
+			msg = decrypted
+		}
 		p.temp.kgRound2Message1s[fromPIdx] = msg
 	case *KGRound2Message2:
 		p.temp.kgRound2Message2s[fromPIdx] = msg
 	case *KGRound3Message:
 		p.temp.kgRound3Messages[fromPIdx] = msg
 	default: // unrecognised message, just ignore!
-		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		p.params.Logger().Warningf("unrecognised message ignored: %v", msg)
 		return false, nil
 	}
 	return true, nil
@@ -8,6 +8,7 @@ package keygen
 
 import (
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"encoding/json"
 	"fmt"
@@ -19,9 +20,11 @@ import (
 
 	"github.com/ipfs/go-log"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
 	"github.com/bnb-chain/tss-lib/v2/crypto/dlnproof"
 	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
 	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
@@ -56,10 +59,12 @@ func TestStartRound1Paillier(t *testing.T) {
 
 	var lp *LocalParty
 	out := make(chan tss.Message, len(pIDs))
+	// a party without peers needs no messages, so it goes through all the rounds on Start
+	end := make(chan *LocalPartySaveData, 1)
 	if 0 < len(fixtures) {
-		lp = NewLocalParty(params, out, nil, fixtures[0].LocalPreParams).(*LocalParty)
+		lp = NewLocalParty(params, out, end, fixtures[0].LocalPreParams).(*LocalParty)
 	} else {
-		lp = NewLocalParty(params, out, nil).(*LocalParty)
+		lp = NewLocalParty(params, out, end).(*LocalParty)
 	}
 	if err := lp.Start(); err != nil {
 		assert.FailNow(t, err.Error())
@@ -96,10 +101,12 @@ func TestFinishAndSaveH1H2(t *testing.T) {
 
 	var lp *LocalParty
 	out := make(chan tss.Message, len(pIDs))
+	// a party without peers needs no messages, so it goes through all the rounds on Start
+	end := make(chan *LocalPartySaveData, 1)
 	if 0 < len(fixtures) {
-		lp = NewLocalParty(params, out, nil, fixtures[0].LocalPreParams).(*LocalParty)
+		lp = NewLocalParty(params, out, end, fixtures[0].LocalPreParams).(*LocalParty)
 	} else {
-		lp = NewLocalParty(params, out, nil).(*LocalParty)
+		lp = NewLocalParty(params, out, end).(*LocalParty)
 	}
 	if err := lp.Start(); err != nil {
 		assert.FailNow(t, err.Error())
@@ -128,6 +135,36 @@ func TestFinishAndSaveH1H2(t *testing.T) {
 	assert.NotZero(t, lp.data.NTildei, "n-tilde should be non-zero")
 }
 
+func TestInvalidPreParams(t *testing.T) {
+	setUp("info")
+
+	fixtures, _, err := LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "the keygen fixtures are required") {
+		return
+	}
+	pIDs := tss.GenerateTestPartyIDs(2)
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), 1)
+
+	preParams := fixtures[0].LocalPreParams
+	preParams.NTildei = nil
+	outCh := make(chan tss.Message, len(pIDs))
+	var lp tss.Party
+	assert.NotPanics(t, func() { lp = NewLocalParty(params, outCh, nil, preParams) })
+	err2 := lp.Start()
+	if assert.NotNil(t, err2) {
+		assert.Contains(t, err2.Error(), "invalid pre-params: NTildei is nil")
+		assert.Empty(t, err2.Culprits())
+	}
+	assert.Empty(t, outCh, "no message should be sent")
+
+	preParams = fixtures[0].LocalPreParams
+	preParams.PaillierSK = nil
+	err2 = NewLocalParty(params, outCh, nil, preParams).Start()
+	if assert.NotNil(t, err2) {
+		assert.Contains(t, err2.Error(), "invalid pre-params: PaillierSK is nil")
+	}
+}
+
 func TestBadMessageCulprits(t *testing.T) {
 	setUp("debug")
 
@@ -166,6 +203,93 @@ func TestBadMessageCulprits(t *testing.T) {
 		err2.Error())
 }
 
+func TestSenderIndexOutOfRangeCulprits(t *testing.T) {
+	setUp("info")
+
+	fixtures, _, err := LoadKeygenTestFixtures(2)
+	if !assert.NoError(t, err, "the keygen fixtures are required") {
+		return
+	}
+	pIDs := tss.GenerateTestPartyIDs(2)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[0], len(pIDs), 1)
+	lp := NewLocalParty(params, make(chan tss.Message, len(pIDs)), nil, fixtures[0].LocalPreParams).(*LocalParty)
+	if err := lp.Start(); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	// the sender claims an index beyond the committee; storing its message would index past the arrays
+	from := tss.NewPartyID("outsider", "P[outsider]", big.NewInt(1000))
+	from.Index = len(pIDs) + 5
+	pre1 := fixtures[1].LocalPreParams
+	dlnProof := dlnproof.NewDLNProof(pre1.H1i, pre1.H2i, pre1.Alpha, pre1.P, pre1.Q, pre1.NTildei, rand.Reader)
+	msg, err := NewKGRound1Message(from, big.NewInt(1), &pre1.PaillierSK.PublicKey, pre1.NTildei, pre1.H1i, pre1.H2i, dlnProof, dlnProof)
+	assert.NoError(t, err)
+	ok, err2 := lp.Update(msg)
+	assert.False(t, ok)
+	if assert.NotNil(t, err2) {
+		assert.Equal(t, []*tss.PartyID{from}, err2.Culprits())
+		assert.Contains(t, err2.Error(), "received msg with a sender index too great (1 <= 7)")
+	}
+}
+
+func TestDuplicateRound1ValuesCulprits(t *testing.T) {
+	setUp("info")
+
+	fixtures, _, err := LoadKeygenTestFixtures(2)
+	if !assert.NoError(t, err, "the keygen fixtures are required") {
+		return
+	}
+	pIDs := tss.GenerateTestPartyIDs(2)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	pre0, pre1 := fixtures[0].LocalPreParams, fixtures[1].LocalPreParams
+	dlnProof := dlnproof.NewDLNProof(pre1.H1i, pre1.H2i, pre1.Alpha, pre1.P, pre1.Q, pre1.NTildei, rand.Reader)
+
+	// party 1 presents the NTilde or the paillier N of party 0
+	cases := []struct {
+		name       string
+		paillierPK *paillier.PublicKey
+		nTilde     *big.Int
+	}{
+		{"NTilde", &pre1.PaillierSK.PublicKey, pre0.NTildei},
+		{"paillier N", &pre0.PaillierSK.PublicKey, pre1.NTildei},
+	}
+	for _, c := range cases {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[0], len(pIDs), 1)
+		lp := NewLocalParty(params, make(chan tss.Message, len(pIDs)), nil, pre0).(*LocalParty)
+		if err := lp.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		msg, err := NewKGRound1Message(pIDs[1], big.NewInt(1), c.paillierPK, c.nTilde, pre1.H1i, pre1.H2i, dlnProof, dlnProof)
+		assert.NoError(t, err)
+		ok, err2 := lp.Update(msg)
+		assert.False(t, ok, c.name)
+		if assert.NotNil(t, err2, c.name) {
+			assert.Equal(t, []*tss.PartyID{pIDs[1]}, err2.Culprits(), c.name)
+			assert.Contains(t, err2.Error(), "already used by another party", c.name)
+		}
+	}
+}
+
+func TestStartDuplicatePartyKeys(t *testing.T) {
+	setUp("info")
+
+	fixtures, _, err := LoadKeygenTestFixtures(2)
+	if !assert.NoError(t, err, "the keygen fixtures are required") {
+		return
+	}
+	pIDs := tss.GenerateTestPartyIDs(2)
+	dup := tss.NewPartyID("3", "P[3]", pIDs[1].KeyInt())
+	dup.Index = 2
+	p2pCtx := tss.NewPeerContext(append(tss.SortedPartyIDs{}, pIDs[0], pIDs[1], dup))
+	params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[0], 3, 1)
+	lp := NewLocalParty(params, make(chan tss.Message, 3), nil, fixtures[0].LocalPreParams)
+	err2 := lp.Start()
+	if assert.NotNil(t, err2, "parties with the same key must be rejected") {
+		assert.Contains(t, err2.Error(), "have the same key")
+	}
+}
+
 func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
 	setUp("info")
 
@@ -243,7 +367,7 @@ keygen:
 			// .. here comes a workaround to recover this party's index (it was removed from save data)
 			index, err := save.OriginalIndex()
 			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
-			tryWriteTestFixtureFile(t, index, *save)
+			tryWriteTestFixtureFile(t, testFixtureDirFormat, index, *save)
 
 			atomic.AddInt32(&ended, 1)
 			if atomic.LoadInt32(&ended) == int32(len(pIDs)) {
@@ -277,6 +401,12 @@ keygen:
 					BigXj := Pj.data.BigXj[j]
 					assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
 
+					// all parties save the same chain code
+					assert.Len(t, Pj.data.ChainCode, 32)
+					assert.Equal(t, save.ChainCode, Pj.data.ChainCode, "ensure all parties agree on the chain code")
+					assert.Equal(t, threshold, Pj.data.KeyThreshold)
+					assert.Equal(t, tss.Secp256k1, Pj.data.CurveName)
+
 					// fails if threshold cannot be satisfied (bad share)
 					{
 						badShares := pShares[:threshold]
@@ -338,8 +468,8 @@ keygen:
 	}
 }
 
-func tryWriteTestFixtureFile(t *testing.T, index int, data LocalPartySaveData) {
-	fixtureFileName := makeTestFixtureFilePath(index)
+func tryWriteTestFixtureFile(t *testing.T, dirFormat string, index int, data LocalPartySaveData) {
+	fixtureFileName := makeTestFixtureFilePathIn(dirFormat, index)
 
 	// fixture file does not already exist?
 	// if it does, we won't re-create it here
@@ -363,3 +493,422 @@ func tryWriteTestFixtureFile(t *testing.T, index int, data LocalPartySaveData) {
 	}
 	//
 }
+
+// keygenRun is a keygen of the parties of `pIDs` with the pre-params of the fixtures, for the E2E tests
+type keygenRun struct {
+	parties []*LocalParty
+	errCh   chan *tss.Error
+	outCh   chan tss.Message
+	endCh   chan *LocalPartySaveData
+}
+
+// newKeygenRun creates the parties on `ec`; `modify`, if not nil, sets up the parameters of party i.
+// The channels are large enough for the messages to be delivered one at a time from the test goroutine.
+func newKeygenRun(ec elliptic.Curve, pIDs tss.SortedPartyIDs, fixtures []LocalPartySaveData, threshold int,
+	modify func(i int, params *tss.Parameters)) *keygenRun {
+	r := &keygenRun{
+		parties: make([]*LocalParty, 0, len(pIDs)),
+		errCh:   make(chan *tss.Error, len(pIDs)*len(pIDs)),
+		outCh:   make(chan tss.Message, 1000),
+		endCh:   make(chan *LocalPartySaveData, len(pIDs)),
+	}
+	p2pCtx := tss.NewPeerContext(pIDs)
+	for i := range pIDs {
+		params := tss.NewParameters(ec, p2pCtx, pIDs[i], len(pIDs), threshold)
+		if modify != nil {
+			modify(i, params)
+		}
+		r.parties = append(r.parties, NewLocalParty(params, r.outCh, r.endCh, fixtures[i].LocalPreParams).(*LocalParty))
+	}
+	return r
+}
+
+func (r *keygenRun) start(P *LocalParty) {
+	if err := P.Start(); err != nil {
+		r.errCh <- err
+	}
+}
+
+// route hands each message to `deliver` once for every recipient until all the parties have ended, and returns their
+// save data in index order. It fails the test on the first error.
+func (r *keygenRun) route(t *testing.T, deliver func(P *LocalParty, msg tss.Message)) []*LocalPartySaveData {
+	saves := make([]*LocalPartySaveData, len(r.parties))
+	for ended := 0; ended < len(r.parties); {
+		select {
+		case err := <-r.errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-r.outCh:
+			if dest := msg.GetTo(); dest != nil {
+				deliver(r.parties[dest[0].Index], msg)
+				continue
+			}
+			for _, P := range r.parties {
+				if P.PartyID().Index != msg.GetFrom().Index {
+					deliver(P, msg)
+				}
+			}
+		case save := <-r.endCh:
+			index, err := save.OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			saves[index] = save
+			ended++
+		}
+	}
+	return saves
+}
+
+// runKeygen runs keygen concurrently and returns the save data in index order; see newKeygenRun for `modify`
+func runKeygen(t *testing.T, ec elliptic.Curve, pIDs tss.SortedPartyIDs, fixtures []LocalPartySaveData, threshold int,
+	modify func(i int, params *tss.Parameters)) []*LocalPartySaveData {
+	r := newKeygenRun(ec, pIDs, fixtures, threshold, modify)
+	for _, P := range r.parties {
+		go r.start(P)
+	}
+	return r.route(t, func(P *LocalParty, msg tss.Message) {
+		go test.SharedPartyUpdater(P, msg, r.errCh)
+	})
+}
+
+func TestE2EMessagesOutOfOrder(t *testing.T) {
+	setUp("info")
+
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	r := newKeygenRun(tss.S256(), pIDs, fixtures, testThreshold, func(_ int, params *tss.Parameters) {
+		// do not use in untrusted setting
+		params.SetNoProofMod()
+		// do not use in untrusted setting
+		params.SetNoProofFac()
+	})
+	updater := test.SharedPartyUpdater
+
+	// the messages to the late party are held back, then delivered in reverse order:
+	// first the round 1 messages before it has started, then the round 3 messages before those of round 2
+	late := r.parties[0]
+	for _, P := range r.parties[1:] {
+		go r.start(P)
+	}
+	held, release := make([]tss.Message, 0), []int{len(pIDs) - 1, 3 * (len(pIDs) - 1)}
+	deliverHeld := func(msgs []tss.Message, start bool) {
+		for i := len(msgs) - 1; 0 <= i; i-- {
+			updater(late, msgs[i], r.errCh)
+		}
+		if start {
+			r.start(late)
+		}
+	}
+	saves := r.route(t, func(P *LocalParty, msg tss.Message) {
+		if P != late || len(release) == 0 {
+			go updater(P, msg, r.errCh)
+			return
+		}
+		if held = append(held, msg); len(held) == release[0] {
+			go deliverHeld(held, len(release) == 2)
+			held, release = make([]tss.Message, 0), release[1:]
+		}
+	})
+	assert.Empty(t, release, "all the held messages should have been delivered")
+	for _, save := range saves[1:] {
+		assert.True(t, saves[0].ECDSAPub.Equals(save.ECDSAPub), "ensure all parties have the same public key")
+	}
+}
+
+func TestE2EEchoBroadcastEquivocation(t *testing.T) {
+	setUp("info")
+
+	fixtures, _, err := LoadKeygenTestFixtures(4)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	pIDs := tss.GenerateTestPartyIDs(len(fixtures))
+	r := newKeygenRun(tss.S256(), pIDs, fixtures, 1, func(_ int, params *tss.Parameters) {
+		// do not use in untrusted setting
+		params.SetNoProofMod()
+		// do not use in untrusted setting
+		params.SetNoProofFac()
+		params.EnableEchoBroadcast()
+	})
+	for _, P := range r.parties {
+		go r.start(P)
+	}
+
+	// party 0 sends another commitment in its round 1 broadcast to party 1 than to the others
+	equivocator, victim := pIDs[0], r.parties[1]
+	equivocate := func(msg tss.Message) tss.Message {
+		content := proto.Clone(msg.(tss.ParsedMessage).Content()).(*KGRound1Message)
+		content.Commitment = big.NewInt(42).Bytes()
+		meta := tss.MessageRouting{From: msg.GetFrom(), IsBroadcast: true}
+		return tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content))
+	}
+
+	// every honest party names the equivocating party
+	honestErrs := 0
+	for honestErrs < len(pIDs)-1 {
+		select {
+		case err := <-r.errCh:
+			if err.Victim() == equivocator {
+				continue
+			}
+			assert.Equal(t, []*tss.PartyID{equivocator}, err.Culprits(), err.Error())
+			assert.Contains(t, err.Error(), "echo broadcast")
+			assert.Equal(t, 3, err.Round())
+			honestErrs++
+		case msg := <-r.outCh:
+			if dest := msg.GetTo(); dest != nil {
+				go test.SharedPartyUpdater(r.parties[dest[0].Index], msg, r.errCh)
+				continue
+			}
+			for _, P := range r.parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				if P == victim && msg.GetFrom() == equivocator && msg.Type() == "binance.tsslib.ecdsa.keygen.KGRound1Message" {
+					go test.SharedPartyUpdater(P, equivocate(msg), r.errCh)
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, r.errCh)
+			}
+		case <-r.endCh:
+			assert.FailNow(t, "the keygen should not complete")
+		}
+	}
+}
+
+func TestE2ENoProofDln(t *testing.T) {
+	setUp("info")
+
+	fixtures, _, err := LoadKeygenTestFixtures(4)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	pIDs := tss.GenerateTestPartyIDs(len(fixtures))
+	saves := runKeygen(t, tss.S256(), pIDs, fixtures, 1, func(_ int, params *tss.Parameters) {
+		// do not use in untrusted setting
+		params.SetNoProofDln()
+	})
+	for _, save := range saves {
+		assert.True(t, saves[0].ECDSAPub.Equals(save.ECDSAPub), "ensure all parties have the same public key")
+		// the values of the other parties are stored even though their proofs were not verified
+		for j, fixture := range fixtures {
+			assert.Equal(t, 0, fixture.NTildei.Cmp(save.NTildej[j]))
+			assert.Equal(t, 0, fixture.H1i.Cmp(save.H1j[j]))
+			assert.Equal(t, 0, fixture.H2i.Cmp(save.H2j[j]))
+		}
+	}
+}
+
+func TestE2EWithShareEncryption(t *testing.T) {
+	setUp("info")
+
+	fixtures, _, err := LoadKeygenTestFixtures(4)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	pIDs := tss.GenerateTestPartyIDs(len(fixtures))
+
+	// PHASE: transport keys
+	transportKeys := make([]*ecdsa.PrivateKey, len(pIDs))
+	transportPubs := make([]*ecdsa.PublicKey, len(pIDs))
+	for j := range pIDs {
+		transportKeys[j], err = ecdsa.GenerateKey(tss.S256(), rand.Reader)
+		assert.NoError(t, err)
+		transportPubs[j] = &transportKeys[j].PublicKey
+	}
+
+	// PHASE: keygen
+	// messages are delivered one at a time so that the p2p shares can be inspected on the wire
+	r := newKeygenRun(tss.S256(), pIDs, fixtures, 1, func(i int, params *tss.Parameters) {
+		params.SetShareEncryptionKeys(transportKeys[i], transportPubs)
+	})
+	for _, P := range r.parties {
+		r.start(P)
+	}
+	saves := r.route(t, func(P *LocalParty, msg tss.Message) {
+		if msg.GetTo() != nil {
+			// a relay must not see the share
+			wire, _, err := msg.WireBytes()
+			assert.NoError(t, err)
+			parsed, err := tss.ParseWireMessage(wire, msg.GetFrom(), msg.IsBroadcast())
+			assert.NoError(t, err)
+			if content, ok := parsed.Content().(*KGRound2Message1); ok {
+				share := r.parties[msg.GetFrom().Index].temp.shares[P.PartyID().Index].Share
+				assert.NotEqual(t, share.Bytes(), content.GetShare())
+			}
+		}
+		test.SharedPartyUpdater(P, msg, r.errCh)
+	})
+	for index, save := range saves {
+		assert.True(t, save.ECDSAPub.Equals(saves[0].ECDSAPub), "all parties should get the same public key")
+		assert.True(t, crypto.ScalarBaseMult(tss.S256(), save.Xi).Equals(save.BigXj[index]), "ensure BigX_j == g^x_j")
+	}
+}
+
+func TestE2ECheckpointResume(t *testing.T) {
+	setUp("info")
+
+	fixtures, _, err := LoadKeygenTestFixtures(4)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	pIDs := tss.GenerateTestPartyIDs(len(fixtures))
+	paramsList := make([]*tss.Parameters, len(pIDs))
+
+	// messages are delivered one at a time so that party 0 can be checkpointed right after it has started round 2
+	r := newKeygenRun(tss.S256(), pIDs, fixtures, 1, func(i int, params *tss.Parameters) {
+		paramsList[i] = params
+	})
+	for _, P := range r.parties {
+		r.start(P)
+	}
+
+	// the resumed party runs alongside party 0; it receives the same messages, but what it sends is dropped
+	var resumed *LocalParty
+	ledger := tss.NewMemoryCheckpointLedger()
+	resumedOutCh := make(chan tss.Message, 1000)
+	resumedEndCh := make(chan *LocalPartySaveData, 1)
+	saves := r.route(t, func(P *LocalParty, msg tss.Message) {
+		if resumed == nil && msg.GetFrom() == pIDs[0] && msg.Type() == "binance.tsslib.ecdsa.keygen.KGRound2Message2" {
+			state, err := r.parties[0].Checkpoint()
+			assert.NoError(t, err, "should checkpoint")
+
+			// the pre-parameters of another party must not open the checkpoint
+			_, err = ResumeFromCheckpoint(state, paramsList[0], ledger, resumedOutCh, resumedEndCh, fixtures[1].LocalPreParams)
+			assert.Error(t, err)
+
+			// nor must the same pre-parameters in another session
+			otherParams := tss.NewParameters(tss.S256(), tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), 1)
+			otherParams.SetSSIDNonce(big.NewInt(1))
+			_, err = ResumeFromCheckpoint(state, otherParams, ledger, resumedOutCh, resumedEndCh, fixtures[0].LocalPreParams)
+			assert.Error(t, err)
+
+			// the checkpoint has the share of party 0 only
+			plain, err := tss.OpenSnapshot(checkpointKey(fixtures[0].LocalPreParams, r.parties[0].temp.ssid), checkpointVersion, state)
+			assert.NoError(t, err)
+			cp := new(checkpointState)
+			assert.NoError(t, json.Unmarshal(plain, cp))
+			assert.Equal(t, 2, cp.Round)
+			assert.NotNil(t, cp.Shares[0])
+			for j := 1; j < len(pIDs); j++ {
+				assert.Nil(t, cp.Shares[j])
+			}
+			assert.Nil(t, cp.Save.PaillierSK)
+
+			rP, err := ResumeFromCheckpoint(state, paramsList[0], ledger, resumedOutCh, resumedEndCh, fixtures[0].LocalPreParams)
+			if !assert.NoError(t, err, "should resume") {
+				t.FailNow()
+			}
+			assert.Equal(t, r.parties[0].String(), rP.String())
+			assert.Error(t, rP.Start(), "a resumed party must not start again")
+			resumed = rP.(*LocalParty)
+			_, err = ResumeFromCheckpoint(state, paramsList[0], ledger, resumedOutCh, resumedEndCh, fixtures[0].LocalPreParams)
+			assert.Error(t, err, "a checkpoint must not be resumed twice")
+		}
+		test.SharedPartyUpdater(P, msg, r.errCh)
+		if resumed != nil && P == r.parties[0] {
+			test.SharedPartyUpdater(resumed, msg, r.errCh)
+		}
+	})
+	if !assert.NotNil(t, resumed, "party 0 should have been resumed from a checkpoint") {
+		return
+	}
+	select {
+	case save := <-resumedEndCh:
+		assert.Equal(t, saves[0], save, "the resumed party should end with the same save data")
+	default:
+		assert.Fail(t, "the resumed party should have ended")
+	}
+	for _, save := range saves[1:] {
+		assert.True(t, saves[0].ECDSAPub.Equals(save.ECDSAPub), "ensure all parties have the same public key")
+	}
+}
+
+func TestE2EP384AndSaveFixtures(t *testing.T) {
+	setUp("info")
+
+	// the pre-params do not depend on the curve, so those of the secp256k1 fixtures are used
+	threshold := testThreshold
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	ec := elliptic.P384()
+	saves := make([]LocalPartySaveData, len(pIDs))
+	for index, save := range runKeygen(t, ec, pIDs, fixtures, threshold, nil) {
+		tryWriteTestFixtureFile(t, testP384FixtureDirFormat, index, *save)
+		saves[index] = *save
+	}
+
+	for _, save := range saves {
+		assert.Equal(t, tss.P384, save.CurveName)
+		assert.True(t, tss.SameCurve(ec, save.ECDSAPub.Curve()))
+		assert.True(t, saves[0].ECDSAPub.Equals(save.ECDSAPub), "ensure all parties have the same public key")
+	}
+	// any t+1 of the shares recover the private key of the public key
+	sk, err := ReconstructPrivateKey(saves[len(saves)-threshold-1:])
+	if assert.NoError(t, err) {
+		assert.True(t, crypto.ScalarBaseMult(ec, sk).Equals(saves[0].ECDSAPub))
+	}
+}
+
+func TestSaveDataExtendedKey(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// the fixtures predate the chain code, which is then derived from the public key
+	extKey, err := keys[0].ExtendedKey()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, MasterChainCode(keys[0].ECDSAPub), extKey.ChainCode)
+	keys[1].ChainCode = MasterChainCode(keys[1].ECDSAPub)
+	extKey1, err := keys[1].ExtendedKey()
+	assert.NoError(t, err)
+	assert.Equal(t, extKey.String(), extKey1.String())
+
+	_, child, err := ckd.DeriveChildKey(7, extKey, tss.S256())
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint8(1), child.Depth)
+		assert.False(t, child.X.Cmp(extKey.X) == 0, "the child key should differ from the master key")
+	}
+
+	keys[2].ChainCode = []byte{1, 2, 3}
+	_, err = keys[2].ExtendedKey()
+	assert.Error(t, err)
+}
+
+func TestSaveDataMetadata(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// the fixtures predate the saved threshold, which is then inferred from BigXj
+	for _, key := range keys {
+		assert.Equal(t, 0, key.KeyThreshold)
+		assert.Equal(t, testThreshold, key.Threshold())
+		assert.Equal(t, testParticipants, key.PartyCount())
+		ids := key.ShareIDs()
+		if assert.Len(t, ids, testParticipants) {
+			assert.Equal(t, key.Ks, ids)
+			ids[0].SetInt64(0)
+			assert.NotEqual(t, 0, key.Ks[0].Sign(), "ShareIDs should return a copy")
+		}
+	}
+
+	keys[0].KeyThreshold = 3
+	assert.Equal(t, 3, keys[0].Threshold())
+
+	// a subset of the parties still determines the threshold if it has at least t+1 of them
+	keys[1].BigXj, keys[1].Ks = keys[1].BigXj[:testThreshold+1], keys[1].Ks[:testThreshold+1]
+	assert.Equal(t, testThreshold, keys[1].Threshold())
+	keys[2].BigXj, keys[2].Ks = keys[2].BigXj[:testThreshold], keys[2].Ks[:testThreshold]
+	assert.Equal(t, -1, keys[2].Threshold())
+
+	// the fixtures predate the saved curve name, which is then taken from ECDSAPub
+	assert.Equal(t, tss.CurveName(""), keys[3].CurveName)
+	assert.NoError(t, keys[3].CheckCurve(tss.S256()))
+	assert.Error(t, keys[3].CheckCurve(elliptic.P256()))
+	keys[3].CurveName = tss.Secp256k1
+	assert.NoError(t, keys[3].CheckCurve(tss.S256()))
+	assert.EqualError(t, keys[3].CheckCurve(elliptic.P256()), "the key is on the curve secp256k1 but the parameters are on the curve P-256")
+}
@@ -112,6 +112,15 @@ func NewKGRound2Message1(
 	to, from *tss.PartyID,
 	share *vss.Share,
 	proof *facproof.ProofFac,
+) tss.ParsedMessage {
+	return newKGRound2Message1(to, from, share.Share.Bytes(), proof)
+}
+
+// newKGRound2Message1 takes the share as bytes, which may be encrypted to the recipient's transport key
+func newKGRound2Message1(
+	to, from *tss.PartyID,
+	shareBz []byte,
+	proof *facproof.ProofFac,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
@@ -120,7 +129,7 @@ func NewKGRound2Message1(
 	}
 	proofBzs := proof.Bytes()
 	content := &KGRound2Message1{
-		Share:    share.Share.Bytes(),
+		Share:    shareBz,
 		FacProof: proofBzs[:],
 	}
 	msg := tss.NewMessageWrapper(meta, content)
@@ -148,6 +157,16 @@ func NewKGRound2Message2(
 	from *tss.PartyID,
 	deCommitment cmt.HashDeCommitment,
 	proof *modproof.ProofMod,
+) tss.ParsedMessage {
+	return newKGRound2Message2(from, deCommitment, proof, nil)
+}
+
+// newKGRound2Message2 also carries the hashes of the round 1 messages when `echo` is given, see tss.EnableEchoBroadcast
+func newKGRound2Message2(
+	from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+	proof *modproof.ProofMod,
+	echo [][]byte,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
@@ -158,6 +177,7 @@ func NewKGRound2Message2(
 	content := &KGRound2Message2{
 		DeCommitment: dcBzs,
 		ModProof:     proofBzs[:],
+		Echo:         echo,
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
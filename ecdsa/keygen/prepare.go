@@ -10,6 +10,7 @@ import (
 	"context"
 	"crypto/rand"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
 	"runtime"
@@ -22,37 +23,104 @@ import (
 const (
 	// Using a modulus length of 2048 is recommended in the GG18 spec
 	paillierModulusLen = 2048
-	// Two 1024-bit safe primes to produce NTilde
-	safePrimeBitLen = 1024
 	// Ticker for printing log statements while generating primes/modulus
 	logProgressTickInterval = 8 * time.Second
+	// Ticker for reporting progress to a PreParamsProgressFunc
+	progressTickInterval = 1 * time.Second
 	// Safe big len using random for ssid
 	SafeBitLen = 1024
 )
 
+const (
+	PreParamsPhasePaillier   PreParamsPhase = "paillier"
+	PreParamsPhaseSafePrimes PreParamsPhase = "safeprimes"
+)
+
+type (
+	// PreParamsPhase names a part of the pre-parameters generation; the phases run concurrently.
+	PreParamsPhase string
+
+	// PreParamsProgress is an event reported while generating pre-parameters.
+	PreParamsProgress struct {
+		Phase   PreParamsPhase // the phase still in progress; empty in the final event
+		Elapsed time.Duration  // time since the generation started
+		Done    bool           // set in the final event only
+		Err     error          // the error that ended the generation, if any, in the final event
+	}
+
+	// PreParamsProgressFunc receives progress events. It is always called from the goroutine that is generating.
+	PreParamsProgressFunc func(PreParamsProgress)
+)
+
+// supportedModulusLens are the lengths of the Paillier and NTilde moduli that keygen accepts.
+// NTilde is the product of two safe primes of half the length.
+var supportedModulusLens = []int{2048, 3072, 4096}
+
 // GeneratePreParams finds two safe primes and computes the Paillier secret required for the protocol.
 // This can be a time consuming process so it is recommended to do it out-of-band.
 // If not specified, a concurrency value equal to the number of available CPU cores will be used.
-// If pre-parameters could not be generated before the timeout, an error is returned.
+// If pre-parameters could not be generated before the timeout, common.ErrGeneratorCancelled is returned.
 func GeneratePreParams(timeout time.Duration, optionalConcurrency ...int) (*LocalPreParams, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 	return GeneratePreParamsWithContext(ctx, optionalConcurrency...)
 }
 
-// GeneratePreParams finds two safe primes and computes the Paillier secret required for the protocol.
+// GeneratePreParamsWithContext finds two safe primes and computes the Paillier secret required for the protocol.
 // This can be a time consuming process so it is recommended to do it out-of-band.
 // If not specified, a concurrency value equal to the number of available CPU cores will be used.
-// If pre-parameters could not be generated before the context is done, an error is returned.
+// If the context is done before the pre-parameters are generated, common.ErrGeneratorCancelled is returned and all
+// of the generator goroutines exit.
 func GeneratePreParamsWithContext(ctx context.Context, optionalConcurrency ...int) (*LocalPreParams, error) {
 	return GeneratePreParamsWithContextAndRandom(ctx, rand.Reader, optionalConcurrency...)
 }
 
-// GeneratePreParams finds two safe primes and computes the Paillier secret required for the protocol.
-// This can be a time consuming process so it is recommended to do it out-of-band.
-// If not specified, a concurrency value equal to the number of available CPU cores will be used.
-// If pre-parameters could not be generated before the context is done, an error is returned.
+// GeneratePreParamsWithContextAndRandom is like GeneratePreParamsWithContext but draws its randomness from `rand`.
+// If the context is done before the pre-parameters are generated, common.ErrGeneratorCancelled is returned.
 func GeneratePreParamsWithContextAndRandom(ctx context.Context, rand io.Reader, optionalConcurrency ...int) (*LocalPreParams, error) {
+	return generatePreParams(ctx, rand, paillierModulusLen, nil, optionalConcurrency...)
+}
+
+// GeneratePreParamsWithProgress is like GeneratePreParamsWithContext but also reports its progress to `progress`:
+// every second an event for each phase still in progress, then a final event with Done set. The events are sent from
+// the calling goroutine, before this function returns. A nil `progress` behaves like GeneratePreParamsWithContext.
+func GeneratePreParamsWithProgress(ctx context.Context, progress PreParamsProgressFunc, optionalConcurrency ...int) (*LocalPreParams, error) {
+	start := time.Now()
+	preParams, err := generatePreParams(ctx, rand.Reader, paillierModulusLen, progress, optionalConcurrency...)
+	if progress != nil {
+		progress(PreParamsProgress{Elapsed: time.Since(start), Done: true, Err: err})
+	}
+	return preParams, err
+}
+
+// GeneratePreParamsWithModulusLen is like GeneratePreParams but generates Paillier and NTilde moduli of
+// `modulusBitLen` bits, which must be one of 2048, 3072 or 4096. Larger moduli are meant for long-lived keys.
+// The generation time grows quickly with the length: on an 8-core machine expect around a minute for 2048 bits,
+// several minutes for 3072 bits and 10-30 minutes for 4096 bits, so the timeout should be set accordingly.
+// Keygen accepts the resulting pre-parameters unchanged; all of the parties should use the same length.
+func GeneratePreParamsWithModulusLen(timeout time.Duration, modulusBitLen int, optionalConcurrency ...int) (*LocalPreParams, error) {
+	if !IsSupportedModulusLen(modulusBitLen) {
+		return nil, fmt.Errorf("GeneratePreParamsWithModulusLen: unsupported modulus length %d, expected one of %v",
+			modulusBitLen, supportedModulusLens)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return generatePreParams(ctx, rand.Reader, modulusBitLen, nil, optionalConcurrency...)
+}
+
+// IsSupportedModulusLen reports whether `bitLen` is a Paillier and NTilde modulus length accepted by keygen.
+func IsSupportedModulusLen(bitLen int) bool {
+	for _, l := range supportedModulusLens {
+		if bitLen == l {
+			return true
+		}
+	}
+	return false
+}
+
+func generatePreParams(ctx context.Context, rand io.Reader, modulusBitLen int, progress PreParamsProgressFunc, optionalConcurrency ...int) (*LocalPreParams, error) {
+	start := time.Now()
+	safePrimeBitLen := modulusBitLen / 2
 	var concurrency int
 	if 0 < len(optionalConcurrency) {
 		if 1 < len(optionalConcurrency) {
@@ -62,10 +130,16 @@ func GeneratePreParamsWithContextAndRandom(ctx context.Context, rand io.Reader,
 	} else {
 		concurrency = runtime.NumCPU()
 	}
-	if concurrency /= 3; concurrency < 1 {
-		concurrency = 1
+	// a third of the routines search the NTilde primes and two thirds the Paillier primes, which have to be far apart.
+	// the search time grows steeply with the length, so 4096-bit moduli are given at least two routines each
+	if concurrency /= 3; concurrency < modulusBitLen/2048 {
+		concurrency = modulusBitLen / 2048
 	}
 
+	// both generators are stopped as soon as one of them fails or the caller gives up
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	// prepare for concurrent Paillier and safe prime generation
 	paiCh := make(chan *paillier.PrivateKey, 1)
 	sgpCh := make(chan []*common.GermainSafePrime, 1)
@@ -75,7 +149,7 @@ func GeneratePreParamsWithContextAndRandom(ctx context.Context, rand io.Reader,
 		common.Logger.Info("generating the Paillier modulus, please wait...")
 		start := time.Now()
 		// more concurrency weight is assigned here because the paillier primes have a requirement of having "large" P-Q
-		PiPaillierSk, _, err := paillier.GenerateKeyPair(ctx, rand, paillierModulusLen, concurrency*2)
+		PiPaillierSk, _, err := paillier.GenerateKeyPair(ctx, rand, modulusBitLen, concurrency*2)
 		if err != nil {
 			ch <- nil
 			return
@@ -98,8 +172,13 @@ func GeneratePreParamsWithContextAndRandom(ctx context.Context, rand io.Reader,
 		ch <- sgps
 	}(sgpCh)
 
-	// this ticker will print a log statement while the generating is still in progress
-	logProgressTicker := time.NewTicker(logProgressTickInterval)
+	// this ticker will print a log statement, or report to `progress`, while the generating is still in progress
+	tickInterval := logProgressTickInterval
+	if progress != nil {
+		tickInterval = progressTickInterval
+	}
+	logProgressTicker := time.NewTicker(tickInterval)
+	defer logProgressTicker.Stop()
 
 	// errors can be thrown in the following code; consume chans to end goroutines here
 	var sgps []*common.GermainSafePrime
@@ -108,12 +187,24 @@ consumer:
 	for {
 		select {
 		case <-logProgressTicker.C:
-			common.Logger.Info("still generating primes...")
+			if progress == nil {
+				common.Logger.Info("still generating primes...")
+				continue
+			}
+			if paiSK == nil {
+				progress(PreParamsProgress{Phase: PreParamsPhasePaillier, Elapsed: time.Since(start)})
+			}
+			if sgps == nil {
+				progress(PreParamsProgress{Phase: PreParamsPhaseSafePrimes, Elapsed: time.Since(start)})
+			}
 		case sgps = <-sgpCh:
 			if sgps == nil ||
 				sgps[0] == nil || sgps[1] == nil ||
 				!sgps[0].Prime().ProbablyPrime(30) || !sgps[1].Prime().ProbablyPrime(30) ||
 				!sgps[0].SafePrime().ProbablyPrime(30) || !sgps[1].SafePrime().ProbablyPrime(30) {
+				if ctx.Err() != nil {
+					return nil, common.ErrGeneratorCancelled
+				}
 				return nil, errors.New("timeout or error while generating the safe primes")
 			}
 			if paiSK != nil {
@@ -121,6 +212,9 @@ consumer:
 			}
 		case paiSK = <-paiCh:
 			if paiSK == nil {
+				if ctx.Err() != nil {
+					return nil, common.ErrGeneratorCancelled
+				}
 				return nil, errors.New("timeout or error while generating the Paillier secret key")
 			}
 			if sgps != nil {
@@ -128,7 +222,6 @@ consumer:
 			}
 		}
 	}
-	logProgressTicker.Stop()
 
 	P, Q := sgps[0].SafePrime(), sgps[1].SafePrime()
 	NTildei := new(big.Int).Mul(P, Q)
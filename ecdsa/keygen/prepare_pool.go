@@ -0,0 +1,133 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+const (
+	// a failed generation is retried after a delay that doubles from the min to the max with each consecutive failure
+	preParamsPoolMinBackoff = time.Second
+	preParamsPoolMaxBackoff = time.Minute
+)
+
+// ErrPreParamsPoolClosed is returned by PreParamsPool.Get once the pool has been closed.
+var ErrPreParamsPoolClosed = errors.New("pre-params pool closed")
+
+// PreParamsPool keeps a supply of freshly generated LocalPreParams so that they can be handed out without waiting at
+// keygen time. A background goroutine generates new pre-parameters whenever the pool holds fewer than its size.
+// Each LocalPreParams is handed out once; they must never be shared between parties or keys.
+type PreParamsPool struct {
+	ch       chan *LocalPreParams
+	ctx      context.Context
+	cancel   context.CancelFunc
+	done     chan struct{}
+	generate func(ctx context.Context) (*LocalPreParams, error)
+
+	minBackoff, maxBackoff time.Duration
+}
+
+// NewPreParamsPool starts a pool holding up to `size` pre-parameters, generated one at a time with the given
+// concurrency (see GeneratePreParams). A failed generation is retried with an exponential backoff of 1s up to 1min.
+// Close must be called to stop the background generation.
+func NewPreParamsPool(size int, concurrency int) *PreParamsPool {
+	return newPreParamsPool(size, preParamsPoolMinBackoff, preParamsPoolMaxBackoff, func(ctx context.Context) (*LocalPreParams, error) {
+		return GeneratePreParamsWithContext(ctx, concurrency)
+	})
+}
+
+func newPreParamsPool(size int, minBackoff, maxBackoff time.Duration, generate func(ctx context.Context) (*LocalPreParams, error)) *PreParamsPool {
+	if size < 1 {
+		size = 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := &PreParamsPool{
+		ch:       make(chan *LocalPreParams, size),
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+		generate: generate,
+
+		minBackoff: minBackoff,
+		maxBackoff: maxBackoff,
+	}
+	go pool.run()
+	return pool
+}
+
+// Get returns pre-parameters from the pool, blocking until some are available, `ctx` is done or the pool is closed.
+func (pool *PreParamsPool) Get(ctx context.Context) (*LocalPreParams, error) {
+	select {
+	case preParams := <-pool.ch:
+		return preParams, nil
+	default:
+	}
+	select {
+	case preParams := <-pool.ch:
+		return preParams, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-pool.ctx.Done():
+		return nil, ErrPreParamsPoolClosed
+	}
+}
+
+// Len returns the number of pre-parameters ready in the pool.
+func (pool *PreParamsPool) Len() int {
+	return len(pool.ch)
+}
+
+// Close stops the background generation, waiting for it to exit. Pre-parameters left in the pool are discarded.
+func (pool *PreParamsPool) Close() {
+	pool.cancel()
+	<-pool.done
+}
+
+func (pool *PreParamsPool) run() {
+	defer close(pool.done)
+	backoff := time.Duration(0)
+	for {
+		preParams, err := pool.generate(pool.ctx)
+		if pool.ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			backoff = pool.nextBackoff(backoff)
+			common.Logger.Warningf("pre-params pool: generation failed, retrying in %s: %v", backoff, err)
+			timer := time.NewTimer(backoff)
+			select {
+			case <-timer.C:
+			case <-pool.ctx.Done():
+				timer.Stop()
+				return
+			}
+			continue
+		}
+		backoff = 0
+		select {
+		case pool.ch <- preParams:
+		case <-pool.ctx.Done():
+			return
+		}
+	}
+}
+
+// nextBackoff returns the delay before the next retry after a failure that followed a delay of `backoff`
+func (pool *PreParamsPool) nextBackoff(backoff time.Duration) time.Duration {
+	if backoff < pool.minBackoff {
+		return pool.minBackoff
+	}
+	if backoff *= 2; backoff > pool.maxBackoff {
+		return pool.maxBackoff
+	}
+	return backoff
+}
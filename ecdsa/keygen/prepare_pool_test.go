@@ -0,0 +1,83 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreParamsPool(t *testing.T) {
+	var generated, failures int64
+	pool := newPreParamsPool(3, time.Millisecond, time.Millisecond, func(ctx context.Context) (*LocalPreParams, error) {
+		if atomic.AddInt64(&failures, 1) == 1 {
+			return nil, errors.New("transient failure")
+		}
+		n := atomic.AddInt64(&generated, 1)
+		return &LocalPreParams{NTildei: big.NewInt(n)}, nil
+	})
+	defer pool.Close()
+
+	// the pool is topped up to its size and no further
+	assert.Eventually(t, func() bool { return pool.Len() == 3 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int64(4), atomic.LoadInt64(&generated), "one generated set waits for room in the pool")
+
+	seen := make(map[int64]bool)
+	for i := 0; i < 5; i++ {
+		preParams, err := pool.Get(context.Background())
+		assert.NoError(t, err)
+		assert.False(t, seen[preParams.NTildei.Int64()], "pre-params must be handed out once")
+		seen[preParams.NTildei.Int64()] = true
+	}
+}
+
+func TestPreParamsPoolGetCancel(t *testing.T) {
+	pool := newPreParamsPool(1, time.Millisecond, time.Millisecond, func(ctx context.Context) (*LocalPreParams, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	preParams, err := pool.Get(ctx)
+	assert.Nil(t, preParams)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, 0, pool.Len())
+
+	pool.Close()
+	_, err = pool.Get(context.Background())
+	assert.Equal(t, ErrPreParamsPoolClosed, err)
+}
+
+func TestPreParamsPoolBackoff(t *testing.T) {
+	const minBackoff, maxBackoff = 20 * time.Millisecond, 80 * time.Millisecond
+	attempts := make(chan time.Time, 100)
+	pool := newPreParamsPool(1, minBackoff, maxBackoff, func(ctx context.Context) (*LocalPreParams, error) {
+		attempts <- time.Now()
+		return nil, errors.New("persistent failure")
+	})
+
+	// the delay between the attempts doubles up to the max
+	prev := <-attempts
+	for _, want := range []time.Duration{minBackoff, 2 * minBackoff, maxBackoff, maxBackoff} {
+		next := <-attempts
+		assert.GreaterOrEqual(t, next.Sub(prev), want)
+		prev = next
+	}
+
+	// the pool closes while it waits to retry
+	start := time.Now()
+	pool.Close()
+	assert.WithinDuration(t, start, time.Now(), maxBackoff/2)
+}
@@ -8,10 +8,13 @@ package keygen
 
 import (
 	"context"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
 )
 
 func TestGeneratePreParamsTimeout(t *testing.T) {
@@ -35,6 +38,55 @@ func TestGeneratePreParamsWithContextTimeout(t *testing.T) {
 	assert.WithinDuration(t, start, time.Now(), 1*time.Second)
 }
 
+func TestGeneratePreParamsWithContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelledAt := make(chan time.Time, 1)
+	time.AfterFunc(100*time.Millisecond, func() {
+		cancelledAt <- time.Now()
+		cancel()
+	})
+
+	preParams, err := GeneratePreParamsWithContext(ctx, 6)
+	returnedAt := time.Now()
+	assert.Nil(t, preParams)
+	assert.Equal(t, common.ErrGeneratorCancelled, err)
+
+	// the call must return soon after the cancellation rather than when the primes are found
+	assert.WithinDuration(t, <-cancelledAt, returnedAt, 500*time.Millisecond)
+}
+
+func TestGeneratePreParamsWithModulusLenUnsupported(t *testing.T) {
+	for _, bitLen := range []int{0, 1024, 2047, 2560, 8192} {
+		preParams, err := GeneratePreParamsWithModulusLen(time.Minute, bitLen, 1)
+		assert.Nil(t, preParams)
+		assert.Error(t, err, "bit length %d", bitLen)
+	}
+	assert.True(t, IsSupportedModulusLen(3072))
+}
+
+func TestGeneratePreParamsWithProgress(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2500*time.Millisecond)
+	defer cancel()
+
+	// not synchronized: the events must all come from this goroutine
+	var events []PreParamsProgress
+	_, err := GeneratePreParamsWithProgress(ctx, func(event PreParamsProgress) {
+		events = append(events, event)
+	}, 1)
+	if err == nil {
+		t.Skip("pre-params were generated before the first progress tick")
+	}
+	assert.Equal(t, common.ErrGeneratorCancelled, err)
+	assert.True(t, len(events) >= 2, "expected tick events before the final event")
+	for _, event := range events[:len(events)-1] {
+		assert.False(t, event.Done)
+		assert.Contains(t, []PreParamsPhase{PreParamsPhasePaillier, PreParamsPhaseSafePrimes}, event.Phase)
+	}
+	last := events[len(events)-1]
+	assert.True(t, last.Done)
+	assert.Equal(t, err, last.Err)
+}
+
 func TestGenerateWithContext(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Minute)
 	defer cancel()
@@ -51,3 +103,47 @@ func TestGenerateWithContext(t *testing.T) {
 	assert.NotNil(t, preParams.P)
 	assert.NotNil(t, preParams.Q)
 }
+
+func TestVerifyH2IsH1PowAlpha(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(1)
+	assert.NoError(t, err)
+	preParams := keys[0].LocalPreParams
+	assert.True(t, preParams.VerifyH2IsH1PowAlpha())
+
+	corrupted := preParams
+	corrupted.H2i = new(big.Int).Add(preParams.H2i, big.NewInt(1))
+	assert.False(t, corrupted.VerifyH2IsH1PowAlpha())
+
+	corrupted = preParams
+	corrupted.Beta = new(big.Int).Add(preParams.Beta, big.NewInt(1))
+	assert.False(t, corrupted.VerifyH2IsH1PowAlpha())
+
+	corrupted = preParams
+	corrupted.Alpha = nil
+	assert.False(t, corrupted.VerifyH2IsH1PowAlpha())
+}
+
+func TestCheckPreParamsDistinct(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(3)
+	assert.NoError(t, err)
+	preParams := []LocalPreParams{keys[0].LocalPreParams, keys[1].LocalPreParams, keys[2].LocalPreParams}
+	collisions, err := CheckPreParamsDistinct(preParams)
+	assert.NoError(t, err)
+	assert.Empty(t, collisions)
+
+	// a restored copy of another party's pre-params
+	preParams = append(preParams, keys[1].LocalPreParams)
+	collisions, err = CheckPreParamsDistinct(preParams)
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]int{{1, 3}}, collisions)
+
+	// a single shared prime, across the Paillier and NTilde moduli
+	mixed := keys[0].LocalPreParams
+	mixed.NTildei = new(big.Int).Mul(keys[2].LocalPreParams.PaillierSK.P, big.NewInt(1000003))
+	collisions, err = CheckPreParamsDistinct([]LocalPreParams{mixed, keys[1].LocalPreParams, keys[2].LocalPreParams})
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]int{{0, 2}}, collisions)
+
+	_, err = CheckPreParamsDistinct([]LocalPreParams{keys[0].LocalPreParams, {}})
+	assert.Error(t, err)
+}
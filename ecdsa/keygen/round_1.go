@@ -35,6 +35,9 @@ func (round *round1) Start() *tss.Error {
 	round.number = 1
 	round.started = true
 	round.resetOK()
+	if err := round.ValidateShareEncryptionKeys(); err != nil {
+		return round.WrapError(err)
+	}
 
 	Pi := round.PartyID()
 	i := Pi.Index
@@ -103,7 +106,7 @@ func (round *round1) Start() *tss.Error {
 	// and keep in temporary storage:
 	// - VSS Vs
 	// - our set of Shamir shares
-	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	round.temp.ssidNonce = round.SSIDNonce()
 	round.save.ShareID = ids[i]
 	round.temp.vs = vs
 	ssid, err := round.getSSID()
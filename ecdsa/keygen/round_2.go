@@ -9,20 +9,17 @@ package keygen
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"math/big"
 	"sync"
 
+	"github.com/bnb-chain/tss-lib/v2/crypto/ecies"
 	"github.com/bnb-chain/tss-lib/v2/crypto/facproof"
 	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
 
-	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
-const (
-	paillierBitsLen = 2048
-)
-
 func (round *round2) Start() *tss.Error {
 	if round.started {
 		return round.WrapError(errors.New("round already started"))
@@ -31,17 +28,21 @@ func (round *round2) Start() *tss.Error {
 	round.started = true
 	round.resetOK()
 
-	common.Logger.Debugf(
+	round.Params().Logger().Debugf(
 		"%s Setting up DLN verification with concurrency level of %d",
 		round.PartyID(),
 		round.Concurrency(),
 	)
-	dlnVerifier := NewDlnProofVerifier(round.Concurrency())
+	ctx, cancel := round.RoundContext()
+	defer cancel()
+	dlnVerifier := NewDlnProofVerifier(ctx, round.Concurrency())
 
 	i := round.PartyID().Index
 
-	// 6. verify dln proofs, store r1 message pieces, ensure uniqueness of h1j, h2j
+	// 6. verify dln proofs, store r1 message pieces, ensure uniqueness of h1j, h2j, NTildej and the paillier N
 	h1H2Map := make(map[string]struct{}, len(round.temp.kgRound1Messages)*2)
+	nTildeMap := make(map[string]struct{}, len(round.temp.kgRound1Messages))
+	paillierNMap := make(map[string]struct{}, len(round.temp.kgRound1Messages))
 	dlnProof1FailCulprits := make([]*tss.PartyID, len(round.temp.kgRound1Messages))
 	dlnProof2FailCulprits := make([]*tss.PartyID, len(round.temp.kgRound1Messages))
 	wg := new(sync.WaitGroup)
@@ -51,13 +52,13 @@ func (round *round2) Start() *tss.Error {
 			r1msg.UnmarshalH2(),
 			r1msg.UnmarshalNTilde(),
 			r1msg.UnmarshalPaillierPK()
-		if paillierPKj.N.BitLen() != paillierBitsLen {
+		if !IsSupportedModulusLen(paillierPKj.N.BitLen()) {
 			return round.WrapError(errors.New("got paillier modulus with insufficient bits for this party"), msg.GetFrom())
 		}
 		if H1j.Cmp(H2j) == 0 {
 			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom())
 		}
-		if NTildej.BitLen() != paillierBitsLen {
+		if !IsSupportedModulusLen(NTildej.BitLen()) {
 			return round.WrapError(errors.New("got NTildej with insufficient bits for this party"), msg.GetFrom())
 		}
 		h1JHex, h2JHex := hex.EncodeToString(H1j.Bytes()), hex.EncodeToString(H2j.Bytes())
@@ -68,19 +69,30 @@ func (round *round2) Start() *tss.Error {
 			return round.WrapError(errors.New("this h2j was already used by another party"), msg.GetFrom())
 		}
 		h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
+		nTildeJHex, paillierNJHex := hex.EncodeToString(NTildej.Bytes()), hex.EncodeToString(paillierPKj.N.Bytes())
+		if _, found := nTildeMap[nTildeJHex]; found {
+			return round.WrapError(errors.New("this NTildej was already used by another party"), msg.GetFrom())
+		}
+		if _, found := paillierNMap[paillierNJHex]; found {
+			return round.WrapError(errors.New("this paillier N was already used by another party"), msg.GetFrom())
+		}
+		nTildeMap[nTildeJHex], paillierNMap[paillierNJHex] = struct{}{}, struct{}{}
 
+		if round.Params().NoProofDln() {
+			continue
+		}
 		wg.Add(2)
 		_j := j
 		_msg := msg
 
-		dlnVerifier.VerifyDLNProof1(r1msg, H1j, H2j, NTildej, func(isValid bool) {
-			if !isValid {
+		dlnVerifier.VerifyDLNProof1(r1msg, H1j, H2j, NTildej, func(isValid bool, err error) {
+			if !isValid && (err == nil || err != ctx.Err()) { // an aborted verification has no culprit
 				dlnProof1FailCulprits[_j] = _msg.GetFrom()
 			}
 			wg.Done()
 		})
-		dlnVerifier.VerifyDLNProof2(r1msg, H2j, H1j, NTildej, func(isValid bool) {
-			if !isValid {
+		dlnVerifier.VerifyDLNProof2(r1msg, H2j, H1j, NTildej, func(isValid bool, err error) {
+			if !isValid && (err == nil || err != ctx.Err()) { // an aborted verification has no culprit
 				dlnProof2FailCulprits[_j] = _msg.GetFrom()
 			}
 			wg.Done()
@@ -92,6 +104,9 @@ func (round *round2) Start() *tss.Error {
 			return round.WrapError(errors.New("dln proof verification failed"), culprit)
 		}
 	}
+	if err := ctx.Err(); err != nil {
+		return round.WrapError(fmt.Errorf("dln proof verification was aborted: %w", err))
+	}
 	// save NTilde_j, h1_j, h2_j, ...
 	for j, msg := range round.temp.kgRound1Messages {
 		if j == i {
@@ -133,6 +148,13 @@ func (round *round2) Start() *tss.Error {
 			round.temp.kgRound2Message1s[j] = r2msg1
 			continue
 		}
+		if round.ShareEncryptionKey() != nil {
+			shareBz, err := ecies.EncryptShare(round.Params(), j, shares[j].Share)
+			if err != nil {
+				return round.WrapError(err, round.PartyID())
+			}
+			r2msg1 = newKGRound2Message1(Pj, round.PartyID(), shareBz, facProof)
+		}
 		round.out <- r2msg1
 	}
 
@@ -146,7 +168,14 @@ func (round *round2) Start() *tss.Error {
 			return round.WrapError(err, round.PartyID())
 		}
 	}
-	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, modProof)
+	if round.Params().EchoBroadcast() {
+		echo, err := tss.EchoHashes(round.temp.kgRound1Messages)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		round.temp.r1EchoHashes = echo
+	}
+	r2msg2 := newKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, modProof, round.temp.r1EchoHashes)
 	round.temp.kgRound2Message2s[i] = r2msg2
 	round.out <- r2msg2
 
@@ -16,6 +16,8 @@ import (
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/facproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
 	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
@@ -31,6 +33,17 @@ func (round *round3) Start() *tss.Error {
 	Ps := round.Parties().IDs()
 	PIdx := round.PartyID().Index
 
+	// check that every party received the same round 1 messages
+	if round.Params().EchoBroadcast() {
+		echoes := make([][][]byte, len(Ps))
+		for j, msg := range round.temp.kgRound2Message2s {
+			echoes[j] = msg.Content().(*KGRound2Message2).GetEcho()
+		}
+		if culprits := tss.EchoCulprits(Ps, PIdx, round.temp.r1EchoHashes, echoes); len(culprits) > 0 {
+			return round.WrapError(errors.New("echo broadcast: the round 1 messages differ between the parties"), culprits...)
+		}
+	}
+
 	// 1,9. calculate xi
 	xi := new(big.Int).Set(round.temp.shares[PIdx].Share)
 	for j := range Ps {
@@ -50,6 +63,7 @@ func (round *round3) Start() *tss.Error {
 	}
 
 	// 4-11.
+	modFailed, facFailed := round.verifyPaillierProofs()
 	type vssOut struct {
 		unWrappedErr error
 		pjVs         vss.Vs
@@ -65,7 +79,6 @@ func (round *round3) Start() *tss.Error {
 		if j == PIdx {
 			continue
 		}
-		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
 		// 6-8.
 		go func(j int, ch chan<- vssOut) {
 			// 4-9.
@@ -83,20 +96,13 @@ func (round *round3) Start() *tss.Error {
 				ch <- vssOut{err, nil}
 				return
 			}
-			modProof, err := r2msg2.UnmarshalModProof()
-			if err != nil && round.Parameters.NoProofMod() {
-				// For old parties, the modProof could be not exist
-				// Not return error for compatibility reason
-				common.Logger.Warningf("modProof not exist:%s", Ps[j])
-			} else {
-				if err != nil {
-					ch <- vssOut{errors.New("modProof verify failed"), nil}
-					return
-				}
-				if ok = modProof.Verify(ContextJ, round.save.PaillierPKs[j].N); !ok {
-					ch <- vssOut{errors.New("modProof verify failed"), nil}
-					return
-				}
+			if err = vss.ValidateCommitments(PjVs, round.Threshold()); err != nil {
+				ch <- vssOut{err, nil}
+				return
+			}
+			if modFailed[j] {
+				ch <- vssOut{errors.New("modProof verify failed"), nil}
+				return
 			}
 			r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
 			PjShare := vss.Share{
@@ -108,21 +114,9 @@ func (round *round3) Start() *tss.Error {
 				ch <- vssOut{errors.New("vss verify failed"), nil}
 				return
 			}
-			facProof, err := r2msg1.UnmarshalFacProof()
-			if err != nil && round.NoProofFac() {
-				// For old parties, the facProof could be not exist
-				// Not return error for compatibility reason
-				common.Logger.Warningf("facProof not exist:%s", Ps[j])
-			} else {
-				if err != nil {
-					ch <- vssOut{errors.New("facProof verify failed"), nil}
-					return
-				}
-				if ok = facProof.Verify(ContextJ, round.EC(), round.save.PaillierPKs[j].N, round.save.NTildei,
-					round.save.H1i, round.save.H2i); !ok {
-					ch <- vssOut{errors.New("facProof verify failed"), nil}
-					return
-				}
+			if facFailed[j] {
+				ch <- vssOut{errors.New("facProof verify failed"), nil}
+				return
 			}
 
 			// (9) handled above
@@ -207,9 +201,12 @@ func (round *round3) Start() *tss.Error {
 		return round.WrapError(errors2.Wrapf(err, "public key is not on the curve"))
 	}
 	round.save.ECDSAPub = ecdsaPubKey
+	round.save.ChainCode = MasterChainCode(ecdsaPubKey)
+	round.save.KeyThreshold = round.Threshold()
+	round.save.CurveName, _ = tss.GetCurveName(round.Params().EC())
 
 	// PRINT public key & private share
-	common.Logger.Debugf("%s public key: %x", round.PartyID(), ecdsaPubKey)
+	round.Params().Logger().Debugf("%s public key: %x", round.PartyID(), ecdsaPubKey)
 
 	// BROADCAST paillier proof for Pi
 	ki := round.PartyID().KeyInt()
@@ -247,3 +244,50 @@ func (round *round3) NextRound() tss.Round {
 	round.started = false
 	return &round4{round}
 }
+
+// verifyPaillierProofs batch verifies the mod and fac proofs of every other Pj and returns, by party index, which failed.
+// A proof that is missing fails unless the parameters skip it, for compatibility with old parties.
+func (round *round3) verifyPaillierProofs() (modFailed, facFailed []bool) {
+	Ps := round.Parties().IDs()
+	modFailed, facFailed = make([]bool, len(Ps)), make([]bool, len(Ps))
+	modInputs, modIdxs := make([]modproof.ProofModInput, 0, len(Ps)), make([]int, 0, len(Ps))
+	facInputs, facIdxs := make([]facproof.ProofFacInput, 0, len(Ps)), make([]int, 0, len(Ps))
+	for j := range Ps {
+		if j == round.PartyID().Index {
+			continue
+		}
+		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		r2msg2 := round.temp.kgRound2Message2s[j].Content().(*KGRound2Message2)
+		modProof, err := r2msg2.UnmarshalModProof()
+		if err != nil && round.Parameters.NoProofMod() {
+			// For old parties, the modProof could be not exist
+			// Not return error for compatibility reason
+			round.Params().Logger().Warningf("modProof not exist:%s", Ps[j])
+		} else if err != nil {
+			modFailed[j] = true
+		} else {
+			modInputs = append(modInputs, modproof.ProofModInput{Proof: modProof, Session: ContextJ, N: round.save.PaillierPKs[j].N})
+			modIdxs = append(modIdxs, j)
+		}
+		r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
+		facProof, err := r2msg1.UnmarshalFacProof()
+		if err != nil && round.NoProofFac() {
+			// For old parties, the facProof could be not exist
+			// Not return error for compatibility reason
+			round.Params().Logger().Warningf("facProof not exist:%s", Ps[j])
+		} else if err != nil {
+			facFailed[j] = true
+		} else {
+			facInputs = append(facInputs, facproof.ProofFacInput{Proof: facProof, Session: ContextJ,
+				N0: round.save.PaillierPKs[j].N, NCap: round.save.NTildei, S: round.save.H1i, T: round.save.H2i})
+			facIdxs = append(facIdxs, j)
+		}
+	}
+	for _, i := range modproof.BatchVerify(modInputs, round.Concurrency()) {
+		modFailed[modIdxs[i]] = true
+	}
+	for _, i := range facproof.BatchVerify(round.EC(), facInputs, round.Concurrency()) {
+		facFailed[facIdxs[i]] = true
+	}
+	return
+}
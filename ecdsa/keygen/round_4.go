@@ -9,7 +9,6 @@ package keygen
 import (
 	"errors"
 
-	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
@@ -43,7 +42,7 @@ func (round *round4) Start() *tss.Error {
 			ppk := round.save.PaillierPKs[j]
 			ok, err := prf.Verify(ppk.N, PIDs[j], ecdsaPub)
 			if err != nil {
-				common.Logger.Error(round.WrapError(err, Ps[j]).Error())
+				round.Params().Logger().Errorf("%s", round.WrapError(err, Ps[j]))
 				ch <- false
 				return
 			}
@@ -63,10 +62,10 @@ func (round *round4) Start() *tss.Error {
 	for j, ok := range round.ok {
 		if !ok {
 			culprits = append(culprits, Ps[j])
-			common.Logger.Warningf("paillier verify failed for party %s", Ps[j])
+			round.Params().Logger().Warningf("paillier verify failed for party %s", Ps[j])
 			continue
 		}
-		common.Logger.Debugf("paillier verify passed for party %s", Ps[j])
+		round.Params().Logger().Debugf("paillier verify passed for party %s", Ps[j])
 
 	}
 	if len(culprits) > 0 {
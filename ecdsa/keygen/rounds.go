@@ -59,6 +59,11 @@ func (round *base) RoundNumber() int {
 	return round.number
 }
 
+// baseRound gives access to the shared round state regardless of which round embeds it
+func (round *base) baseRound() *base {
+	return round
+}
+
 // CanProceed is inherited by other rounds
 func (round *base) CanProceed() bool {
 	if !round.started {
@@ -7,12 +7,18 @@
 package keygen
 
 import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
 	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
@@ -47,9 +53,24 @@ type (
 
 		// used for test assertions (may be discarded)
 		ECDSAPub *crypto.ECPoint // y
+
+		// chain code of the extended master key for HD derivation, see ExtendedKey
+		ChainCode []byte
+
+		// the threshold t of the key; any t+1 of the parties can sign. see Threshold
+		KeyThreshold int
+
+		// the name of the curve of the key, see CheckCurve
+		CurveName tss.CurveName
 	}
 )
 
+// masterChainCodeKey is the HMAC key of the master chain code, like the "Bitcoin seed" key of BIP-32.
+var masterChainCodeKey = []byte("tss-lib master chain code")
+
+// xpubVersion is the BIP-32 version bytes of a mainnet extended public key ("xpub").
+var xpubVersion = []byte{0x04, 0x88, 0xb2, 0x1e}
+
 func NewLocalPartySaveData(partyCount int) (saveData LocalPartySaveData) {
 	saveData.Ks = make([]*big.Int, partyCount)
 	saveData.NTildej = make([]*big.Int, partyCount)
@@ -66,14 +87,83 @@ func (preParams LocalPreParams) Validate() bool {
 		preParams.H2i != nil
 }
 
+// ValidateWithProof reports whether the pre-params have all the values needed to prove them, see checkWithProof
 func (preParams LocalPreParams) ValidateWithProof() bool {
-	return preParams.Validate() &&
-		preParams.PaillierSK.P != nil &&
-		preParams.PaillierSK.Q != nil &&
-		preParams.Alpha != nil &&
-		preParams.Beta != nil &&
-		preParams.P != nil &&
-		preParams.Q != nil
+	return preParams.checkWithProof() == nil
+}
+
+// checkWithProof returns an error that names the first missing value of the pre-params
+func (preParams LocalPreParams) checkWithProof() error {
+	if preParams.PaillierSK == nil {
+		return errors.New("invalid pre-params: PaillierSK is nil")
+	}
+	for _, v := range []struct {
+		name  string
+		value *big.Int
+	}{
+		{"PaillierSK.N", preParams.PaillierSK.N},
+		{"PaillierSK.P", preParams.PaillierSK.P},
+		{"PaillierSK.Q", preParams.PaillierSK.Q},
+		{"PaillierSK.LambdaN", preParams.PaillierSK.LambdaN},
+		{"PaillierSK.PhiN", preParams.PaillierSK.PhiN},
+		{"NTildei", preParams.NTildei},
+		{"H1i", preParams.H1i},
+		{"H2i", preParams.H2i},
+		{"Alpha", preParams.Alpha},
+		{"Beta", preParams.Beta},
+		{"P", preParams.P},
+		{"Q", preParams.Q},
+	} {
+		if v.value == nil {
+			return fmt.Errorf("invalid pre-params: %s is nil", v.name)
+		}
+	}
+	return nil
+}
+
+// VerifyH2IsH1PowAlpha checks that H2i = H1i^Alpha mod NTildei (and H1i = H2i^Beta when Beta is present)
+// using the locally stored secret exponents. It is a cheap self-check for corrupted pre-params;
+// peers verify the same relationship through the DLN proofs instead.
+func (preParams LocalPreParams) VerifyH2IsH1PowAlpha() bool {
+	if preParams.NTildei == nil || preParams.H1i == nil || preParams.H2i == nil || preParams.Alpha == nil ||
+		preParams.NTildei.Sign() <= 0 {
+		return false
+	}
+	if new(big.Int).Exp(preParams.H1i, preParams.Alpha, preParams.NTildei).Cmp(preParams.H2i) != 0 {
+		return false
+	}
+	return preParams.Beta == nil ||
+		new(big.Int).Exp(preParams.H2i, preParams.Beta, preParams.NTildei).Cmp(preParams.H1i) == 0
+}
+
+// CheckPreParamsDistinct returns the index pairs of the pre-params that share a prime, e.g. after a backup mix-up.
+// Both the Paillier and the NTilde moduli are compared with one another by their GCD, so that a shared prime is found
+// even when the moduli themselves differ. An error is returned if a modulus is missing.
+func CheckPreParamsDistinct(preParams []LocalPreParams) ([][2]int, error) {
+	moduli := make([][2]*big.Int, len(preParams))
+	for i, pp := range preParams {
+		if pp.PaillierSK == nil || pp.PaillierSK.N == nil || pp.NTildei == nil ||
+			pp.PaillierSK.N.Sign() <= 0 || pp.NTildei.Sign() <= 0 {
+			return nil, fmt.Errorf("the pre-params at index %d are missing a modulus", i)
+		}
+		moduli[i] = [2]*big.Int{pp.PaillierSK.N, pp.NTildei}
+	}
+	collisions := make([][2]int, 0)
+	gcd := new(big.Int)
+	for i := range moduli {
+	pairs:
+		for j := i + 1; j < len(moduli); j++ {
+			for _, a := range moduli[i] {
+				for _, b := range moduli[j] {
+					if gcd.GCD(nil, nil, a, b).Cmp(big.NewInt(1)) != 0 {
+						collisions = append(collisions, [2]int{i, j})
+						continue pairs
+					}
+				}
+			}
+		}
+	}
+	return collisions, nil
 }
 
 // BuildLocalSaveDataSubset re-creates the LocalPartySaveData to contain data for only the list of signing parties.
@@ -86,6 +176,9 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	newData.LocalPreParams = sourceData.LocalPreParams
 	newData.LocalSecrets = sourceData.LocalSecrets
 	newData.ECDSAPub = sourceData.ECDSAPub
+	newData.ChainCode = sourceData.ChainCode
+	newData.KeyThreshold = sourceData.KeyThreshold
+	newData.CurveName = sourceData.CurveName
 	for j, id := range sortedIDs {
 		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
 		if !ok {
@@ -100,3 +193,93 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	}
 	return newData
 }
+
+// MasterChainCode returns the chain code that keygen saves along with the public key `ecdsaPub`: the right half of
+// HMAC-SHA512 over the compressed public key. Every party derives the same chain code from the public key they agreed
+// on, without another round; as it is public, it only serves the non-hardened derivation of crypto/ckd.
+func MasterChainCode(ecdsaPub *crypto.ECPoint) []byte {
+	mac := hmac.New(sha512.New, masterChainCodeKey)
+	mac.Write(ecdsaPub.Bytes())
+	return mac.Sum(nil)[32:]
+}
+
+// ExtendedKey returns the extended master public key of the saved key, for use with ckd.DeriveChildKey.
+// Save data from before the chain code was saved get the chain code that keygen would have saved.
+func (save LocalPartySaveData) ExtendedKey() (*ckd.ExtendedKey, error) {
+	if save.ECDSAPub == nil {
+		return nil, errors.New("ExtendedKey: the save data has no public key")
+	}
+	chainCode := save.ChainCode
+	if len(chainCode) == 0 {
+		chainCode = MasterChainCode(save.ECDSAPub)
+	}
+	if len(chainCode) != 32 {
+		return nil, fmt.Errorf("ExtendedKey: the chain code has %d bytes, expected 32", len(chainCode))
+	}
+	return &ckd.ExtendedKey{
+		PublicKey:  *save.ECDSAPub.ToECDSAPubKey(),
+		Depth:      0,
+		ChildIndex: 0,
+		ChainCode:  chainCode,
+		ParentFP:   []byte{0x00, 0x00, 0x00, 0x00},
+		Version:    xpubVersion,
+	}, nil
+}
+
+// CheckCurve returns an error if the key is not on the curve `ec`, such as when the parameters of a signing were
+// built for another curve than the keygen. Save data from before the curve name was saved are checked against the
+// curve of ECDSAPub.
+func (save LocalPartySaveData) CheckCurve(ec elliptic.Curve) error {
+	var keyCurve elliptic.Curve
+	if save.CurveName != "" {
+		var ok bool
+		if keyCurve, ok = tss.GetCurveByName(save.CurveName); !ok {
+			return fmt.Errorf("the key is on the curve %s, which is not registered", save.CurveName)
+		}
+	} else if save.ECDSAPub != nil {
+		keyCurve = save.ECDSAPub.Curve()
+	} else {
+		return nil
+	}
+	if !tss.SameCurve(keyCurve, ec) {
+		return fmt.Errorf("the key is on the curve %s but the parameters are on the curve %s",
+			keyCurve.Params().Name, ec.Params().Name)
+	}
+	return nil
+}
+
+// PartyCount returns the number of parties that hold a share of the key.
+func (save LocalPartySaveData) PartyCount() int {
+	return len(save.Ks)
+}
+
+// ShareIDs returns a copy of the share IDs (the keys of the parties) in the order of the save data.
+func (save LocalPartySaveData) ShareIDs() []*big.Int {
+	ids := make([]*big.Int, len(save.Ks))
+	for j, kj := range save.Ks {
+		ids[j] = new(big.Int).Set(kj)
+	}
+	return ids
+}
+
+// Threshold returns the threshold t of the key, so that t+1 parties are needed to sign.
+// Save data from before the threshold was saved get the smallest t for which the first t+1 of BigXj interpolate
+// ECDSAPub in the exponent, i.e. the degree of the sharing polynomial. -1 is returned if it cannot be determined.
+func (save LocalPartySaveData) Threshold() int {
+	if 0 < save.KeyThreshold {
+		return save.KeyThreshold
+	}
+	if save.ECDSAPub == nil || len(save.Ks) == 0 || len(save.Ks) != len(save.BigXj) {
+		return -1
+	}
+	for t := 0; t < len(save.Ks); t++ {
+		y, err := vss.InterpolatePublicShares(save.Ks[:t+1], save.BigXj[:t+1])
+		if err != nil {
+			return -1
+		}
+		if y.Equals(save.ECDSAPub) {
+			return t
+		}
+	}
+	return -1
+}
@@ -0,0 +1,348 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// SaveDataSchemaVersion is the version of the JSON schema written by ExportSaveData.
+//
+// The schema is meant for moving a key share between TSS libraries and is independent of the JSON encoding of
+// LocalPartySaveData, which follows the Go struct. Integers are big-endian hex strings without a prefix and points
+// are affine {"x", "y"} pairs:
+//
+//	{
+//	  "version":   1,
+//	  "curve":     "secp256k1",             // a curve name registered in tss, see tss.GetCurveByName
+//	  "threshold": 2,                       // t; any t+1 parties can sign
+//	  "shareID":   "...",                   // the share ID (x coordinate) of this party
+//	  "xi":        "...",                   // the secret share of this party
+//	  "ecdsaPub":  {"x": "...", "y": "..."},
+//	  "chainCode": "...",                   // optional, see MasterChainCode
+//	  "paillierP": "...", "paillierQ": "...", // the primes of this party's Paillier modulus
+//	  "alpha": "...", "beta": "...",        // optional: h2 = h1^alpha, h1 = h2^beta mod NTilde of this party
+//	  "p": "...", "q": "...",               // optional: NTilde = (2p+1)(2q+1) of this party
+//	  "parties": [                          // every party of the key, this one included
+//	    {"shareID": "...", "bigX": {"x": "...", "y": "..."}, "paillierN": "...",
+//	     "nTilde": "...", "h1": "...", "h2": "..."}
+//	  ]
+//	}
+//
+// Not every library keeps the same material, so an imported key may not support every protocol of this one:
+//   - alpha, beta, p and q are needed to prove the NTilde of this party in a keygen-style DLN proof. Keys imported
+//     without them can sign and reshare, and an auxrefresh with new pre-params replaces them.
+//   - libraries based on CGGMP (e.g. multi-party-sig) keep ring-Pedersen parameters (N, t, s) with s = t^λ, which map
+//     onto nTilde, h1, h2 and alpha, but have no beta. Their proofs are not compatible with those of this library,
+//     so all the parties of a key must move over together.
+//   - the moduli must have a length accepted by IsSupportedModulusLen.
+//   - the parties' share IDs must match the keys of their tss.PartyID in this library.
+const SaveDataSchemaVersion = 1
+
+type (
+	saveDataSchema struct {
+		Version   int               `json:"version"`
+		Curve     tss.CurveName     `json:"curve"`
+		Threshold int               `json:"threshold"`
+		ShareID   string            `json:"shareID"`
+		Xi        string            `json:"xi"`
+		ECDSAPub  schemaPoint       `json:"ecdsaPub"`
+		ChainCode string            `json:"chainCode,omitempty"`
+		PaillierP string            `json:"paillierP"`
+		PaillierQ string            `json:"paillierQ"`
+		Alpha     string            `json:"alpha,omitempty"`
+		Beta      string            `json:"beta,omitempty"`
+		P         string            `json:"p,omitempty"`
+		Q         string            `json:"q,omitempty"`
+		Parties   []saveDataSchemaJ `json:"parties"`
+	}
+
+	saveDataSchemaJ struct {
+		ShareID   string      `json:"shareID"`
+		BigX      schemaPoint `json:"bigX"`
+		PaillierN string      `json:"paillierN"`
+		NTilde    string      `json:"nTilde"`
+		H1        string      `json:"h1"`
+		H2        string      `json:"h2"`
+	}
+
+	schemaPoint struct {
+		X string `json:"x"`
+		Y string `json:"y"`
+	}
+)
+
+// ExportSaveData encodes the key share in `save` in the versioned JSON schema described by SaveDataSchemaVersion,
+// for import into another library. The output holds the secret share and the Paillier secret key and must be
+// protected like the save data itself.
+func ExportSaveData(save LocalPartySaveData) ([]byte, error) {
+	if save.ECDSAPub == nil || save.Xi == nil || save.ShareID == nil {
+		return nil, errors.New("ExportSaveData: the save data has no key share")
+	}
+	if save.PaillierSK == nil || save.PaillierSK.P == nil || save.PaillierSK.Q == nil {
+		return nil, errors.New("ExportSaveData: the save data has no Paillier primes")
+	}
+	n := save.PartyCount()
+	if n == 0 || len(save.BigXj) != n || len(save.PaillierPKs) != n ||
+		len(save.NTildej) != n || len(save.H1j) != n || len(save.H2j) != n {
+		return nil, errors.New("ExportSaveData: the save data has missing party data")
+	}
+	threshold := save.Threshold()
+	if threshold < 1 {
+		return nil, errors.New("ExportSaveData: the threshold of the key could not be determined")
+	}
+	curve := save.CurveName
+	if curve == "" {
+		var ok bool
+		if curve, ok = tss.GetCurveName(save.ECDSAPub.Curve()); !ok {
+			return nil, errors.New("ExportSaveData: the curve of the key is not registered")
+		}
+	}
+	schema := saveDataSchema{
+		Version:   SaveDataSchemaVersion,
+		Curve:     curve,
+		Threshold: threshold,
+		ShareID:   hexInt(save.ShareID),
+		Xi:        hexInt(save.Xi),
+		ECDSAPub:  newSchemaPoint(save.ECDSAPub),
+		ChainCode: hex.EncodeToString(save.ChainCode),
+		PaillierP: hexInt(save.PaillierSK.P),
+		PaillierQ: hexInt(save.PaillierSK.Q),
+		Alpha:     hexInt(save.Alpha),
+		Beta:      hexInt(save.Beta),
+		P:         hexInt(save.P),
+		Q:         hexInt(save.Q),
+		Parties:   make([]saveDataSchemaJ, n),
+	}
+	for j := range save.Ks {
+		if save.Ks[j] == nil || save.BigXj[j] == nil || save.PaillierPKs[j] == nil || save.NTildej[j] == nil ||
+			save.H1j[j] == nil || save.H2j[j] == nil {
+			return nil, fmt.Errorf("ExportSaveData: the save data is missing data of party %d", j)
+		}
+		schema.Parties[j] = saveDataSchemaJ{
+			ShareID:   hexInt(save.Ks[j]),
+			BigX:      newSchemaPoint(save.BigXj[j]),
+			PaillierN: hexInt(save.PaillierPKs[j].N),
+			NTilde:    hexInt(save.NTildej[j]),
+			H1:        hexInt(save.H1j[j]),
+			H2:        hexInt(save.H2j[j]),
+		}
+	}
+	bz, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(bz, '\n'), nil
+}
+
+// ImportSaveData decodes a key share in the versioned JSON schema described by SaveDataSchemaVersion.
+// Besides the encoding, it checks that the share matches the public share Xj of this party, that the public shares of
+// all the parties interpolate the claimed public key for the threshold, i.e. that the share reconstructs the key with
+// those of the others, and that the Paillier and NTilde parameters of this party match those listed for it.
+func ImportSaveData(schemaJSON []byte) (LocalPartySaveData, error) {
+	var schema saveDataSchema
+	dec := json.NewDecoder(bytes.NewReader(schemaJSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&schema); err != nil {
+		return LocalPartySaveData{}, fmt.Errorf("ImportSaveData: %v", err)
+	}
+	save, err := schema.toSaveData()
+	if err != nil {
+		return LocalPartySaveData{}, fmt.Errorf("ImportSaveData: %v", err)
+	}
+	return save, nil
+}
+
+func (schema *saveDataSchema) toSaveData() (save LocalPartySaveData, err error) {
+	if schema.Version != SaveDataSchemaVersion {
+		return save, fmt.Errorf("unsupported schema version %d", schema.Version)
+	}
+	ec, ok := tss.GetCurveByName(schema.Curve)
+	if !ok {
+		return save, fmt.Errorf("the curve %q is not registered", schema.Curve)
+	}
+	n, t := len(schema.Parties), schema.Threshold
+	if t < 1 || n <= t {
+		return save, fmt.Errorf("the threshold %d is invalid for %d parties", t, n)
+	}
+	save = NewLocalPartySaveData(n)
+	save.KeyThreshold, save.CurveName = t, schema.Curve
+	for j, Pj := range schema.Parties {
+		if save.Ks[j], err = parseHexInt(fmt.Sprintf("parties[%d].shareID", j), Pj.ShareID); err != nil {
+			return
+		}
+		if save.BigXj[j], err = Pj.BigX.toECPoint(ec, fmt.Sprintf("parties[%d].bigX", j)); err != nil {
+			return
+		}
+		var N *big.Int
+		if N, err = parseHexInt(fmt.Sprintf("parties[%d].paillierN", j), Pj.PaillierN); err != nil {
+			return
+		}
+		save.PaillierPKs[j] = &paillier.PublicKey{N: N}
+		if save.NTildej[j], err = parseHexInt(fmt.Sprintf("parties[%d].nTilde", j), Pj.NTilde); err != nil {
+			return
+		}
+		if save.H1j[j], err = parseHexInt(fmt.Sprintf("parties[%d].h1", j), Pj.H1); err != nil {
+			return
+		}
+		if save.H2j[j], err = parseHexInt(fmt.Sprintf("parties[%d].h2", j), Pj.H2); err != nil {
+			return
+		}
+		if !IsSupportedModulusLen(N.BitLen()) || !IsSupportedModulusLen(save.NTildej[j].BitLen()) {
+			return save, fmt.Errorf("the moduli of party %d have an unsupported length", j)
+		}
+		if save.H1j[j].Cmp(save.H2j[j]) == 0 {
+			return save, fmt.Errorf("h1 and h2 of party %d are equal", j)
+		}
+	}
+	if _, err = vss.CheckIndexes(ec, save.Ks); err != nil {
+		return
+	}
+
+	// this party
+	if save.ShareID, err = parseHexInt("shareID", schema.ShareID); err != nil {
+		return
+	}
+	i := -1
+	for j, kj := range save.Ks {
+		if kj.Cmp(save.ShareID) == 0 {
+			i = j
+		}
+	}
+	if i < 0 {
+		return save, errors.New("the shareID is not one of the parties")
+	}
+	if save.Xi, err = parseHexInt("xi", schema.Xi); err != nil {
+		return
+	}
+	if save.Xi.Cmp(ec.Params().N) >= 0 || !crypto.ScalarBaseMult(ec, save.Xi).Equals(save.BigXj[i]) {
+		return save, errors.New("xi does not match the bigX of this party")
+	}
+	if save.ECDSAPub, err = schema.ECDSAPub.toECPoint(ec, "ecdsaPub"); err != nil {
+		return
+	}
+	if err = checkPublicSharesInterpolate(save.Ks, save.BigXj, save.ECDSAPub, t); err != nil {
+		return
+	}
+	if schema.ChainCode != "" {
+		if save.ChainCode, err = hex.DecodeString(schema.ChainCode); err != nil || len(save.ChainCode) != 32 {
+			return save, errors.New("the chainCode must be 32 hex-encoded bytes")
+		}
+	}
+
+	// the pre-params of this party
+	var P, Q *big.Int
+	if P, err = parseHexInt("paillierP", schema.PaillierP); err != nil {
+		return
+	}
+	if Q, err = parseHexInt("paillierQ", schema.PaillierQ); err != nil {
+		return
+	}
+	if new(big.Int).Mul(P, Q).Cmp(save.PaillierPKs[i].N) != 0 {
+		return save, errors.New("paillierP * paillierQ does not equal the paillierN of this party")
+	}
+	save.PaillierSK = paillierSKFromPrimes(P, Q)
+	save.NTildei, save.H1i, save.H2i = save.NTildej[i], save.H1j[i], save.H2j[i]
+	if schema.Alpha == "" && schema.Beta == "" && schema.P == "" && schema.Q == "" {
+		return save, nil
+	}
+	for _, field := range []struct {
+		name, value string
+		dst         **big.Int
+	}{{"alpha", schema.Alpha, &save.Alpha}, {"beta", schema.Beta, &save.Beta}, {"p", schema.P, &save.P}, {"q", schema.Q, &save.Q}} {
+		if *field.dst, err = parseHexInt(field.name, field.value); err != nil {
+			return save, fmt.Errorf("%v; alpha, beta, p and q must be given together", err)
+		}
+	}
+	if !save.VerifyH2IsH1PowAlpha() {
+		return save, errors.New("alpha and beta do not match h1 and h2 of this party")
+	}
+	one, two := big.NewInt(1), big.NewInt(2)
+	safeP := new(big.Int).Add(new(big.Int).Mul(save.P, two), one)
+	safeQ := new(big.Int).Add(new(big.Int).Mul(save.Q, two), one)
+	if new(big.Int).Mul(safeP, safeQ).Cmp(save.NTildei) != 0 {
+		return save, errors.New("(2p+1)(2q+1) does not equal the nTilde of this party")
+	}
+	return save, nil
+}
+
+// checkPublicSharesInterpolate returns an error unless the public shares Xj all lie on a polynomial of degree t in the
+// exponent that evaluates to y at 0: the first t of them must interpolate y together with each of the others.
+func checkPublicSharesInterpolate(ks []*big.Int, bigXs []*crypto.ECPoint, y *crypto.ECPoint, t int) error {
+	idxs := make([]int, t+1)
+	for j := range idxs[:t] {
+		idxs[j] = j
+	}
+	for j := t; j < len(ks); j++ {
+		idxs[t] = j
+		subKs, subXs := make([]*big.Int, t+1), make([]*crypto.ECPoint, t+1)
+		for c, idx := range idxs {
+			subKs[c], subXs[c] = ks[idx], bigXs[idx]
+		}
+		res, err := vss.InterpolatePublicShares(subKs, subXs)
+		if err != nil || !res.Equals(y) {
+			return fmt.Errorf("the bigX of the parties do not reconstruct ecdsaPub with threshold %d (party %d)", t, j)
+		}
+	}
+	return nil
+}
+
+func paillierSKFromPrimes(P, Q *big.Int) *paillier.PrivateKey {
+	one := big.NewInt(1)
+	N := new(big.Int).Mul(P, Q)
+	pMinus1, qMinus1 := new(big.Int).Sub(P, one), new(big.Int).Sub(Q, one)
+	phiN := new(big.Int).Mul(pMinus1, qMinus1)
+	gcd := new(big.Int).GCD(nil, nil, pMinus1, qMinus1)
+	lambdaN := new(big.Int).Div(phiN, gcd)
+	return &paillier.PrivateKey{PublicKey: paillier.PublicKey{N: N}, LambdaN: lambdaN, PhiN: phiN, P: P, Q: Q}
+}
+
+func hexInt(i *big.Int) string {
+	if i == nil {
+		return ""
+	}
+	return i.Text(16)
+}
+
+func parseHexInt(name, s string) (*big.Int, error) {
+	i, ok := new(big.Int).SetString(s, 16)
+	if !ok || s == "" || s[0] == '-' || s[0] == '+' {
+		return nil, fmt.Errorf("%s must be a non-negative hex integer", name)
+	}
+	return i, nil
+}
+
+func newSchemaPoint(p *crypto.ECPoint) schemaPoint {
+	return schemaPoint{X: hexInt(p.X()), Y: hexInt(p.Y())}
+}
+
+func (p schemaPoint) toECPoint(ec elliptic.Curve, name string) (*crypto.ECPoint, error) {
+	x, err := parseHexInt(name+".x", p.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := parseHexInt(name+".y", p.Y)
+	if err != nil {
+		return nil, err
+	}
+	pt, err := crypto.NewECPoint(ec, x, y)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a point on the curve", name)
+	}
+	return pt, nil
+}
@@ -0,0 +1,119 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// the golden file is the export of the keygen fixture of party 0
+const saveDataSchemaGoldenFile = "../../test/_ecdsa_schema_fixtures/save_data_v1.json"
+
+func TestExportSaveDataGolden(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(1)
+	assert.NoError(t, err)
+	golden, err := os.ReadFile(saveDataSchemaGoldenFile)
+	assert.NoError(t, err)
+
+	bz, err := ExportSaveData(keys[0])
+	assert.NoError(t, err)
+	assert.Equal(t, string(golden), string(bz))
+}
+
+func TestImportSaveDataGolden(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(1)
+	assert.NoError(t, err)
+	key := keys[0]
+	golden, err := os.ReadFile(saveDataSchemaGoldenFile)
+	assert.NoError(t, err)
+
+	imported, err := ImportSaveData(golden)
+	assert.NoError(t, err)
+	assert.Equal(t, test.TestThreshold, imported.KeyThreshold)
+	assert.Equal(t, tss.CurveName("secp256k1"), imported.CurveName)
+	assert.Equal(t, key.Xi, imported.Xi)
+	assert.Equal(t, key.ShareID, imported.ShareID)
+	assert.True(t, key.ECDSAPub.Equals(imported.ECDSAPub))
+	assert.Equal(t, key.LocalPreParams, imported.LocalPreParams)
+	assert.Equal(t, key.Ks, imported.Ks)
+	assert.Equal(t, key.NTildej, imported.NTildej)
+	assert.Equal(t, key.H1j, imported.H1j)
+	assert.Equal(t, key.H2j, imported.H2j)
+	assert.Equal(t, key.PaillierPKs, imported.PaillierPKs)
+	for j := range key.BigXj {
+		assert.True(t, key.BigXj[j].Equals(imported.BigXj[j]))
+	}
+
+	bz, err := ExportSaveData(imported)
+	assert.NoError(t, err)
+	assert.Equal(t, string(golden), string(bz), "the export of an import is unchanged")
+}
+
+func TestImportSaveDataInvalid(t *testing.T) {
+	golden, err := os.ReadFile(saveDataSchemaGoldenFile)
+	assert.NoError(t, err)
+
+	tamper := func(f func(schema map[string]interface{})) []byte {
+		var schema map[string]interface{}
+		assert.NoError(t, json.Unmarshal(golden, &schema))
+		f(schema)
+		bz, err := json.Marshal(schema)
+		assert.NoError(t, err)
+		return bz
+	}
+	party := func(schema map[string]interface{}, j int) map[string]interface{} {
+		return schema["parties"].([]interface{})[j].(map[string]interface{})
+	}
+	cases := map[string]func(schema map[string]interface{}){
+		"unknown version":   func(schema map[string]interface{}) { schema["version"] = 2 },
+		"unknown field":     func(schema map[string]interface{}) { schema["extra"] = "x" },
+		"unknown curve":     func(schema map[string]interface{}) { schema["curve"] = "curve25519" },
+		"wrong threshold":   func(schema map[string]interface{}) { schema["threshold"] = test.TestThreshold - 1 },
+		"threshold too big": func(schema map[string]interface{}) { schema["threshold"] = test.TestParticipants },
+		"wrong xi":          func(schema map[string]interface{}) { schema["xi"] = "1" },
+		"bad xi":            func(schema map[string]interface{}) { schema["xi"] = "0x1" },
+		"unknown shareID":   func(schema map[string]interface{}) { schema["shareID"] = "2" },
+		"wrong ecdsaPub":    func(schema map[string]interface{}) { schema["ecdsaPub"] = party(schema, 1)["bigX"] },
+		"off-curve point": func(schema map[string]interface{}) {
+			party(schema, 0)["bigX"].(map[string]interface{})["y"] = "1"
+		},
+		"wrong bigX of another party": func(schema map[string]interface{}) {
+			party(schema, 3)["bigX"] = schema["ecdsaPub"]
+		},
+		"duplicate shareID": func(schema map[string]interface{}) {
+			party(schema, 4)["shareID"] = party(schema, 3)["shareID"]
+		},
+		"wrong paillier prime": func(schema map[string]interface{}) { schema["paillierP"] = schema["paillierQ"] },
+		"short nTilde":         func(schema map[string]interface{}) { party(schema, 2)["nTilde"] = "ff" },
+		"equal h1 and h2":      func(schema map[string]interface{}) { party(schema, 2)["h2"] = party(schema, 2)["h1"] },
+		"wrong alpha":          func(schema map[string]interface{}) { schema["alpha"] = "2" },
+		"partial dln secrets":  func(schema map[string]interface{}) { delete(schema, "beta") },
+		"no parties":           func(schema map[string]interface{}) { schema["parties"] = []interface{}{} },
+	}
+	for name, f := range cases {
+		_, err := ImportSaveData(tamper(f))
+		assert.Error(t, err, name)
+	}
+
+	// the secrets of the NTilde are optional
+	imported, err := ImportSaveData(tamper(func(schema map[string]interface{}) {
+		delete(schema, "alpha")
+		delete(schema, "beta")
+		delete(schema, "p")
+		delete(schema, "q")
+	}))
+	assert.NoError(t, err)
+	assert.True(t, imported.Validate())
+	assert.False(t, imported.ValidateWithProof())
+}
@@ -7,6 +7,7 @@
 package keygen
 
 import (
+	"crypto/elliptic"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -29,18 +30,29 @@ const (
 	TestThreshold    = test.TestParticipants / 2
 )
 const (
-	testFixtureDirFormat  = "%s/../../test/_ecdsa_fixtures"
-	testFixtureFileFormat = "keygen_data_%d.json"
+	testFixtureDirFormat     = "%s/../../test/_ecdsa_fixtures"
+	testP384FixtureDirFormat = "%s/../../test/_ecdsa_p384_fixtures"
+	testFixtureFileFormat    = "keygen_data_%d.json"
 )
 
 func LoadKeygenTestFixtures(qty int, optionalStart ...int) ([]LocalPartySaveData, tss.SortedPartyIDs, error) {
-	keys := make([]LocalPartySaveData, 0, qty)
 	start := 0
 	if 0 < len(optionalStart) {
 		start = optionalStart[0]
 	}
+	return loadKeygenTestFixtures(testFixtureDirFormat, tss.S256(), qty, start)
+}
+
+// LoadKeygenTestFixturesP384 loads the save data of a keygen on the NIST P-384 curve, like LoadKeygenTestFixtures.
+// The parties have the pre-params of the secp256k1 fixtures.
+func LoadKeygenTestFixturesP384(qty int) ([]LocalPartySaveData, tss.SortedPartyIDs, error) {
+	return loadKeygenTestFixtures(testP384FixtureDirFormat, elliptic.P384(), qty, 0)
+}
+
+func loadKeygenTestFixtures(dirFormat string, ec elliptic.Curve, qty, start int) ([]LocalPartySaveData, tss.SortedPartyIDs, error) {
+	keys := make([]LocalPartySaveData, 0, qty)
 	for i := start; i < qty; i++ {
-		fixtureFilePath := makeTestFixtureFilePath(i)
+		fixtureFilePath := makeTestFixtureFilePathIn(dirFormat, i)
 		bz, err := ioutil.ReadFile(fixtureFilePath)
 		if err != nil {
 			return nil, nil, errors.Wrapf(err,
@@ -54,9 +66,9 @@ func LoadKeygenTestFixtures(qty int, optionalStart ...int) ([]LocalPartySaveData
 				i, fixtureFilePath)
 		}
 		for _, kbxj := range key.BigXj {
-			kbxj.SetCurve(tss.S256())
+			kbxj.SetCurve(ec)
 		}
-		key.ECDSAPub.SetCurve(tss.S256())
+		key.ECDSAPub.SetCurve(ec)
 		keys = append(keys, key)
 	}
 	partyIDs := make(tss.UnSortedPartyIDs, len(keys))
@@ -121,8 +133,12 @@ func LoadNTildeH1H2FromTestFixture(idx int) (NTildei, h1i, h2i *big.Int, err err
 }
 
 func makeTestFixtureFilePath(partyIndex int) string {
+	return makeTestFixtureFilePathIn(testFixtureDirFormat, partyIndex)
+}
+
+func makeTestFixtureFilePathIn(dirFormat string, partyIndex int) string {
 	_, callerFileName, _, _ := runtime.Caller(0)
 	srcDirName := filepath.Dir(callerFileName)
-	fixtureDirName := fmt.Sprintf(testFixtureDirFormat, srcDirName)
+	fixtureDirName := fmt.Sprintf(dirFormat, srcDirName)
 	return fmt.Sprintf("%s/"+testFixtureFileFormat, fixtureDirName, partyIndex)
 }
@@ -8,6 +8,8 @@ package resharing_test
 
 import (
 	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"fmt"
 	"math/big"
 	"runtime"
@@ -19,6 +21,7 @@ import (
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
 	. "github.com/bnb-chain/tss-lib/v2/ecdsa/resharing"
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
@@ -49,6 +52,17 @@ func TestE2EConcurrent(t *testing.T) {
 	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold+1+extraParties+firstPartyIdx, firstPartyIdx)
 	assert.NoError(t, err, "should load keygen fixtures")
 
+	// a chain code that is not the one derived from the public key must be carried forward as well
+	chainCode, err := common.GetRandomBytes(rand.Reader, 32)
+	assert.NoError(t, err)
+	for j := range oldKeys {
+		oldKeys[j].ChainCode = chainCode
+	}
+	oldExtKey, err := oldKeys[0].ExtendedKey()
+	assert.NoError(t, err)
+	_, oldChild, err := ckd.DeriveChildKey(7, oldExtKey, tss.S256())
+	assert.NoError(t, err)
+
 	// PHASE: resharing
 	oldP2PCtx := tss.NewPeerContext(oldPIDs)
 	// init the new parties; re-use the fixture pre-params for speed
@@ -73,7 +87,9 @@ func TestE2EConcurrent(t *testing.T) {
 	// init the old parties first
 	for j, pID := range oldPIDs {
 		params := tss.NewReSharingParameters(tss.S256(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
-		P := NewLocalParty(params, oldKeys[j], outCh, endCh).(*LocalParty) // discard old key data
+		input, err := PrepareOldCommitteeInput(oldKeys[j], oldPIDs)
+		assert.NoError(t, err, "should prepare the old committee input")
+		P := NewLocalParty(params, input, outCh, endCh).(*LocalParty) // discard old key data
 		oldCommittee = append(oldCommittee, P)
 	}
 	// init the new parties
@@ -150,6 +166,44 @@ func TestE2EConcurrent(t *testing.T) {
 				assert.Equal(t, len(oldCommittee), endedOldCommittee)
 				t.Logf("Resharing done. Reshared %d participants", reSharingEnded)
 
+				// the group public key must be preserved for every member of the new committee
+				for _, key := range newKeys {
+					assert.NoError(t, VerifyPublicKeyPreserved(oldKeys[0], key), "public key should be preserved")
+				}
+				// old committee members and auditors only see the public parts of the new committee's data
+				publicKey := newKeys[0]
+				publicKey.LocalSecrets = keygen.LocalSecrets{}
+				publicKey.LocalPreParams = keygen.LocalPreParams{}
+				for _, key := range oldKeys {
+					assert.NoError(t, VerifyPublicKeyPreserved(key, publicKey), "public key should be preserved")
+				}
+				badKey := newKeys[0]
+				badKey.BigXj = append([]*crypto.ECPoint{}, newKeys[0].BigXj...)
+				badKey.BigXj[1], _ = badKey.BigXj[1].Add(crypto.ScalarBaseMult(tss.S256(), big.NewInt(1)))
+				assert.Error(t, VerifyPublicKeyPreserved(oldKeys[0], badKey), "altered public shares should be detected")
+
+				// the same child key is derived before and after the resharing
+				for _, key := range newKeys {
+					assert.Equal(t, chainCode, key.ChainCode)
+					extKey, err := key.ExtendedKey()
+					assert.NoError(t, err)
+					_, child, err := ckd.DeriveChildKey(7, extKey, tss.S256())
+					if assert.NoError(t, err) {
+						assert.Equal(t, oldChild.String(), child.String(), "the derived child key should be preserved")
+					}
+				}
+				badKey.BigXj = newKeys[0].BigXj
+				badKey.ChainCode = keygen.MasterChainCode(badKey.ECDSAPub)
+				assert.Error(t, VerifyPublicKeyPreserved(oldKeys[0], badKey), "an altered chain code should be detected")
+
+				// the result of the resharing as a whole can be verified against the old public key
+				assert.NoError(t, VerifyReshareResult(oldKeys[0].ECDSAPub, newKeys))
+				corruptedKeys := append([]keygen.LocalPartySaveData{}, newKeys...)
+				corruptedKeys[1].Xi = new(big.Int).Add(newKeys[1].Xi, big.NewInt(1))
+				assert.Error(t, VerifyReshareResult(oldKeys[0].ECDSAPub, corruptedKeys), "a corrupted share should be detected")
+				otherPub := crypto.ScalarBaseMult(tss.S256(), big.NewInt(42))
+				assert.Error(t, VerifyReshareResult(otherPub, newKeys), "another public key should be detected")
+
 				// xj tests: BigXj == xj*G
 				for j, key := range newKeys {
 					// xj test: BigXj == xj*G
@@ -157,6 +211,7 @@ func TestE2EConcurrent(t *testing.T) {
 					gXj := crypto.ScalarBaseMult(tss.S256(), xj)
 					BigXj := key.BigXj[j]
 					assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
+					assert.Equal(t, newThreshold, key.Threshold())
 				}
 
 				// more verification of signing is implemented within local_party_test.go of keygen package
@@ -236,3 +291,204 @@ signing:
 		}
 	}
 }
+
+func TestPrepareOldCommitteeInput(t *testing.T) {
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	oldCommittee := pIDs[:testThreshold+1]
+
+	input, err := PrepareOldCommitteeInput(keys[0], oldCommittee)
+	assert.NoError(t, err)
+	assert.Equal(t, len(oldCommittee), len(input.Ks))
+	for j, id := range oldCommittee {
+		assert.Equal(t, 0, id.KeyInt().Cmp(input.Ks[j]))
+	}
+
+	_, err = PrepareOldCommitteeInput(keys[testParticipants-1], oldCommittee)
+	assert.Error(t, err, "a party outside of the old committee should be rejected")
+
+	noSecret := keys[0]
+	noSecret.Xi = nil
+	_, err = PrepareOldCommitteeInput(noSecret, oldCommittee)
+	assert.Error(t, err, "save data without a secret share should be rejected")
+
+	missing := keygen.BuildLocalSaveDataSubset(keys[0], pIDs[:testThreshold])
+	_, err = PrepareOldCommitteeInput(missing, oldCommittee)
+	assert.Error(t, err, "save data missing an old committee member should be rejected")
+}
+
+func TestE2EThresholdChangeSameParties(t *testing.T) {
+	setUp("info")
+
+	threshold, newThreshold := testThreshold, testThreshold+1
+
+	// PHASE: load keygen fixtures
+	oldKeys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: resharing to the same parties; every party is in both committees
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	for j, pID := range pIDs {
+		params := tss.NewReSharingParameters(tss.S256(), p2pCtx, p2pCtx, pID, len(pIDs), threshold, len(pIDs), newThreshold)
+		// do not use in untrusted setting
+		params.SetNoProofMod()
+		// do not use in untrusted setting
+		params.SetNoProofFac()
+		P := NewLocalParty(params, oldKeys[j], outCh, endCh).(*LocalParty)
+		assert.True(t, params.IsOldCommittee() && params.IsNewCommittee())
+		parties = append(parties, P)
+	}
+	for _, P := range parties {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	newKeys := make([]keygen.LocalPartySaveData, len(pIDs))
+	var reSharingEnded int32
+resharing:
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				t.Fatal("did not expect a msg to have a nil destination during resharing")
+			}
+			// a message to both committees lists each party twice
+			seen := make(map[int]bool, len(dest))
+			for _, destP := range dest {
+				if seen[destP.Index] {
+					continue
+				}
+				seen[destP.Index] = true
+				go updater(parties[destP.Index], msg, errCh)
+			}
+
+		case save := <-endCh:
+			index, err := save.OriginalIndex()
+			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
+			newKeys[index] = *save
+			if atomic.AddInt32(&reSharingEnded, 1) == int32(len(pIDs)) {
+				break resharing
+			}
+		}
+	}
+	for j, key := range newKeys {
+		assert.NoError(t, VerifyPublicKeyPreserved(oldKeys[j], key), "public key should be preserved")
+		assert.True(t, key.ECDSAPub.Equals(oldKeys[j].ECDSAPub), "public key should be unchanged")
+		assert.Equal(t, newThreshold, key.Threshold())
+		assert.NotEqual(t, 0, key.Xi.Cmp(oldKeys[j].Xi), "the share should be refreshed")
+	}
+	assert.NoError(t, VerifyReshareResult(oldKeys[0].ECDSAPub, newKeys))
+
+	// PHASE: signing with the new threshold
+	signParties := make([]*signing.LocalParty, 0, len(pIDs))
+	signErrCh := make(chan *tss.Error, len(pIDs))
+	signOutCh := make(chan tss.Message, len(pIDs))
+	signEndCh := make(chan *common.SignatureData, len(pIDs))
+
+	for j, pID := range pIDs {
+		params := tss.NewParameters(tss.S256(), p2pCtx, pID, len(pIDs), newThreshold)
+		P := signing.NewLocalParty(big.NewInt(42), params, newKeys[j], signOutCh, signEndCh).(*signing.LocalParty)
+		signParties = append(signParties, P)
+		go func(P *signing.LocalParty) {
+			if err := P.Start(); err != nil {
+				signErrCh <- err
+			}
+		}(P)
+	}
+
+	var signEnded int32
+	for {
+		select {
+		case err := <-signErrCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return
+
+		case msg := <-signOutCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range signParties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, signErrCh)
+				}
+			} else {
+				go updater(signParties[dest[0].Index], msg, signErrCh)
+			}
+
+		case signData := <-signEndCh:
+			if atomic.AddInt32(&signEnded, 1) == int32(len(pIDs)) {
+				pk := ecdsa.PublicKey{
+					Curve: tss.S256(),
+					X:     oldKeys[0].ECDSAPub.X(),
+					Y:     oldKeys[0].ECDSAPub.Y(),
+				}
+				ok := ecdsa.Verify(&pk, big.NewInt(42).Bytes(),
+					new(big.Int).SetBytes(signData.R),
+					new(big.Int).SetBytes(signData.S))
+				assert.True(t, ok, "ecdsa verify must pass")
+				return
+			}
+		}
+	}
+}
+
+func TestPartlyOverlappingCommittees(t *testing.T) {
+	setUp("info")
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// the last old parties stay, and two parties join
+	unsorted := make(tss.UnSortedPartyIDs, 0, len(oldPIDs))
+	for _, Pj := range oldPIDs[2:] {
+		unsorted = append(unsorted, tss.NewPartyID(Pj.Id, Pj.Moniker, Pj.KeyInt()))
+	}
+	for _, Pj := range tss.GenerateTestPartyIDs(2, len(oldPIDs)) {
+		unsorted = append(unsorted, tss.NewPartyID(Pj.Id, Pj.Moniker, Pj.KeyInt()))
+	}
+	newPIDs := tss.SortPartyIDs(unsorted)
+
+	oldCtx, newCtx := tss.NewPeerContext(oldPIDs), tss.NewPeerContext(newPIDs)
+	for j, pID := range oldPIDs {
+		params := tss.NewReSharingParameters(tss.S256(), oldCtx, newCtx, pID, len(oldPIDs), testThreshold, len(newPIDs), testThreshold)
+		P := NewLocalParty(params, oldKeys[j], make(chan tss.Message, len(oldPIDs)+len(newPIDs)), nil)
+		err := P.Start()
+		if assert.NotNil(t, err, "party %d", j) {
+			assert.Contains(t, err.Error(), "the old and new committees share parties")
+		}
+	}
+}
+
+func TestCurveMismatch(t *testing.T) {
+	setUp("info")
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold+1, 0)
+	assert.NoError(t, err, "should load keygen fixtures")
+	newPIDs := tss.GenerateTestPartyIDs(testParticipants)
+	oldCtx, newCtx := tss.NewPeerContext(oldPIDs), tss.NewPeerContext(newPIDs)
+
+	// the keys are on secp256k1, the parameters on P-256
+	params := tss.NewReSharingParameters(elliptic.P256(), oldCtx, newCtx, oldPIDs[0], len(oldPIDs), testThreshold, len(newPIDs), testThreshold)
+	P := NewLocalParty(params, oldKeys[0], make(chan tss.Message, len(oldPIDs)+len(newPIDs)), nil)
+	err2 := P.Start()
+	if assert.NotNil(t, err2) {
+		assert.Contains(t, err2.Error(), "the key is on the curve secp256k1 but the parameters are on the curve P-256")
+	}
+}
@@ -44,6 +44,7 @@ func NewDGRound1Message(
 	ecdsaPub *crypto.ECPoint,
 	vct cmt.HashCommitment,
 	ssid []byte,
+	chainCode []byte,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:             from,
@@ -51,11 +52,13 @@ func NewDGRound1Message(
 		IsBroadcast:      true,
 		IsToOldCommittee: false,
 	}
+	pubX, pubY := ecdsaPub.CoordinateBytes()
 	content := &DGRound1Message{
-		EcdsaPubX:   ecdsaPub.X().Bytes(),
-		EcdsaPubY:   ecdsaPub.Y().Bytes(),
+		EcdsaPubX:   pubX,
+		EcdsaPubY:   pubY,
 		VCommitment: vct.Bytes(),
 		Ssid:        ssid,
+		ChainCode:   chainCode,
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
@@ -65,7 +68,8 @@ func (m *DGRound1Message) ValidateBasic() bool {
 	return m != nil &&
 		common.NonEmptyBytes(m.EcdsaPubX) &&
 		common.NonEmptyBytes(m.EcdsaPubY) &&
-		common.NonEmptyBytes(m.VCommitment)
+		common.NonEmptyBytes(m.VCommitment) &&
+		common.NonEmptyBytes(m.ChainCode)
 }
 
 func (m *DGRound1Message) UnmarshalECDSAPub(ec elliptic.Curve) (*crypto.ECPoint, error) {
@@ -83,6 +87,10 @@ func (m *DGRound1Message) UnmarshalSSID() []byte {
 	return m.GetSsid()
 }
 
+func (m *DGRound1Message) UnmarshalChainCode() []byte {
+	return m.GetChainCode()
+}
+
 // ----- //
 
 func NewDGRound2Message1(
@@ -92,6 +100,7 @@ func NewDGRound2Message1(
 	modProof *modproof.ProofMod,
 	NTildei, H1i, H2i *big.Int,
 	dlnProof1, dlnProof2 *dlnproof.Proof,
+	chainCode []byte,
 ) (tss.ParsedMessage, error) {
 	meta := tss.MessageRouting{
 		From:             from,
@@ -116,6 +125,7 @@ func NewDGRound2Message1(
 		H2:         H2i.Bytes(),
 		Dlnproof_1: dlnProof1Bz,
 		Dlnproof_2: dlnProof2Bz,
+		ChainCode:  chainCode,
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg), nil
@@ -131,7 +141,8 @@ func (m *DGRound2Message1) ValidateBasic() bool {
 		common.NonEmptyBytes(m.H2) &&
 		// expected len of dln proof = sizeof(int64) + len(alpha) + len(t)
 		common.NonEmptyMultiBytes(m.GetDlnproof_1(), 2+(dlnproof.Iterations*2)) &&
-		common.NonEmptyMultiBytes(m.GetDlnproof_2(), 2+(dlnproof.Iterations*2))
+		common.NonEmptyMultiBytes(m.GetDlnproof_2(), 2+(dlnproof.Iterations*2)) &&
+		common.NonEmptyBytes(m.ChainCode)
 }
 
 func (m *DGRound2Message1) UnmarshalPaillierPK() *paillier.PublicKey {
@@ -164,6 +175,10 @@ func (m *DGRound2Message1) UnmarshalDLNProof2() (*dlnproof.Proof, error) {
 	return dlnproof.UnmarshalDLNProof(m.GetDlnproof_2())
 }
 
+func (m *DGRound2Message1) UnmarshalChainCode() []byte {
+	return m.GetChainCode()
+}
+
 // ----- //
 
 func NewDGRound2Message2(
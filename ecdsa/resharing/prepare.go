@@ -0,0 +1,66 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package resharing
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// PrepareOldCommitteeInput slices a party's full keygen save data down to the members of the old committee,
+// which is the form that NewLocalParty expects for an old committee member.
+// Unlike keygen.BuildLocalSaveDataSubset it does not panic; an error is returned if the save data is missing
+// any field that the old committee needs or if this party is not itself a member of the old committee.
+func PrepareOldCommitteeInput(fullKey keygen.LocalPartySaveData, oldCommittee tss.SortedPartyIDs) (keygen.LocalPartySaveData, error) {
+	if len(oldCommittee) == 0 {
+		return keygen.LocalPartySaveData{}, errors.New("PrepareOldCommitteeInput: the old committee is empty")
+	}
+	if fullKey.Xi == nil || fullKey.ShareID == nil {
+		return keygen.LocalPartySaveData{}, errors.New("PrepareOldCommitteeInput: the save data has no secret share")
+	}
+	if fullKey.ECDSAPub == nil {
+		return keygen.LocalPartySaveData{}, errors.New("PrepareOldCommitteeInput: the save data has no public key")
+	}
+	if len(fullKey.BigXj) != len(fullKey.Ks) || len(fullKey.NTildej) != len(fullKey.Ks) ||
+		len(fullKey.H1j) != len(fullKey.Ks) || len(fullKey.H2j) != len(fullKey.Ks) {
+		return keygen.LocalPartySaveData{}, errors.New("PrepareOldCommitteeInput: the save data has inconsistent party data lengths")
+	}
+	found := false
+	for _, id := range oldCommittee {
+		if id.KeyInt().Cmp(fullKey.ShareID) == 0 {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return keygen.LocalPartySaveData{}, errors.New("PrepareOldCommitteeInput: this party is not a member of the old committee")
+	}
+	for _, id := range oldCommittee {
+		j := -1
+		for k, kj := range fullKey.Ks {
+			if kj != nil && kj.Cmp(id.KeyInt()) == 0 {
+				j = k
+				break
+			}
+		}
+		if j < 0 {
+			return keygen.LocalPartySaveData{}, fmt.Errorf("PrepareOldCommitteeInput: old committee member %s is not in the save data", id)
+		}
+		if fullKey.BigXj[j] == nil || fullKey.NTildej[j] == nil || fullKey.H1j[j] == nil || fullKey.H2j[j] == nil {
+			return keygen.LocalPartySaveData{}, fmt.Errorf("PrepareOldCommitteeInput: the save data is missing public data of old committee member %s", id)
+		}
+		if fullKey.Ks[j].Cmp(fullKey.ShareID) == 0 &&
+			!crypto.ScalarBaseMult(fullKey.ECDSAPub.Curve(), fullKey.Xi).Equals(fullKey.BigXj[j]) {
+			return keygen.LocalPartySaveData{}, errors.New("PrepareOldCommitteeInput: the secret share does not match its public share")
+		}
+	}
+	return keygen.BuildLocalSaveDataSubset(fullKey, oldCommittee), nil
+}
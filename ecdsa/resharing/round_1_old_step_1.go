@@ -7,9 +7,9 @@
 package resharing
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
-	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
@@ -35,19 +35,37 @@ func (round *round1) Start() *tss.Error {
 	round.resetOK() // resets both round.oldOK and round.newOK
 	round.allNewOK()
 
+	if err := checkCommittees(round.OldParties().IDs(), round.NewParties().IDs()); err != nil {
+		return round.WrapError(err)
+	}
 	if !round.ReSharingParams().IsOldCommittee() {
 		return nil
 	}
-	round.allOldOK()
+	Pi := round.PartyID()
+	i := Pi.Index
 
-	round.temp.ssidNonce = new(big.Int).SetUint64(uint64(0))
+	if err := round.input.CheckCurve(round.Params().EC()); err != nil {
+		return round.WrapError(err, round.PartyID())
+	}
+	// the chain code is carried forward so that the keys derived from the extended key stay the same
+	extKey, err := round.input.ExtendedKey()
+	if err != nil {
+		return round.WrapError(err, round.PartyID())
+	}
+	if round.ReSharingParams().IsNewCommittee() {
+		// a party in both committees still waits for the other old parties, and expects them to share our key
+		round.oldOK[i] = true
+		round.save.ECDSAPub = round.input.ECDSAPub
+		round.save.ChainCode = extKey.ChainCode
+	} else {
+		round.allOldOK()
+	}
+	round.temp.ssidNonce = round.SSIDNonce()
 	ssid, err := round.getSSID()
 	if err != nil {
 		return round.WrapError(err)
 	}
 	round.temp.ssid = ssid
-	Pi := round.PartyID()
-	i := Pi.Index
 
 	// 1. PrepareForSigning() -> w_i
 	xi, ks, bigXj := round.input.Xi, round.input.Ks, round.input.BigXj
@@ -77,13 +95,41 @@ func (round *round1) Start() *tss.Error {
 	// 5. "broadcast" C_i to members of the NEW committee
 	r1msg := NewDGRound1Message(
 		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
-		round.input.ECDSAPub, vCmt.C, ssid)
+		round.input.ECDSAPub, vCmt.C, ssid, extKey.ChainCode)
 	round.temp.dgRound1Messages[i] = r1msg
 	round.out <- r1msg
 
 	return nil
 }
 
+// checkCommittees returns an error if a party is in both committees but the committees are not the same parties in the
+// same order. A party in both committees uses its index in the old committee as its index in the new one.
+func checkCommittees(oldIDs, newIDs tss.SortedPartyIDs) error {
+	oldKeys := make(map[string]struct{}, len(oldIDs))
+	for _, Pj := range oldIDs {
+		oldKeys[string(Pj.Key)] = struct{}{}
+	}
+	overlap := false
+	for _, Pj := range newIDs {
+		if _, ok := oldKeys[string(Pj.Key)]; ok {
+			overlap = true
+			break
+		}
+	}
+	if !overlap {
+		return nil
+	}
+	if len(oldIDs) != len(newIDs) {
+		return fmt.Errorf("the old and new committees share parties, so they must be the same parties, but have %d and %d", len(oldIDs), len(newIDs))
+	}
+	for j := range oldIDs {
+		if !bytes.Equal(oldIDs[j].Key, newIDs[j].Key) {
+			return fmt.Errorf("the old and new committees share parties, so they must be the same parties, but differ at index %d", j)
+		}
+	}
+	return nil
+}
+
 func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
 	// accept messages from old -> new committee
 	if _, ok := msg.Content().(*DGRound1Message); ok {
@@ -125,6 +171,14 @@ func (round *round1) Update() (bool, *tss.Error) {
 			return false, round.WrapError(errors.New("ecdsa pub key did not match what we received previously"), msg.GetFrom())
 		}
 		round.save.ECDSAPub = candidate
+
+		// every member of the old committee must send the same chain code
+		chainCode := msg.Content().(*DGRound1Message).UnmarshalChainCode()
+		if round.save.ChainCode != nil &&
+			!bytes.Equal(chainCode, round.save.ChainCode) {
+			return false, round.WrapError(errors.New("chain code did not match what we received previously"), msg.GetFrom())
+		}
+		round.save.ChainCode = chainCode
 	}
 	return ret, nil
 }
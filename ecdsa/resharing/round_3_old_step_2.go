@@ -24,16 +24,25 @@ func (round *round3) Start() *tss.Error {
 	if !round.ReSharingParams().IsOldCommittee() {
 		return nil
 	}
-	round.allOldOK()
-
 	Pi := round.PartyID()
 	i := Pi.Index
 
+	if round.ReSharingParams().IsNewCommittee() {
+		// a party in both committees still waits for the shares of the other old parties
+		round.oldOK[i] = true
+	} else {
+		round.allOldOK()
+	}
+
 	// 2. send share to Pj from the new committee
 	for j, Pj := range round.NewParties().IDs() {
 		share := round.temp.NewShares[j]
 		r3msg1 := NewDGRound3Message1(Pj, round.PartyID(), share)
-		round.temp.dgRound3Message1s[i] = r3msg1
+		if Pj.KeyInt().Cmp(Pi.KeyInt()) == 0 {
+			// keep our own share rather than sending it to ourselves
+			round.temp.dgRound3Message1s[i] = r3msg1
+			continue
+		}
 		round.out <- r3msg1
 	}
 
@@ -7,8 +7,10 @@
 package resharing
 
 import (
+	"bytes"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"math/big"
 	"sync"
 
@@ -19,6 +21,7 @@ import (
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
 	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
 	"github.com/bnb-chain/tss-lib/v2/tss"
@@ -39,12 +42,14 @@ func (round *round4) Start() *tss.Error {
 		return nil
 	}
 
-	common.Logger.Debugf(
+	round.Params().Logger().Debugf(
 		"%s Setting up DLN verification with concurrency level of %d",
 		round.PartyID(),
 		round.Concurrency(),
 	)
-	dlnVerifier := keygen.NewDlnProofVerifier(round.Concurrency())
+	ctx, cancel := round.RoundContext()
+	defer cancel()
+	dlnVerifier := keygen.NewDlnProofVerifier(ctx, round.Concurrency())
 
 	Pi := round.PartyID()
 	i := Pi.Index
@@ -55,6 +60,7 @@ func (round *round4) Start() *tss.Error {
 	paiProofCulprits := make([]*tss.PartyID, len(round.temp.dgRound2Message1s)) // who caused the error(s)
 	dlnProof1FailCulprits := make([]*tss.PartyID, len(round.temp.dgRound2Message1s))
 	dlnProof2FailCulprits := make([]*tss.PartyID, len(round.temp.dgRound2Message1s))
+	modInputs, modIdxs := make([]modproof.ProofModInput, 0, len(round.temp.dgRound2Message1s)), make([]int, 0, len(round.temp.dgRound2Message1s))
 	wg := new(sync.WaitGroup)
 	for j, msg := range round.temp.dgRound2Message1s {
 		r2msg1 := msg.Content().(*DGRound2Message1)
@@ -62,6 +68,10 @@ func (round *round4) Start() *tss.Error {
 			r2msg1.UnmarshalNTilde(),
 			r2msg1.UnmarshalH1(),
 			r2msg1.UnmarshalH2()
+		// every member of the new committee must have received the same chain code
+		if !bytes.Equal(r2msg1.UnmarshalChainCode(), round.save.ChainCode) {
+			return round.WrapError(errors.New("chain code did not match ours"), msg.GetFrom())
+		}
 		if H1j.Cmp(H2j) == 0 {
 			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom())
 		}
@@ -73,46 +83,51 @@ func (round *round4) Start() *tss.Error {
 			return round.WrapError(errors.New("this h2j was already used by another party"), msg.GetFrom())
 		}
 		h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
-		wg.Add(3)
-		go func(j int, msg tss.ParsedMessage, r2msg1 *DGRound2Message1) {
-			defer wg.Done()
-			modProof, err := r2msg1.UnmarshalModProof()
-			if err != nil {
-				if !round.Parameters.NoProofMod() {
-					paiProofCulprits[j] = msg.GetFrom()
-				}
-				common.Logger.Warningf("modProof verify failed for party %s", msg.GetFrom(), err)
-				return
-			}
-			ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
-			if ok := modProof.Verify(ContextJ, paiPK.N); !ok {
+		if modProof, err := r2msg1.UnmarshalModProof(); err != nil {
+			if !round.Parameters.NoProofMod() {
 				paiProofCulprits[j] = msg.GetFrom()
-				common.Logger.Warningf("modProof verify failed for party %s", msg.GetFrom(), err)
 			}
-		}(j, msg, r2msg1)
+			round.Params().Logger().Warningf("modProof verify failed for party %s", msg.GetFrom(), err)
+		} else {
+			ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+			modInputs = append(modInputs, modproof.ProofModInput{Proof: modProof, Session: ContextJ, N: paiPK.N})
+			modIdxs = append(modIdxs, j)
+		}
+		if round.Params().NoProofDln() {
+			continue
+		}
+		wg.Add(2)
 		_j := j
 		_msg := msg
-		dlnVerifier.VerifyDLNProof1(r2msg1, H1j, H2j, NTildej, func(isValid bool) {
-			if !isValid {
+		dlnVerifier.VerifyDLNProof1(r2msg1, H1j, H2j, NTildej, func(isValid bool, err error) {
+			if !isValid && (err == nil || err != ctx.Err()) { // an aborted verification has no culprit
 				dlnProof1FailCulprits[_j] = _msg.GetFrom()
-				common.Logger.Warningf("dln proof 1 verify failed for party %s", _msg.GetFrom())
+				round.Params().Logger().Warningf("dln proof 1 verify failed for party %s", _msg.GetFrom())
 			}
 			wg.Done()
 		})
-		dlnVerifier.VerifyDLNProof2(r2msg1, H2j, H1j, NTildej, func(isValid bool) {
-			if !isValid {
+		dlnVerifier.VerifyDLNProof2(r2msg1, H2j, H1j, NTildej, func(isValid bool, err error) {
+			if !isValid && (err == nil || err != ctx.Err()) { // an aborted verification has no culprit
 				dlnProof2FailCulprits[_j] = _msg.GetFrom()
-				common.Logger.Warningf("dln proof 2 verify failed for party %s", _msg.GetFrom())
+				round.Params().Logger().Warningf("dln proof 2 verify failed for party %s", _msg.GetFrom())
 			}
 			wg.Done()
 		})
 	}
+	for _, k := range modproof.BatchVerify(modInputs, round.Concurrency()) {
+		j := modIdxs[k]
+		paiProofCulprits[j] = round.temp.dgRound2Message1s[j].GetFrom()
+		round.Params().Logger().Warningf("modProof verify failed for party %s", paiProofCulprits[j])
+	}
 	wg.Wait()
 	for _, culprit := range append(append(paiProofCulprits, dlnProof1FailCulprits...), dlnProof2FailCulprits...) {
 		if culprit != nil {
 			return round.WrapError(errors.New("dln proof verification failed"), culprit)
 		}
 	}
+	if err := ctx.Err(); err != nil {
+		return round.WrapError(fmt.Errorf("dln proof verification was aborted: %w", err))
+	}
 	// save NTilde_j, h1_j, h2_j received in NewCommitteeStep1 here
 	for j, msg := range round.temp.dgRound2Message1s {
 		if j == i {
@@ -140,7 +155,7 @@ func (round *round4) Start() *tss.Error {
 		// 6. unpack flat "v" commitment content
 		vCmtDeCmt := commitments.HashCommitDecommit{C: vCj, D: vDj}
 		ok, flatVs := vCmtDeCmt.DeCommit()
-		if !ok || len(flatVs) != (round.NewThreshold()+1)*2 { // they're points so * 2
+		if !ok || len(flatVs)%2 != 0 { // they're points so * 2
 			// TODO collect culprits and return a list of them as per convention
 			return round.WrapError(errors.New("de-commitment of v_j0..v_jt failed"), round.Parties().IDs()[j])
 		}
@@ -148,6 +163,10 @@ func (round *round4) Start() *tss.Error {
 		if err != nil {
 			return round.WrapError(err, round.Parties().IDs()[j])
 		}
+		// the polynomial must be of the degree of the new threshold, otherwise the share cannot verify against it
+		if err = vss.ValidateCommitments(vj, round.NewThreshold()); err != nil {
+			return round.WrapError(err, round.Parties().IDs()[j])
+		}
 		vjc[j] = vj
 
 		// 8.
@@ -191,20 +210,22 @@ func (round *round4) Start() *tss.Error {
 	for j := 0; j < round.NewPartyCount(); j++ {
 		Pj := round.NewParties().IDs()[j]
 		kj := Pj.KeyInt()
-		newBigXj := Vc[0]
 		newKs = append(newKs, kj)
-		z := new(big.Int).SetInt64(int64(1))
+		// newBigXj = sum(Vc[c] * kj^c)
+		zs := make([]*big.Int, round.NewThreshold()+1)
+		zs[0] = big.NewInt(1)
 		for c := 1; c <= round.NewThreshold(); c++ {
-			z = modQ.Mul(z, kj)
-			newBigXj, err = newBigXj.Add(Vc[c].ScalarMult(z))
-			if err != nil {
-				paiProofCulprits = append(paiProofCulprits, Pj)
-			}
+			zs[c] = modQ.Mul(zs[c-1], kj)
+		}
+		var newBigXj *crypto.ECPoint
+		newBigXj, err = crypto.MultiScalarMult(Vc, zs)
+		if err != nil {
+			paiProofCulprits = append(paiProofCulprits, Pj)
 		}
 		newBigXjs[j] = newBigXj
 	}
 	if len(paiProofCulprits) > 0 {
-		return round.WrapError(errors2.Wrapf(err, "newBigXj.Add(Vc[c].ScalarMult(z))"), paiProofCulprits...)
+		return round.WrapError(errors2.Wrapf(err, "crypto.MultiScalarMult(Vc, zs)"), paiProofCulprits...)
 	}
 
 	round.temp.newXi = newXi
@@ -10,7 +10,7 @@ import (
 	"errors"
 	"math/big"
 
-	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/facproof"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
@@ -28,6 +28,11 @@ func (round *round5) Start() *tss.Error {
 	i := Pi.Index
 
 	if round.IsNewCommittee() {
+		// a party that is also in the old committee must end up with the same public key that it started with
+		if round.IsOldCommittee() && !round.save.ECDSAPub.Equals(round.input.ECDSAPub) {
+			return round.WrapError(errors.New("assertion failed: the reshared y != the old y"), Pi)
+		}
+
 		// 21.
 		// for this P: SAVE data
 		ContextI := append(round.temp.ssid, big.NewInt(int64(i)).Bytes()...)
@@ -35,6 +40,8 @@ func (round *round5) Start() *tss.Error {
 		round.save.ShareID = round.PartyID().KeyInt()
 		round.save.Xi = round.temp.newXi
 		round.save.Ks = round.temp.newKs
+		round.save.KeyThreshold = round.NewThreshold()
+		round.save.CurveName, _ = tss.GetCurveName(round.Params().EC())
 
 		// misc: build list of paillier public keys to save
 		for j, msg := range round.temp.dgRound2Message1s {
@@ -44,26 +51,31 @@ func (round *round5) Start() *tss.Error {
 			r2msg1 := msg.Content().(*DGRound2Message1)
 			round.save.PaillierPKs[j] = r2msg1.UnmarshalPaillierPK()
 		}
+		facInputs, facIdxs := make([]facproof.ProofFacInput, 0, len(round.temp.dgRound4Message1s)), make([]int, 0, len(round.temp.dgRound4Message1s))
+		culprits := make([]*tss.PartyID, 0, len(round.temp.dgRound4Message1s)) // who caused the error(s)
 		for j, msg := range round.temp.dgRound4Message1s {
 			if j == i {
 				continue
 			}
 			r4msg1 := msg.Content().(*DGRound4Message1)
 			proof, err := r4msg1.UnmarshalFacProof()
-			if err != nil && round.Parameters.NoProofFac() {
-				common.Logger.Warningf("facProof verify failed for party %s", msg.GetFrom(), err)
-			} else {
-				if err != nil {
-					common.Logger.Warningf("facProof verify failed for party %s", msg.GetFrom(), err)
-					return round.WrapError(err, round.NewParties().IDs()[j])
-				}
-				if ok := proof.Verify(ContextI, round.EC(), round.save.PaillierPKs[j].N, round.save.NTildei,
-					round.save.H1i, round.save.H2i); !ok {
-					common.Logger.Warningf("facProof verify failed for party %s", msg.GetFrom(), err)
-					return round.WrapError(err, round.NewParties().IDs()[j])
+			if err != nil {
+				round.Params().Logger().Warningf("facProof verify failed for party %s", msg.GetFrom(), err)
+				if !round.Parameters.NoProofFac() {
+					culprits = append(culprits, round.NewParties().IDs()[j])
 				}
+				continue
 			}
-
+			facInputs = append(facInputs, facproof.ProofFacInput{Proof: proof, Session: ContextI,
+				N0: round.save.PaillierPKs[j].N, NCap: round.save.NTildei, S: round.save.H1i, T: round.save.H2i})
+			facIdxs = append(facIdxs, j)
+		}
+		for _, k := range facproof.BatchVerify(round.EC(), facInputs, round.Concurrency()) {
+			round.Params().Logger().Warningf("facProof verify failed for party %s", round.NewParties().IDs()[facIdxs[k]])
+			culprits = append(culprits, round.NewParties().IDs()[facIdxs[k]])
+		}
+		if len(culprits) > 0 {
+			return round.WrapError(errors.New("facProof verify failed"), culprits...)
 		}
 	} else if round.IsOldCommittee() {
 		round.input.Xi.SetInt64(0)
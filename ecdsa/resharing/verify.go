@@ -0,0 +1,155 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package resharing
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+)
+
+// VerifyPublicKeyPreserved checks that a resharing did not alter the group public key or its chain code.
+// `oldKey` is the save data held before the resharing and `newKey` is the save data output by the resharing.
+// It may be called by members of either committee as well as by an auditor; old committee members do not receive the
+// new public shares from the protocol, so they should pass the public parts of a new committee member's save data.
+// When `newKey` holds the public shares of the new committee (BigXj and Ks), they are interpolated in the exponent
+// and must also yield the group public key; when it holds a secret share, that share must match its public share.
+func VerifyPublicKeyPreserved(oldKey, newKey keygen.LocalPartySaveData) error {
+	if oldKey.ECDSAPub == nil || newKey.ECDSAPub == nil {
+		return errors.New("VerifyPublicKeyPreserved: the public key is missing from the save data")
+	}
+	if !oldKey.ECDSAPub.Equals(newKey.ECDSAPub) {
+		return errors.New("VerifyPublicKeyPreserved: the public key was changed by the resharing")
+	}
+	oldExtKey, err := oldKey.ExtendedKey()
+	if err != nil {
+		return fmt.Errorf("VerifyPublicKeyPreserved: %v", err)
+	}
+	newExtKey, err := newKey.ExtendedKey()
+	if err != nil {
+		return fmt.Errorf("VerifyPublicKeyPreserved: %v", err)
+	}
+	if !bytes.Equal(oldExtKey.ChainCode, newExtKey.ChainCode) {
+		return errors.New("VerifyPublicKeyPreserved: the chain code was changed by the resharing")
+	}
+	if !hasPublicShares(newKey) {
+		// only the group public key is available to compare
+		return nil
+	}
+	if err := verifyPublicShares(oldKey.ECDSAPub, newKey); err != nil {
+		return fmt.Errorf("VerifyPublicKeyPreserved: %v", err)
+	}
+	return nil
+}
+
+// VerifyReshareResult checks the save data output to the members of the new committee by a resharing against the
+// group public key `oldPub` held before it, e.g. for an audit trail. The public shares of the new committee are the
+// sum of the new VSS commitments evaluated at each party key; they must be the same in every save data, lie on one
+// polynomial of the degree t of the new threshold in the exponent, so that any t+1 of them interpolate to `oldPub`,
+// and match the secret share of each save data that holds one.
+func VerifyReshareResult(oldPub *crypto.ECPoint, newKeys []keygen.LocalPartySaveData) error {
+	if oldPub == nil {
+		return errors.New("VerifyReshareResult: the old public key is missing")
+	}
+	if len(newKeys) == 0 {
+		return errors.New("VerifyReshareResult: there is no save data to verify")
+	}
+	first := newKeys[0]
+	for i, key := range newKeys {
+		if key.ECDSAPub == nil || !key.ECDSAPub.Equals(oldPub) {
+			return fmt.Errorf("VerifyReshareResult: the public key of save data %d differs from the old public key", i)
+		}
+		if !hasPublicShares(key) {
+			return fmt.Errorf("VerifyReshareResult: save data %d holds no public shares", i)
+		}
+		if len(key.Ks) != len(first.Ks) || len(key.BigXj) != len(first.BigXj) {
+			return fmt.Errorf("VerifyReshareResult: save data %d has another party count than save data 0", i)
+		}
+		for j := range key.BigXj {
+			if key.Ks[j] == nil || key.BigXj[j] == nil || first.Ks[j] == nil || first.BigXj[j] == nil ||
+				key.Ks[j].Cmp(first.Ks[j]) != 0 || !key.BigXj[j].Equals(first.BigXj[j]) {
+				return fmt.Errorf("VerifyReshareResult: save data %d and 0 disagree on the public share of party index %d", i, j)
+			}
+		}
+		if err := verifyPublicShares(oldPub, key); err != nil {
+			return fmt.Errorf("VerifyReshareResult: save data %d: %v", i, err)
+		}
+	}
+	// the public shares must also be of the degree of the new threshold, so that t+1 parties are enough to sign
+	t := first.Threshold()
+	if t < 0 || len(first.Ks) <= t {
+		return fmt.Errorf("VerifyReshareResult: the threshold %d does not suit %d parties", t, len(first.Ks))
+	}
+	y, err := vss.InterpolatePublicShares(first.Ks[:t+1], first.BigXj[:t+1])
+	if err != nil {
+		return fmt.Errorf("VerifyReshareResult: %v", err)
+	}
+	if !y.Equals(oldPub) {
+		return fmt.Errorf("VerifyReshareResult: the first t+1 public shares do not interpolate to the public key (t=%d)", t)
+	}
+	// every other public share must lie on the polynomial through the first t+1
+	for j := t + 1; j < len(first.Ks); j++ {
+		BigXj, err := interpolatePublicSharesAt(first.Ks[:t+1], first.BigXj[:t+1], first.Ks[j])
+		if err != nil {
+			return fmt.Errorf("VerifyReshareResult: %v", err)
+		}
+		if !BigXj.Equals(first.BigXj[j]) {
+			return fmt.Errorf("VerifyReshareResult: the public share of party index %d is not on the polynomial of the first t+1 (t=%d)", j, t)
+		}
+	}
+	return nil
+}
+
+// interpolatePublicSharesAt returns the value at `at` of the polynomial through the public shares in the exponent.
+// It is their interpolation at 0 with the share IDs shifted by -`at`.
+func interpolatePublicSharesAt(ks []*big.Int, bigXs []*crypto.ECPoint, at *big.Int) (*crypto.ECPoint, error) {
+	modN := common.ModInt(bigXs[0].Curve().Params().N)
+	shifted := make([]*big.Int, len(ks))
+	for i, k := range ks {
+		shifted[i] = modN.Sub(k, at)
+	}
+	return vss.InterpolatePublicShares(shifted, bigXs)
+}
+
+// verifyPublicShares checks that the public shares of `key` interpolate to `pub` in the exponent, and that its secret
+// share matches its public share if it holds one.
+func verifyPublicShares(pub *crypto.ECPoint, key keygen.LocalPartySaveData) error {
+	y, err := vss.InterpolatePublicShares(key.Ks, key.BigXj)
+	if err != nil {
+		return err
+	}
+	if !y.Equals(pub) {
+		return errors.New("the new public shares do not interpolate to the public key")
+	}
+	if key.Xi == nil || key.Xi.Sign() == 0 {
+		return nil
+	}
+	for j, kj := range key.Ks {
+		if key.ShareID != nil && kj.Cmp(key.ShareID) == 0 {
+			if !crypto.ScalarBaseMult(pub.Curve(), key.Xi).Equals(key.BigXj[j]) {
+				return errors.New("the secret share does not match its public share")
+			}
+			return nil
+		}
+	}
+	return errors.New("the share ID was not found in the save data")
+}
+
+func hasPublicShares(key keygen.LocalPartySaveData) bool {
+	for _, BigXj := range key.BigXj {
+		if BigXj != nil {
+			return true
+		}
+	}
+	return false
+}
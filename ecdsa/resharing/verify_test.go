@@ -0,0 +1,73 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package resharing_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	. "github.com/bnb-chain/tss-lib/v2/ecdsa/resharing"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestVerifyReshareResultPolynomialDegree(t *testing.T) {
+	ec := tss.S256()
+	q := ec.Params().N
+	threshold, n := 1, 5
+	secret := common.GetRandomPositiveInt(rand.Reader, q)
+	pub := crypto.ScalarBaseMult(ec, secret)
+	ks := tss.GenerateTestPartyIDs(n).Keys()
+	_, shares, err := vss.Create(ec, threshold, secret, ks, rand.Reader)
+	if !assert.NoError(t, err) {
+		return
+	}
+	BigXj := make([]*crypto.ECPoint, n)
+	for j, share := range shares {
+		BigXj[j] = crypto.ScalarBaseMult(ec, share.Share)
+	}
+	newKeys := func(BigXj []*crypto.ECPoint) []keygen.LocalPartySaveData {
+		keys := make([]keygen.LocalPartySaveData, n)
+		for j := range keys {
+			keys[j] = keygen.NewLocalPartySaveData(n)
+			keys[j].ECDSAPub, keys[j].KeyThreshold = pub, threshold
+			copy(keys[j].Ks, ks)
+			copy(keys[j].BigXj, BigXj)
+		}
+		return keys
+	}
+	assert.NoError(t, VerifyReshareResult(pub, newKeys(BigXj)))
+
+	// adding c*z*(z-k_0)*...*(z-k_t) to the polynomial keeps the first t+1 public shares and the value at 0, so
+	// that all n shares still interpolate to the public key, but the polynomial is no longer of degree t
+	modQ := common.ModInt(q)
+	c := common.GetRandomPositiveInt(rand.Reader, q)
+	badXj := append([]*crypto.ECPoint{}, BigXj...)
+	for j := threshold + 1; j < n; j++ {
+		extra := modQ.Mul(c, ks[j])
+		for i := 0; i <= threshold; i++ {
+			extra = modQ.Mul(extra, modQ.Sub(ks[j], ks[i]))
+		}
+		badXj[j], err = badXj[j].Add(crypto.ScalarBaseMult(ec, extra))
+		if !assert.NoError(t, err) {
+			return
+		}
+	}
+	y, err := vss.InterpolatePublicShares(ks, badXj)
+	if assert.NoError(t, err) {
+		assert.True(t, y.Equals(pub), "all the shares should still interpolate to the public key")
+	}
+	err = VerifyReshareResult(pub, newKeys(badXj))
+	if assert.Error(t, err, "shares of a higher degree should be detected") {
+		assert.Contains(t, err.Error(), "is not on the polynomial")
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/mta"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	checkpointVersion = 1
+	checkpointDomain  = "tss-lib ecdsa signing checkpoint"
+
+	// the first round that can be checkpointed; sigma_i and s_i are fixed once it has started
+	checkpointFirstRound = 5
+)
+
+// checkpointState holds everything a signing party needs to continue from the round it was in.
+// The mta proofs of round 2 are not kept as they are only needed to build messages that have already been sent.
+type checkpointState struct {
+	tss.Checkpoint
+
+	W, M, K, Theta, ThetaInverse, Sigma, KeyDerivationDelta, Gamma *big.Int
+	FullBytesLen                                                   int
+	Cis                                                            []*big.Int
+	BigWs                                                          []*crypto.ECPoint
+	PointGamma                                                     *crypto.ECPoint
+	DeCommit                                                       cmt.HashDeCommitment
+
+	// negated so that the checkpoints taken before the signing options resume with low S and the recovery id
+	NoLowS, NoRecoveryID bool
+
+	Betas, C1jis, C2jis, Vs []*big.Int
+
+	Li, Si, Rx, Ry, Roi *big.Int
+	BigR, BigAi, BigVi  *crypto.ECPoint
+	DPower              cmt.HashDeCommitment
+
+	Ui, Ti *crypto.ECPoint
+	DTelda cmt.HashDeCommitment
+	BigVjs []*crypto.ECPoint
+
+	SSIDNonce *big.Int
+	SSID      []byte
+}
+
+// Checkpoint captures the state of a running signing party so that it can be continued later with ResumeFromCheckpoint,
+// e.g. after a process restart or after moving the messages of a round across an air gap. The format is versioned.
+// The checkpoint contains the party's nonces and is sealed with a key derived from the party's secret share,
+// so it can only be opened by a holder of the same key share.
+//
+// A checkpoint can only be taken from round 5 on. Before that, the MtA shares and sigma_i are not fixed yet: a copy of
+// the party resumed from an earlier checkpoint could be answered with other MtA and delta responses by a malicious
+// co-signer, which would then get two s_i for the same k_i and could solve for k_i, sigma_i and the secret share w_i.
+// From round 5 on, a resumed party only repeats the s_i that it has already committed to.
+// Each checkpoint can still be resumed only once, see tss.CheckpointLedger.
+func (p *LocalParty) Checkpoint() ([]byte, error) {
+	return tss.BaseSnapshot(p, func(round tss.Round, run []byte) ([]byte, error) {
+		if _, ok := round.(*finalization); ok {
+			return nil, errors.New("could not checkpoint. signing is finalizing")
+		}
+		rnd, ok := round.(interface{ baseRound() *base })
+		if !ok || !rnd.baseRound().started {
+			return nil, errors.New("could not checkpoint. the current round has not started")
+		}
+		b := rnd.baseRound()
+		if b.number < checkpointFirstRound {
+			return nil, fmt.Errorf("could not checkpoint. signing can only be checkpointed from round %d, it is in round %d",
+				checkpointFirstRound, b.number)
+		}
+		shared, err := tss.NewCheckpoint(p.params, run, b.number, b.ok, p.temp.localMessageStore.all()...)
+		if err != nil {
+			return nil, err
+		}
+		state := &checkpointState{
+			Checkpoint:         shared,
+			W:                  p.temp.w,
+			M:                  p.temp.m,
+			K:                  p.temp.k,
+			Theta:              p.temp.theta,
+			ThetaInverse:       p.temp.thetaInverse,
+			Sigma:              p.temp.sigma,
+			KeyDerivationDelta: p.temp.keyDerivationDelta,
+			Gamma:              p.temp.gamma,
+			FullBytesLen:       p.temp.fullBytesLen,
+			NoLowS:             !p.temp.lowS,
+			NoRecoveryID:       !p.temp.recoveryID,
+			Cis:                p.temp.cis,
+			BigWs:              p.temp.bigWs,
+			PointGamma:         p.temp.pointGamma,
+			DeCommit:           p.temp.deCommit,
+			Betas:              p.temp.betas,
+			C1jis:              p.temp.c1jis,
+			C2jis:              p.temp.c2jis,
+			Vs:                 p.temp.vs,
+			Li:                 p.temp.li,
+			Si:                 p.temp.si,
+			Rx:                 p.temp.rx,
+			Ry:                 p.temp.ry,
+			Roi:                p.temp.roi,
+			BigR:               p.temp.bigR,
+			BigAi:              p.temp.bigAi,
+			BigVi:              p.temp.bigVi,
+			DPower:             p.temp.DPower,
+			Ui:                 p.temp.Ui,
+			Ti:                 p.temp.Ti,
+			DTelda:             p.temp.DTelda,
+			BigVjs:             p.temp.bigVjs,
+			SSIDNonce:          p.temp.ssidNonce,
+			SSID:               p.temp.ssid,
+		}
+		plain, err := json.Marshal(state)
+		if err != nil {
+			return nil, err
+		}
+		// the party's own share has the key derivation delta added to it in round 1; seal with the original share
+		xi := p.keys.Xi
+		if p.temp.keyDerivationDelta != nil {
+			xi = common.ModInt(p.params.EC().Params().N).Sub(xi, p.temp.keyDerivationDelta)
+		}
+		return sealCheckpoint(p.params.Rand(), xi, p.keys.ShareID, plain)
+	})
+}
+
+// ResumeFromCheckpoint restores a signing party from a checkpoint taken with LocalParty.Checkpoint.
+// `params` and `key` must be the same as those given to the party that took the checkpoint.
+// The resume is recorded in `ledger`, which refuses to resume the checkpoint, or one taken before it, a second time.
+// The returned party is already running in the checkpointed round and must not be started again;
+// feed it the remaining messages for that round with Update.
+func ResumeFromCheckpoint(
+	state []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	ledger tss.CheckpointLedger,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) (tss.Party, error) {
+	plain, err := openCheckpoint(key.Xi, key.ShareID, state)
+	if err != nil {
+		return nil, err
+	}
+	cp := new(checkpointState)
+	if err = json.Unmarshal(plain, cp); err != nil {
+		return nil, fmt.Errorf("checkpoint could not be decoded: %v", err)
+	}
+	if err = cp.Check(params, checkpointFirstRound, 9); err != nil {
+		return nil, err
+	}
+	partyCount := len(params.Parties().IDs())
+	if len(cp.Cis) != partyCount || len(cp.BigWs) != partyCount ||
+		len(cp.Betas) != partyCount || len(cp.C1jis) != partyCount || len(cp.C2jis) != partyCount || len(cp.Vs) != partyCount {
+		return nil, errors.New("checkpoint is inconsistent with the number of parties")
+	}
+	if cp.M == nil || cp.K == nil || cp.Gamma == nil || cp.W == nil || cp.SSIDNonce == nil {
+		return nil, errors.New("checkpoint is missing round 1 data")
+	}
+
+	opts := []Option{WithKeyDerivationDelta(cp.KeyDerivationDelta), WithFullBytesLen(cp.FullBytesLen)}
+	if !cp.NoLowS {
+		opts = append(opts, WithLowS())
+	}
+	if !cp.NoRecoveryID {
+		opts = append(opts, WithRecoveryID())
+	}
+	p := NewLocalPartyWithOptions(cp.M, params, key, out, end, opts...).(*LocalParty)
+	if cp.KeyDerivationDelta != nil {
+		p.keys.Xi = common.ModInt(params.EC().Params().N).Add(cp.KeyDerivationDelta, p.keys.Xi)
+	}
+	p.temp.w, p.temp.k, p.temp.gamma = cp.W, cp.K, cp.Gamma
+	p.temp.theta, p.temp.thetaInverse, p.temp.sigma = cp.Theta, cp.ThetaInverse, cp.Sigma
+	p.temp.cis, p.temp.bigWs, p.temp.pointGamma, p.temp.deCommit = cp.Cis, cp.BigWs, cp.PointGamma, cp.DeCommit
+	p.temp.betas, p.temp.c1jis, p.temp.c2jis, p.temp.vs = cp.Betas, cp.C1jis, cp.C2jis, cp.Vs
+	p.temp.pi1jis = make([]*mta.ProofBob, partyCount)
+	p.temp.pi2jis = make([]*mta.ProofBobWC, partyCount)
+	p.temp.li, p.temp.si, p.temp.rx, p.temp.ry, p.temp.roi = cp.Li, cp.Si, cp.Rx, cp.Ry, cp.Roi
+	p.temp.bigR, p.temp.bigAi, p.temp.bigVi, p.temp.DPower = cp.BigR, cp.BigAi, cp.BigVi, cp.DPower
+	p.temp.Ui, p.temp.Ti, p.temp.DTelda, p.temp.bigVjs = cp.Ui, cp.Ti, cp.DTelda, cp.BigVjs
+	p.temp.ssidNonce, p.temp.ssid = cp.SSIDNonce, cp.SSID
+
+	// the ssid binds the checkpoint to this key and this set of parties
+	round := p.FirstRound()
+	ssid, err := round.(*round1).getSSID()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(ssid, cp.SSID) {
+		return nil, errors.New("checkpoint does not match the given key data")
+	}
+	for n := 1; n < cp.Round; n++ {
+		round = round.NextRound()
+	}
+	b := round.(interface{ baseRound() *base }).baseRound()
+	b.number, b.started, b.ok = cp.Round, true, cp.OK
+
+	if err = cp.Resume(p, TaskName, round, ledger, p.StoreMessage); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ----- //
+
+func (store *localMessageStore) all() [][]tss.ParsedMessage {
+	return [][]tss.ParsedMessage{
+		store.signRound1Message1s,
+		store.signRound1Message2s,
+		store.signRound2Messages,
+		store.signRound3Messages,
+		store.signRound4Messages,
+		store.signRound5Messages,
+		store.signRound6Messages,
+		store.signRound7Messages,
+		store.signRound8Messages,
+		store.signRound9Messages,
+	}
+}
+
+// checkpointKey derives the key that seals the checkpoints of a party from its secret share
+func checkpointKey(xi, shareID *big.Int) ([]byte, error) {
+	if xi == nil || shareID == nil {
+		return nil, errors.New("key data is missing the secret share")
+	}
+	return common.SHA512_256([]byte(checkpointDomain), xi.Bytes(), shareID.Bytes()), nil
+}
+
+func sealCheckpoint(rand io.Reader, xi, shareID *big.Int, plain []byte) ([]byte, error) {
+	key, err := checkpointKey(xi, shareID)
+	if err != nil {
+		return nil, err
+	}
+	return tss.SealSnapshot(rand, key, checkpointVersion, plain)
+}
+
+func openCheckpoint(xi, shareID *big.Int, state []byte) ([]byte, error) {
+	key, err := checkpointKey(xi, shareID)
+	if err != nil {
+		return nil, err
+	}
+	return tss.OpenSnapshot(key, checkpointVersion, state)
+}
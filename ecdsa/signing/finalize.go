@@ -13,6 +13,7 @@ import (
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
@@ -37,9 +38,11 @@ func (round *finalization) Start() *tss.Error {
 	}
 
 	recid := 0
-	// byte v = if(R.X > curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
-	if round.temp.rx.Cmp(round.Params().EC().Params().N) > 0 {
+	// byte v = if(R.X >= curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
+	r := round.temp.rx
+	if r.Cmp(round.Params().EC().Params().N) >= 0 {
 		recid = 2
+		r = new(big.Int).Sub(r, round.Params().EC().Params().N)
 	}
 	if round.temp.ry.Bit(0) != 0 {
 		recid |= 1
@@ -50,17 +53,19 @@ func (round *finalization) Start() *tss.Error {
 	// This is needed because of tendermint checks here:
 	// https://github.com/tendermint/tendermint/blob/d9481e3648450cb99e15c6a070c1fb69aa0c255b/crypto/secp256k1/secp256k1_nocgo.go#L43-L47
 	secp256k1halfN := new(big.Int).Rsh(round.Params().EC().Params().N, 1)
-	if sumS.Cmp(secp256k1halfN) > 0 {
+	if round.temp.lowS && sumS.Cmp(secp256k1halfN) > 0 {
 		sumS.Sub(round.Params().EC().Params().N, sumS)
 		recid ^= 1
 	}
 
 	// save the signature for final output
-	bitSizeInBytes := round.Params().EC().Params().BitSize / 8
-	round.data.R = padToLengthBytesInPlace(round.temp.rx.Bytes(), bitSizeInBytes)
+	bitSizeInBytes := (round.Params().EC().Params().BitSize + 7) / 8
+	round.data.R = padToLengthBytesInPlace(r.Bytes(), bitSizeInBytes)
 	round.data.S = padToLengthBytesInPlace(sumS.Bytes(), bitSizeInBytes)
 	round.data.Signature = append(round.data.R, round.data.S...)
-	round.data.SignatureRecovery = []byte{byte(recid)}
+	if round.temp.recoveryID {
+		round.data.SignatureRecovery = []byte{byte(recid)}
+	}
 	if round.temp.fullBytesLen == 0 {
 		round.data.M = round.temp.m.Bytes()
 	} else {
@@ -75,9 +80,9 @@ func (round *finalization) Start() *tss.Error {
 		Y:     round.key.ECDSAPub.Y(),
 	}
 
-	ok := ecdsa.Verify(&pk, round.data.M, round.temp.rx, sumS)
+	ok := ecdsa.Verify(&pk, round.data.M, r, sumS)
 	if !ok {
-		return round.WrapError(fmt.Errorf("signature verification failed"))
+		return round.WrapError(fmt.Errorf("signature verification failed"), round.sShareCulprits()...)
 	}
 
 	round.end <- round.data
@@ -85,6 +90,33 @@ func (round *finalization) Start() *tss.Error {
 	return nil
 }
 
+// sShareCulprits returns the parties whose s_j does not match the V_j = R^s_j * g^l_j they committed to in round 5.
+// The check U = T of round 9 ensures that the committed shares sum to a valid signature, so when the signature does not
+// verify some party has revealed an s_j other than the one it committed to. The parties that did not reveal l_j, as
+// older versions do not, cannot be checked and are not blamed.
+func (round *finalization) sShareCulprits() []*tss.PartyID {
+	if len(round.temp.bigVjs) != len(round.Parties().IDs()) {
+		return nil // resumed from a checkpoint taken before this data was kept
+	}
+	ec := round.Params().EC()
+	culprits := make([]*tss.PartyID, 0)
+	for j, Pj := range round.Parties().IDs() {
+		if j == round.PartyID().Index {
+			continue
+		}
+		r9msg := round.temp.signRound9Messages[j].Content().(*SignRound9Message)
+		if !r9msg.HasL() {
+			continue
+		}
+		rToSj := round.temp.bigR.ScalarMult(r9msg.UnmarshalS())
+		bigVj, err := rToSj.Add(crypto.ScalarBaseMult(ec, r9msg.UnmarshalL()))
+		if err != nil || !bigVj.Equals(round.temp.bigVjs[j]) {
+			culprits = append(culprits, Pj)
+		}
+	}
+	return culprits
+}
+
 func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
 	// not expecting any incoming messages in this round
 	return false
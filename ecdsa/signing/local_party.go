@@ -7,6 +7,7 @@
 package signing
 
 import (
+	"crypto/elliptic"
 	"errors"
 	"fmt"
 	"math/big"
@@ -66,10 +67,12 @@ type (
 		keyDerivationDelta,
 		gamma *big.Int
 		fullBytesLen int
-		cis          []*big.Int
-		bigWs        []*crypto.ECPoint
-		pointGamma   *crypto.ECPoint
-		deCommit     cmt.HashDeCommitment
+		lowS,
+		recoveryID bool
+		cis        []*big.Int
+		bigWs      []*crypto.ECPoint
+		pointGamma *crypto.ECPoint
+		deCommit   cmt.HashDeCommitment
 
 		// round 2
 		betas, // return value of Bob_mid
@@ -94,12 +97,18 @@ type (
 		Ui,
 		Ti *crypto.ECPoint
 		DTelda cmt.HashDeCommitment
+		bigVjs []*crypto.ECPoint
 
 		ssidNonce *big.Int
 		ssid      []byte
 	}
 )
 
+// NewLocalParty returns a party that signs `msg`, the message hash as an integer in [1, N-1]; Start fails otherwise.
+// The signature is normalized to a low S (S <= N/2), as required by Bitcoin and Ethereum, and comes with its recovery
+// id, i.e. it is NewLocalPartyWithOptions with WithLowS and WithRecoveryID.
+// The signers are the parties of `params`: Start fails if they are fewer than t+1, and the signing waits for every one
+// of them, so use Parameters.SetRoundTimeout to learn which signers have not sent their messages.
 func NewLocalParty(
 	msg *big.Int,
 	params *tss.Parameters,
@@ -110,6 +119,42 @@ func NewLocalParty(
 	return NewLocalPartyWithKDD(msg, params, key, nil, out, end, fullBytesLen...)
 }
 
+// NewLocalPartyFromMessage returns a party that signs `rawMsg` hashed with `hash`, e.g. common.HashBitcoin or
+// common.HashEthereum, so that callers do not have to pre-hash and reduce the message themselves.
+// The digest is converted to an integer as in SEC 1 (4.1.3): it is truncated to the bit length of the curve order,
+// then reduced modulo the order.
+func NewLocalPartyFromMessage(
+	rawMsg []byte,
+	hash common.MessageHash,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) (tss.Party, error) {
+	if hash.IsNone() {
+		return nil, errors.New("ECDSA signing requires the message to be hashed")
+	}
+	return NewLocalPartyFromDigest(hash.Digest(rawMsg), params, key, out, end)
+}
+
+// NewLocalPartyFromDigest returns a party that signs `digest`, a message hashed by the caller, e.g. the 32-byte
+// sighash of a Bitcoin transaction. Unlike new(big.Int).SetBytes(digest) given to NewLocalParty, it keeps the leading
+// zero bytes of the digest, so that SignatureData.M is the full digest that the signature verifies against.
+// The digest is converted to an integer as in NewLocalPartyFromMessage.
+func NewLocalPartyFromDigest(
+	digest []byte,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) (tss.Party, error) {
+	if len(digest) == 0 {
+		return nil, errors.New("the digest to sign is empty")
+	}
+	m, mLen := hashToInt(digest, params.EC())
+	return NewLocalParty(m, params, key, out, end, mLen), nil
+}
+
 // NewLocalPartyWithKDD returns a party with key derivation delta for HD support
 func NewLocalPartyWithKDD(
 	msg *big.Int,
@@ -119,10 +164,64 @@ func NewLocalPartyWithKDD(
 	out chan<- tss.Message,
 	end chan<- *common.SignatureData,
 	fullBytesLen ...int,
+) tss.Party {
+	opts := []Option{WithKeyDerivationDelta(keyDerivationDelta), WithLowS(), WithRecoveryID()}
+	if len(fullBytesLen) > 0 {
+		opts = append(opts, WithFullBytesLen(fullBytesLen[0]))
+	}
+	return NewLocalPartyWithOptions(msg, params, key, out, end, opts...)
+}
+
+// ----- //
+
+// Option configures a party created with NewLocalPartyWithOptions.
+type Option func(*localTempData)
+
+// WithKeyDerivationDelta signs with the child key derived with the delta `keyDerivationDelta`, see
+// UpdatePublicKeyAndAdjustBigXj. A nil delta signs with the key itself.
+func WithKeyDerivationDelta(keyDerivationDelta *big.Int) Option {
+	return func(temp *localTempData) {
+		temp.keyDerivationDelta = keyDerivationDelta
+	}
+}
+
+// WithFullBytesLen sets the length of SignatureData.M, so that a message hash with leading zeros is returned with
+// them. By default M is the minimal big-endian encoding of the message.
+func WithFullBytesLen(fullBytesLen int) Option {
+	return func(temp *localTempData) {
+		temp.fullBytesLen = fullBytesLen
+	}
+}
+
+// WithLowS normalizes the signature to a low S (S <= N/2), as required by Bitcoin (BIP 62) and Ethereum (EIP-2).
+// Without it, S is returned as computed and may be either of S and N-S.
+func WithLowS() Option {
+	return func(temp *localTempData) {
+		temp.lowS = true
+	}
+}
+
+// WithRecoveryID sets SignatureData.SignatureRecovery, the recovery id of the public key that Ethereum signatures
+// carry, see SignatureData.CompactRecoverable. Without it, SignatureRecovery is left empty.
+func WithRecoveryID() Option {
+	return func(temp *localTempData) {
+		temp.recoveryID = true
+	}
+}
+
+// NewLocalPartyWithOptions returns a party that signs `msg`, the message hash as an integer, configured with `opts`.
+// Unlike NewLocalParty, it returns the signature as computed unless WithLowS and WithRecoveryID are given.
+func NewLocalPartyWithOptions(
+	msg *big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	opts ...Option,
 ) tss.Party {
 	partyCount := len(params.Parties().IDs())
 	p := &LocalParty{
-		BaseParty: new(tss.BaseParty),
+		BaseParty: tss.NewBaseParty(func(tss.ParsedMessage) int { return partyCount }),
 		params:    params,
 		keys:      keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
 		temp:      localTempData{},
@@ -142,13 +241,10 @@ func NewLocalPartyWithKDD(
 	p.temp.signRound8Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound9Messages = make([]tss.ParsedMessage, partyCount)
 	// temp data init
-	p.temp.keyDerivationDelta = keyDerivationDelta
-	p.temp.m = msg
-	if len(fullBytesLen) > 0 {
-		p.temp.fullBytesLen = fullBytesLen[0]
-	} else {
-		p.temp.fullBytesLen = 0
+	for _, opt := range opts {
+		opt(&p.temp)
 	}
+	p.temp.m = msg
 	p.temp.cis = make([]*big.Int, partyCount)
 	p.temp.bigWs = make([]*crypto.ECPoint, partyCount)
 	p.temp.betas = make([]*big.Int, partyCount)
@@ -189,18 +285,6 @@ func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroa
 	return p.Update(msg)
 }
 
-func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
-	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
-		return ok, err
-	}
-	// check that the message's "from index" will fit into the array
-	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
-		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
-			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
-	}
-	return true, nil
-}
-
 func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
 	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
@@ -209,7 +293,7 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	fromPIdx := msg.GetFrom().Index
 
 	// switch/case is necessary to store any messages beyond current round
-	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	// replays are only rejected with Parameters.EnableReplayProtection. we expect the caller to apply spoofing protection.
 	switch msg.Content().(type) {
 	case *SignRound1Message1:
 		p.temp.signRound1Message1s[fromPIdx] = msg
@@ -232,7 +316,7 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	case *SignRound9Message:
 		p.temp.signRound9Messages[fromPIdx] = msg
 	default: // unrecognised message, just ignore!
-		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		p.params.Logger().Warningf("unrecognised message ignored: %v", msg)
 		return false, nil
 	}
 	return true, nil
@@ -245,3 +329,16 @@ func (p *LocalParty) PartyID() *tss.PartyID {
 func (p *LocalParty) String() string {
 	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
 }
+
+// hashToInt converts a message digest to an integer modulo the curve order, returning it with its length in bytes
+func hashToInt(digest []byte, ec elliptic.Curve) (*big.Int, int) {
+	N := ec.Params().N
+	if orderBytes := (N.BitLen() + 7) / 8; len(digest) > orderBytes {
+		digest = digest[:orderBytes]
+	}
+	m := new(big.Int).SetBytes(digest)
+	if excess := len(digest)*8 - N.BitLen(); excess > 0 {
+		m.Rsh(m, uint(excess))
+	}
+	return m.Mod(m, N), len(digest)
+}
@@ -7,20 +7,33 @@
 package signing
 
 import (
+	"bytes"
+	"crypto"
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/hex"
 	"fmt"
 	"math/big"
 	"runtime"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
 	"github.com/ipfs/go-log"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	crypto2 "github.com/bnb-chain/tss-lib/v2/crypto"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
 	"github.com/bnb-chain/tss-lib/v2/test"
 	"github.com/bnb-chain/tss-lib/v2/tss"
@@ -95,7 +108,7 @@ signing:
 				go updater(parties[dest[0].Index], msg, errCh)
 			}
 
-		case <-endCh:
+		case data := <-endCh:
 			atomic.AddInt32(&ended, 1)
 			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
 				t.Logf("Done. Received signature data from %d participants", ended)
@@ -103,6 +116,18 @@ signing:
 				r := parties[0].temp.rx
 				fmt.Printf("sign result: R(%s, %s), r=%s\n", R.X().String(), R.Y().String(), r.String())
 
+				// BEGIN public key recovery
+				halfN := new(big.Int).Rsh(tss.S256().Params().N, 1)
+				assert.True(t, new(big.Int).SetBytes(data.S).Cmp(halfN) <= 0, "S should be normalized to low S")
+				ethSig := data.EthereumSignature()
+				assert.Len(t, ethSig, 65)
+				compact := append([]byte{27 + ethSig[64]}, ethSig[:64]...)
+				recovered, _, err := btcecdsa.RecoverCompact(compact, data.M)
+				assert.NoError(t, err)
+				assert.Equal(t, keys[0].ECDSAPub.X(), recovered.X(), "the recovered public key should match")
+				assert.Equal(t, keys[0].ECDSAPub.Y(), recovered.Y(), "the recovered public key should match")
+				// END public key recovery
+
 				modN := common.ModInt(tss.S256().Params().N)
 
 				// BEGIN check s correctness
@@ -113,6 +138,13 @@ signing:
 				fmt.Printf("S: %s\n", sumS.String())
 				// END check s correctness
 
+				// the secrets of each party were wiped after use
+				for _, p := range parties {
+					for _, secret := range []*big.Int{p.temp.w, p.temp.k, p.temp.gamma, p.temp.sigma, p.temp.roi} {
+						assert.Zero(t, secret.Sign())
+					}
+				}
+
 				// BEGIN ECDSA verify
 				pkX, pkY := keys[0].ECDSAPub.X(), keys[0].ECDSAPub.Y()
 				pk := ecdsa.PublicKey{
@@ -334,6 +366,759 @@ signing:
 	}
 }
 
+func TestE2ECheckpointResume(t *testing.T) {
+	setUp("info")
+	// checkpoint once every party has reached round 5, and in the last round before finalization
+	for _, atMsgType := range []string{
+		"binance.tsslib.ecdsa.signing.SignRound6Message",
+		"binance.tsslib.ecdsa.signing.SignRound9Message",
+	} {
+		t.Run(atMsgType, func(t *testing.T) {
+			testE2ECheckpointResumeAt(t, atMsgType)
+		})
+	}
+}
+
+func TestCheckpointBeforeRound5(t *testing.T) {
+	setUp("info")
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+	P := NewLocalParty(big.NewInt(42), params, keys[0], make(chan tss.Message, len(signPIDs)), nil).(*LocalParty)
+	if err := P.Start(); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	_, err = P.Checkpoint()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "can only be checkpointed from round 5")
+	}
+}
+
+func testE2ECheckpointResumeAt(t *testing.T, atMsgType string) {
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: signing
+	// messages are delivered one at a time so that every party can be checkpointed at the same point
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+	paramsList := make([]*tss.Parameters, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs)*len(signPIDs))
+	outCh := make(chan tss.Message, 1000)
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		paramsList = append(paramsList, params)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	ledger := tss.NewMemoryCheckpointLedger()
+	resumed := false
+	ended := 0
+	var sig *common.SignatureData
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			if msg.Type() == atMsgType && !resumed {
+				resumed = true
+				for i, P := range parties {
+					state, err := P.Checkpoint()
+					assert.NoError(t, err, "should checkpoint")
+
+					// another party's key share must not open the checkpoint
+					_, err = ResumeFromCheckpoint(state, paramsList[i], keys[(i+1)%len(keys)], ledger, outCh, endCh)
+					assert.Error(t, err)
+					tampered := append([]byte{}, state...)
+					tampered[len(tampered)-1] ^= 0xff
+					_, err = ResumeFromCheckpoint(tampered, paramsList[i], keys[i], ledger, outCh, endCh)
+					assert.Error(t, err)
+
+					rP, err := ResumeFromCheckpoint(state, paramsList[i], keys[i], ledger, outCh, endCh)
+					assert.NoError(t, err, "should resume")
+					assert.Equal(t, P.String(), rP.String())
+					assert.Error(t, rP.Start(), "a resumed party must not start again")
+					parties[i] = rP.(*LocalParty)
+
+					// the checkpoint is consumed, and so is any other checkpoint of the party from the same round
+					_, err = ResumeFromCheckpoint(state, paramsList[i], keys[i], ledger, outCh, endCh)
+					assert.Error(t, err, "a checkpoint must not be resumed twice")
+					again, err := P.Checkpoint()
+					assert.NoError(t, err)
+					_, err = ResumeFromCheckpoint(again, paramsList[i], keys[i], ledger, outCh, endCh)
+					assert.Error(t, err, "a checkpoint of a consumed round must not be resumed")
+				}
+			}
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					updater(P, msg, errCh)
+				}
+			} else {
+				updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case sig = <-endCh:
+			if ended++; ended == len(signPIDs) {
+				break signing
+			}
+		}
+	}
+	assert.True(t, resumed, "parties should have been resumed from a checkpoint")
+	pk := ecdsa.PublicKey{
+		Curve: tss.EC(),
+		X:     keys[0].ECDSAPub.X(),
+		Y:     keys[0].ECDSAPub.Y(),
+	}
+	ok := ecdsa.Verify(&pk, big.NewInt(42).Bytes(), new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S))
+	assert.True(t, ok, "ecdsa verify must pass")
+}
+
+// BenchmarkE2ESkipProofs attributes the signing latency to each proof type by skipping its verification.
+func BenchmarkE2ESkipProofs(b *testing.B) {
+	setUp("error")
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	if err != nil {
+		b.Fatal(err)
+	}
+	cases := []struct {
+		name  string
+		skips tss.InsecureProofSkips
+	}{
+		{"none", 0},
+		{"range-proof-alice", tss.InsecureSkipRangeProofAlice},
+		{"proof-bob", tss.InsecureSkipProofBob},
+		{"schnorr", tss.InsecureSkipSchnorrProofs},
+		{"all", tss.InsecureSkipRangeProofAlice | tss.InsecureSkipProofBob | tss.InsecureSkipSchnorrProofs},
+	}
+	for _, c := range cases {
+		b.Run(c.name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				if err := signWithSkippedProofs(keys, signPIDs, c.skips); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func signWithSkippedProofs(keys []keygen.LocalPartySaveData, signPIDs tss.SortedPartyIDs, skips tss.InsecureProofSkips) error {
+	_, err := signSequentially(keys, signPIDs, big.NewInt(42), func(params *tss.Parameters) {
+		params.SetInsecureSkipProofsForBenchmarkOnly(skips)
+	})
+	return err
+}
+
+// BenchmarkRound2 measures the construction of the round 2 MtA messages of one party in a signing by 20 parties, with
+// the proofs generated one at a time and with Concurrency() at a time.
+func BenchmarkRound2(b *testing.B) {
+	setUp("error")
+	const partyCount = 20
+	fixtures, _, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	if err != nil {
+		b.Fatal(err)
+	}
+	pIDs := tss.GenerateTestPartyIDs(partyCount)
+	keys := syntheticKeys(fixtures, pIDs)
+
+	// run round 1 of every party and store the round 1 messages addressed to the first party
+	p2pCtx := tss.NewPeerContext(pIDs)
+	outCh := make(chan tss.Message, partyCount*partyCount)
+	endCh := make(chan *common.SignatureData, partyCount)
+	var P *LocalParty
+	for i, Pi := range pIDs {
+		params := tss.NewParameters(tss.S256(), p2pCtx, Pi, partyCount, testThreshold)
+		party := NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh).(*LocalParty)
+		if err := party.Start(); err != nil {
+			b.Fatal(err)
+		}
+		if i == 0 {
+			P = party
+		}
+	}
+	for len(outCh) > 0 {
+		msg := <-outCh
+		if dest := msg.GetTo(); dest == nil || dest[0].Index != 0 {
+			continue
+		}
+		pMsg, err := tss.ParseWireMessage(wireBytesOf(b, msg), msg.GetFrom(), msg.IsBroadcast())
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := P.StoreMessage(pMsg); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	for _, c := range []struct {
+		name        string
+		concurrency int
+	}{
+		{"serial", 1},
+		{"parallel", runtime.NumCPU()},
+	} {
+		b.Run(c.name, func(b *testing.B) {
+			P.params.SetConcurrency(c.concurrency)
+			for n := 0; n < b.N; n++ {
+				round := &round2{P.FirstRound().(*round1)}
+				if err := round.Start(); err != nil {
+					b.Fatal(err)
+				}
+				for j := 0; j < partyCount-1; j++ {
+					<-outCh
+				}
+			}
+		})
+	}
+}
+
+// syntheticKeys makes key data for `pIDs` with random shares, reusing the pre-params of the fixtures in turn, as there
+// are fewer fixtures than parties. The shares are not of one key, which is enough for the rounds before round 5.
+func syntheticKeys(fixtures []keygen.LocalPartySaveData, pIDs tss.SortedPartyIDs) []keygen.LocalPartySaveData {
+	ec := tss.S256()
+	shared := keygen.NewLocalPartySaveData(len(pIDs))
+	xs := make([]*big.Int, len(pIDs))
+	for j, Pj := range pIDs {
+		fixture := fixtures[j%len(fixtures)]
+		xs[j] = common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+		shared.Ks[j] = Pj.KeyInt()
+		shared.BigXj[j] = crypto2.ScalarBaseMult(ec, xs[j])
+		shared.NTildej[j], shared.H1j[j], shared.H2j[j] = fixture.NTildei, fixture.H1i, fixture.H2i
+		shared.PaillierPKs[j] = &fixture.PaillierSK.PublicKey
+	}
+	keys := make([]keygen.LocalPartySaveData, len(pIDs))
+	for i, Pi := range pIDs {
+		keys[i] = shared
+		keys[i].LocalPreParams = fixtures[i%len(fixtures)].LocalPreParams
+		keys[i].Xi, keys[i].ShareID = xs[i], Pi.KeyInt()
+		keys[i].ECDSAPub = fixtures[0].ECDSAPub
+	}
+	return keys
+}
+
+func wireBytesOf(b *testing.B, msg tss.Message) []byte {
+	bz, _, err := msg.WireBytes()
+	if err != nil {
+		b.Fatal(err)
+	}
+	return bz
+}
+
+// signSequentially runs a signing of `msg` by `signPIDs` on a single goroutine and returns the signature data.
+// The parameters are on the curve of the keys. The parties are created by NewLocalParty, or by
+// NewLocalPartyWithOptions when `opts` are given.
+func signSequentially(keys []keygen.LocalPartySaveData, signPIDs tss.SortedPartyIDs, msg *big.Int, configure func(*tss.Parameters), opts ...Option) (*common.SignatureData, error) {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs)*len(signPIDs))
+	outCh := make(chan tss.Message, 1000)
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(keys[i].ECDSAPub.Curve(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		if configure != nil {
+			configure(params)
+		}
+		var P *LocalParty
+		if len(opts) == 0 {
+			P = NewLocalParty(msg, params, keys[i], outCh, endCh).(*LocalParty)
+		} else {
+			P = NewLocalPartyWithOptions(msg, params, keys[i], outCh, endCh, opts...).(*LocalParty)
+		}
+		parties = append(parties, P)
+		if err := P.Start(); err != nil {
+			return nil, err
+		}
+	}
+	return routeSequentially(parties, errCh, outCh, endCh)
+}
+
+// routeSequentially delivers the messages of the started `parties` on a single goroutine until they have all ended,
+// and returns the signature data of the last one.
+func routeSequentially(parties []*LocalParty, errCh chan *tss.Error, outCh <-chan tss.Message, endCh <-chan *common.SignatureData) (*common.SignatureData, error) {
+	var data *common.SignatureData
+	for ended := 0; ended < len(parties); {
+		select {
+		case err := <-errCh:
+			return nil, err
+		case msg := <-outCh:
+			if dest := msg.GetTo(); dest != nil {
+				test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+				continue
+			}
+			for _, P := range parties {
+				if P.PartyID().Index != msg.GetFrom().Index {
+					test.SharedPartyUpdater(P, msg, errCh)
+				}
+			}
+		case data = <-endCh:
+			ended++
+		}
+	}
+	return data, nil
+}
+
+func TestE2EWithDealtKey(t *testing.T) {
+	setUp("info")
+
+	fixtures, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	preParams := make([]keygen.LocalPreParams, len(fixtures))
+	for i, fixture := range fixtures {
+		preParams[i] = fixture.LocalPreParams
+	}
+	secret := common.GetRandomPositiveInt(rand.Reader, tss.S256().Params().N)
+	keys, err := keygen.NewSaveDataFromSecret(tss.S256(), secret, pIDs, testThreshold, preParams, rand.Reader)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// any threshold+1 of the parties can sign; take the last ones
+	signKeys := keys[len(keys)-testThreshold-1:]
+	signPIDs := tss.SortPartyIDs(pIDs[len(pIDs)-testThreshold-1:].ToUnSorted())
+	msg := big.NewInt(42)
+	data, err := signSequentially(signKeys, signPIDs, msg, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	pkX, pkY := tss.S256().ScalarBaseMult(secret.Bytes())
+	pk := ecdsa.PublicKey{Curve: tss.S256(), X: pkX, Y: pkY}
+	ok := ecdsa.Verify(&pk, msg.Bytes(), new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S))
+	assert.True(t, ok, "the signature should verify against the public key of the dealt secret")
+}
+
+func TestE2EP384(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixturesP384(testParticipants)
+	if !assert.NoError(t, err, "should load the P-384 keygen fixtures") {
+		return
+	}
+	ec := elliptic.P384()
+	assert.True(t, tss.SameCurve(ec, keys[0].ECDSAPub.Curve()))
+
+	signKeys := keys[:testThreshold+1]
+	signPIDs := pIDs[:testThreshold+1]
+	digest := sha512.Sum384([]byte("a message to sign on P-384"))
+	msg, _ := hashToInt(digest[:], ec)
+	data, err := signSequentially(signKeys, signPIDs, msg, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, data.R, 48)
+	assert.Len(t, data.S, 48)
+	pk := ecdsa.PublicKey{Curve: ec, X: keys[0].ECDSAPub.X(), Y: keys[0].ECDSAPub.Y()}
+	ok := ecdsa.Verify(&pk, digest[:], new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S))
+	assert.True(t, ok, "ecdsa verify must pass")
+}
+
+func TestE2EIdentifiesBadSShare(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// the last party reveals an s_i other than the one it committed to
+	cheater := signPIDs[len(signPIDs)-1]
+	ended := 0
+	for {
+		select {
+		case err := <-errCh:
+			assert.Equal(t, 10, err.Round())
+			assert.Equal(t, []*tss.PartyID{cheater}, err.Culprits())
+			return
+
+		case msg := <-outCh:
+			if r9msg, ok := msg.(tss.ParsedMessage).Content().(*SignRound9Message); ok && msg.GetFrom() == cheater {
+				badS := new(big.Int).Add(r9msg.UnmarshalS(), big.NewInt(1))
+				msg = NewSignRound9Message(cheater, badS, r9msg.UnmarshalL())
+			}
+			for _, P := range parties {
+				if P.PartyID().Index != msg.GetFrom().Index && (msg.GetTo() == nil || msg.GetTo()[0].Index == P.PartyID().Index) {
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+			}
+
+		case <-endCh:
+			// only the cheater, which sums its own good share, may end
+			if ended++; ended > 1 {
+				assert.FailNow(t, "a signature with a bad share should not verify")
+			}
+		}
+	}
+}
+
+func TestSignRound9MessageWithoutL(t *testing.T) {
+	// parties of older versions do not reveal l_i
+	m := &SignRound9Message{S: big.NewInt(42).Bytes()}
+	assert.True(t, m.ValidateBasic())
+	assert.False(t, m.HasL())
+	assert.False(t, (&SignRound9Message{L: big.NewInt(42).Bytes()}).ValidateBasic())
+
+	pIDs := tss.GenerateTestPartyIDs(1)
+	assert.True(t, NewSignRound9Message(pIDs[0], big.NewInt(42), big.NewInt(7)).Content().(*SignRound9Message).HasL())
+}
+
+func TestE2EIdentifiesBadProofBob(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// the last party sends a bad MtA respondent proof to the first party
+	cheater, victim := signPIDs[len(signPIDs)-1], signPIDs[0]
+	for {
+		select {
+		case err := <-errCh:
+			assert.Equal(t, victim, err.Victim())
+			assert.Equal(t, 3, err.Round())
+			assert.Equal(t, []*tss.PartyID{cheater}, err.Culprits())
+			return
+
+		case msg := <-outCh:
+			if r2msg, ok := msg.(tss.ParsedMessage).Content().(*SignRound2Message); ok && msg.GetFrom() == cheater && msg.GetTo()[0] == victim {
+				content := proto.Clone(r2msg).(*SignRound2Message)
+				content.ProofBob[len(content.ProofBob)-1] = new(big.Int).Add(new(big.Int).SetBytes(content.ProofBob[len(content.ProofBob)-1]), big.NewInt(1)).Bytes()
+				meta := tss.MessageRouting{From: cheater, To: msg.GetTo()}
+				msg = tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content))
+			}
+			for _, P := range parties {
+				if P.PartyID().Index != msg.GetFrom().Index && (msg.GetTo() == nil || msg.GetTo()[0].Index == P.PartyID().Index) {
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+			}
+
+		case <-endCh:
+			assert.FailNow(t, "the signing should not complete")
+		}
+	}
+}
+
+func TestSSIDNonce(t *testing.T) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	ssidWith := func(nonce *big.Int) []byte {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+		if nonce != nil {
+			params.SetSSIDNonce(nonce)
+		}
+		// round 1 sets the ssid when the party starts
+		outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs))
+		P := NewLocalParty(big.NewInt(42), params, keys[0], outCh, nil).(*LocalParty)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+		assert.NotEmpty(t, P.temp.ssid)
+		return P.temp.ssid
+	}
+	assert.Equal(t, ssidWith(nil), ssidWith(big.NewInt(0)), "the default nonce should be 0")
+	assert.Equal(t, ssidWith(big.NewInt(7)), ssidWith(big.NewInt(7)), "the ssid should be reproducible")
+	assert.NotEqual(t, ssidWith(nil), ssidWith(big.NewInt(7)), "the nonce should be bound to the ssid")
+}
+
+func TestNewLocalPartyFromMessage(t *testing.T) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+	rawMsg := []byte("an arbitrary-length message to sign")
+
+	P, err := NewLocalPartyFromMessage(rawMsg, common.HashBitcoin, params, keys[0], nil, nil)
+	assert.NoError(t, err)
+	btcHash := common.HashBitcoin.Digest(rawMsg)
+	assert.Equal(t, new(big.Int).SetBytes(btcHash), P.(*LocalParty).temp.m)
+	assert.Equal(t, 32, P.(*LocalParty).temp.fullBytesLen)
+
+	// digests longer than the curve order are truncated to its bit length
+	sha512, err := common.NewMessageHash(crypto.SHA512)
+	assert.NoError(t, err)
+	P, err = NewLocalPartyFromMessage(rawMsg, sha512, params, keys[0], nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, new(big.Int).SetBytes(sha512.Digest(rawMsg)[:32]), P.(*LocalParty).temp.m)
+
+	// digests above the curve order are reduced
+	m, _ := hashToInt(bytes.Repeat([]byte{0xff}, 32), tss.S256())
+	assert.True(t, m.Cmp(tss.S256().Params().N) < 0)
+
+	_, err = NewLocalPartyFromMessage(rawMsg, common.HashNone, params, keys[0], nil, nil)
+	assert.Error(t, err, "ECDSA requires a message hash")
+}
+
+func TestE2ENewLocalPartyFromDigest(t *testing.T) {
+	setUp("info")
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// a digest with a leading zero byte, which new(big.Int).SetBytes would shorten to 31 bytes
+	var digest []byte
+	for i := 0; digest == nil || digest[0] != 0x00; i++ {
+		digest = common.HashBitcoin.Digest([]byte(fmt.Sprintf("message %d", i)))
+	}
+	assert.Len(t, new(big.Int).SetBytes(digest).Bytes(), 31)
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	parties := make([]*LocalParty, 0, len(signPIDs))
+	for i := range signPIDs {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P, err := NewLocalPartyFromDigest(digest, params, keys[i], outCh, endCh)
+		assert.NoError(t, err)
+		parties = append(parties, P.(*LocalParty))
+		assert.Nil(t, P.Start())
+	}
+	data, err := routeSequentially(parties, errCh, outCh, endCh)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, digest, data.M, "the signed message should be the full digest")
+	pk := keys[0].ECDSAPub.ToECDSAPubKey()
+	assert.True(t, ecdsa.Verify(pk, digest, new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S)))
+	var r, s btcec.ModNScalar
+	r.SetByteSlice(data.R)
+	s.SetByteSlice(data.S)
+	btcPK, err := btcec.ParsePubKey(keys[0].ECDSAPub.Bytes())
+	assert.NoError(t, err)
+	assert.True(t, btcecdsa.NewSignature(&r, &s).Verify(digest, btcPK), "the signature should verify as a Bitcoin signature")
+
+	_, err = NewLocalPartyFromDigest(nil, tss.NewParameters(tss.S256(), p2pCtx, signPIDs[0], len(signPIDs), testThreshold), keys[0], nil, nil)
+	assert.Error(t, err)
+}
+
+func TestCurveMismatch(t *testing.T) {
+	setUp("info")
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+
+	// the keys are on secp256k1, the parameters on P-256
+	params := tss.NewParameters(elliptic.P256(), p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+	P := NewLocalParty(big.NewInt(42), params, keys[0], make(chan tss.Message, len(signPIDs)), nil)
+	err2 := P.Start()
+	if assert.NotNil(t, err2) {
+		assert.Contains(t, err2.Error(), "the key is on the curve secp256k1 but the parameters are on the curve P-256")
+	}
+
+	// a point of a message on another curve than the parameters is rejected
+	x := common.GetRandomPositiveInt(rand.Reader, elliptic.P256().Params().N)
+	X := crypto2.ScalarBaseMult(elliptic.P256(), x)
+	proof, err := schnorr.NewZKProof([]byte("session"), x, X, rand.Reader)
+	assert.NoError(t, err)
+	msg := NewSignRound4Message(signPIDs[1], cmt.HashDeCommitment{big.NewInt(1), big.NewInt(2), big.NewInt(3)}, proof)
+	_, err = msg.Content().(*SignRound4Message).UnmarshalZKProof(tss.S256())
+	assert.Error(t, err)
+	_, err = msg.Content().(*SignRound4Message).UnmarshalZKProof(elliptic.P256())
+	assert.NoError(t, err)
+}
+
+func TestE2ECompressPoints(t *testing.T) {
+	setUp("info")
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	msg := big.NewInt(42)
+	data, err := signSequentially(keys, signPIDs, msg, func(params *tss.Parameters) {
+		params.EnableCompressPoints()
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	pkX, pkY := keys[0].ECDSAPub.X(), keys[0].ECDSAPub.Y()
+	pk := ecdsa.PublicKey{Curve: tss.S256(), X: pkX, Y: pkY}
+	ok := ecdsa.Verify(&pk, msg.Bytes(), new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S))
+	assert.True(t, ok, "the signature should verify")
+}
+
+func TestCompressPointsMessages(t *testing.T) {
+	ec := tss.S256()
+	pIDs := tss.GenerateTestPartyIDs(1)
+	dc := cmt.HashDeCommitment{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4), big.NewInt(5)}
+	x := common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+	X := crypto2.ScalarBaseMult(ec, x)
+	proof, err := schnorr.NewZKProof([]byte("session"), x, X, rand.Reader)
+	assert.NoError(t, err)
+	s, l := common.GetRandomPositiveInt(rand.Reader, ec.Params().N), common.GetRandomPositiveInt(rand.Reader, ec.Params().N)
+	V, err := X.ScalarMult(s).Add(crypto2.ScalarBaseMult(ec, l))
+	assert.NoError(t, err)
+	vProof, err := schnorr.NewZKVProof([]byte("session"), V, X, s, l, rand.Reader)
+	assert.NoError(t, err)
+
+	for _, compress := range []bool{false, true} {
+		r4 := newSignRound4Message(pIDs[0], dc[:3], proof, compress).Content().(*SignRound4Message)
+		r6 := newSignRound6Message(pIDs[0], dc, proof, vProof, compress).Content().(*SignRound6Message)
+		assert.True(t, r4.ValidateBasic() && r6.ValidateBasic(), "compress=%v", compress)
+		assert.Equal(t, compress, len(r4.GetProofAlpha()) > 0)
+
+		pf4, err := r4.UnmarshalZKProof(ec)
+		if assert.NoError(t, err) {
+			assert.True(t, pf4.Verify([]byte("session"), X), "compress=%v", compress)
+		}
+		pf6, err := r6.UnmarshalZKProof(ec)
+		if assert.NoError(t, err) {
+			assert.True(t, pf6.Verify([]byte("session"), X), "compress=%v", compress)
+		}
+		vPf6, err := r6.UnmarshalZKVProof(ec)
+		if assert.NoError(t, err) {
+			assert.True(t, vPf6.Verify([]byte("session"), V, X), "compress=%v", compress)
+		}
+	}
+
+	// the compressed messages are smaller
+	r4, r4C := NewSignRound4Message(pIDs[0], dc[:3], proof), newSignRound4Message(pIDs[0], dc[:3], proof, true)
+	r6, r6C := NewSignRound6Message(pIDs[0], dc, proof, vProof), newSignRound6Message(pIDs[0], dc, proof, vProof, true)
+	assert.Less(t, proto.Size(r4C.Content()), proto.Size(r4.Content()))
+	assert.Less(t, proto.Size(r6C.Content()), proto.Size(r6.Content()))
+
+	// a point sent in both encodings is rejected
+	both := r4C.Content().(*SignRound4Message)
+	both.ProofAlphaX, both.ProofAlphaY = proof.Bytes()[0], proof.Bytes()[1]
+	assert.False(t, both.ValidateBasic())
+	_, err = both.UnmarshalZKProof(ec)
+	assert.Error(t, err)
+}
+
+func TestInvalidMessage(t *testing.T) {
+	setUp("info")
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	N := tss.S256().Params().N
+
+	cases := []struct {
+		name         string
+		msg          *big.Int
+		fullBytesLen []int
+		err          string
+	}{
+		{"nil", nil, nil, "the message to sign is nil"},
+		{"zero", big.NewInt(0), nil, "the message to sign must be positive, got 0"},
+		{"negative", big.NewInt(-1), nil, "the message to sign must be positive, got -1"},
+		{"N", new(big.Int).Set(N), nil, "must be less than the order of the curve"},
+		{"greater than N", new(big.Int).Add(N, big.NewInt(1)), nil, "must be less than the order of the curve"},
+		{"longer than fullBytesLen", big.NewInt(1 << 16), []int{2}, "does not fit into fullBytesLen (2 bytes)"},
+	}
+	for _, c := range cases {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+		outCh := make(chan tss.Message, len(signPIDs))
+		P := NewLocalParty(c.msg, params, keys[0], outCh, nil, c.fullBytesLen...)
+		err := P.Start()
+		if assert.NotNil(t, err, c.name) {
+			assert.Contains(t, err.Error(), c.err, c.name)
+			assert.Empty(t, err.Culprits(), c.name)
+		}
+		assert.Empty(t, outCh, "%s: no message should be sent", c.name)
+	}
+}
+
+func TestMissingSigner(t *testing.T) {
+	setUp("info")
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+
+	// fewer signers than t+1 are rejected at Start
+	params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[0], len(signPIDs), len(signPIDs))
+	if err := NewLocalParty(big.NewInt(42), params, keys[0], make(chan tss.Message, len(signPIDs)), nil).Start(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), fmt.Sprintf("t+1=%d signers are needed, but the parameters list %d", len(signPIDs)+1, len(signPIDs)))
+	}
+	// as is a threshold other than that of the key
+	key := keys[0]
+	key.KeyThreshold = testThreshold + 1
+	params = tss.NewParameters(tss.S256(), p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+	if err := NewLocalParty(big.NewInt(42), params, key, make(chan tss.Message, len(signPIDs)), nil).Start(); assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "is not the threshold of the key")
+	}
+
+	// the last signer is never started; the others report it when the round times out rather than hang
+	missing := signPIDs[len(signPIDs)-1]
+	outCh := make(chan tss.Message, len(signPIDs)*len(signPIDs))
+	timeoutCh := make(chan *tss.Error, 1)
+	parties := make([]*LocalParty, 0, len(signPIDs)-1)
+	for i, Pi := range signPIDs[:len(signPIDs)-1] {
+		params := tss.NewParameters(tss.S256(), p2pCtx, Pi, len(signPIDs), testThreshold)
+		if i == 0 {
+			params.SetRoundTimeout(500*time.Millisecond, func(err *tss.Error) { timeoutCh <- err })
+		}
+		P := NewLocalParty(big.NewInt(42), params, keys[i], outCh, nil).(*LocalParty)
+		assert.Nil(t, P.Start())
+		parties = append(parties, P)
+	}
+	errCh := make(chan *tss.Error, len(signPIDs))
+	for len(outCh) > 0 {
+		msg := <-outCh
+		for _, P := range parties {
+			if P.PartyID().Index == msg.GetFrom().Index {
+				continue
+			}
+			if dest := msg.GetTo(); dest == nil || dest[0].Index == P.PartyID().Index {
+				test.SharedPartyUpdater(P, msg, errCh)
+			}
+		}
+	}
+	assert.Empty(t, errCh)
+
+	select {
+	case err := <-timeoutCh:
+		assert.Equal(t, 1, err.Round())
+		assert.Equal(t, []*tss.PartyID{missing}, err.Culprits(), "the error should name the signer that is missing")
+		assert.Contains(t, err.Error(), "still waiting for")
+	case <-time.After(5 * time.Second):
+		assert.FailNow(t, "the round timeout did not fire")
+	}
+	assert.Equal(t, []*tss.PartyID{missing}, parties[0].WaitingFor())
+}
+
 func TestFillTo32BytesInPlace(t *testing.T) {
 	s := big.NewInt(123456789)
 	normalizedS := padToLengthBytesInPlace(s.Bytes(), 32)
@@ -357,3 +1142,99 @@ func fillBytes(x *big.Int, buf []byte) []byte {
 	}
 	return buf
 }
+
+func TestE2EWithOptions(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// without options, the signature is returned as computed
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+	P := NewLocalPartyWithOptions(big.NewInt(42), params, keys[0], nil, nil).(*LocalParty)
+	assert.False(t, P.temp.lowS)
+	assert.False(t, P.temp.recoveryID)
+	assert.Equal(t, 0, P.temp.fullBytesLen)
+	assert.Nil(t, P.temp.keyDerivationDelta)
+
+	// a message hash with a leading zero byte keeps it with WithFullBytesLen
+	hash := sha256.Sum256([]byte("tss-lib"))
+	hash[0] = 0
+	msg := new(big.Int).SetBytes(hash[:])
+	data, err := signSequentially(keys, signPIDs, msg, nil, WithFullBytesLen(32), WithRecoveryID())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, hash[:], data.M)
+	pk := keys[0].ECDSAPub.ToECDSAPubKey()
+	assert.True(t, ecdsa.Verify(pk, data.M, new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S)))
+
+	// the recovery id matches S, whether it is low or not
+	if assert.Len(t, data.SignatureRecovery, 1) {
+		compact := append([]byte{27 + data.SignatureRecovery[0]}, data.R...)
+		compact = append(compact, data.S...)
+		recovered, _, err := btcecdsa.RecoverCompact(compact, data.M)
+		if assert.NoError(t, err) {
+			assert.Equal(t, 0, recovered.X().Cmp(pk.X))
+			assert.Equal(t, 0, recovered.Y().Cmp(pk.Y))
+		}
+	}
+}
+
+// captureLogger records the log entries of a party, prefixed with their level
+type captureLogger struct {
+	mtx     sync.Mutex
+	entries []string
+}
+
+func (l *captureLogger) log(level, format string, args ...interface{}) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.entries = append(l.entries, level+" "+fmt.Sprintf(format, args...))
+}
+
+func (l *captureLogger) Debugf(format string, args ...interface{}) {
+	l.log("DEBUG", format, args...)
+}
+
+func (l *captureLogger) Infof(format string, args ...interface{}) {
+	l.log("INFO", format, args...)
+}
+
+func (l *captureLogger) Warningf(format string, args ...interface{}) {
+	l.log("WARN", format, args...)
+}
+
+func (l *captureLogger) Errorf(format string, args ...interface{}) {
+	l.log("ERROR", format, args...)
+}
+
+func TestE2EWithLogger(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	loggers := make(map[string]*captureLogger, len(signPIDs))
+	_, err = signSequentially(keys, signPIDs, big.NewInt(42), func(params *tss.Parameters) {
+		logger := new(captureLogger)
+		loggers[params.PartyID().Id] = logger
+		params.SetLogger(logger)
+		params.SetInsecureSkipProofsForBenchmarkOnly(tss.InsecureSkipSchnorrProofs)
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, pID := range signPIDs {
+		entries := loggers[pID.Id].entries
+		assert.Contains(t, entries, fmt.Sprintf("INFO party %s: signing round 1 starting", pID))
+		assert.Contains(t, entries, fmt.Sprintf("INFO party %s: signing finished!", pID))
+		assert.Contains(t, entries, "WARN INSECURE: skipping the verification of signing proofs (100), this must only be used for benchmarking")
+		for _, entry := range entries {
+			// a party only logs about itself
+			if strings.HasPrefix(entry, "INFO party") {
+				assert.True(t, strings.HasPrefix(entry, fmt.Sprintf("INFO party %s:", pID)), entry)
+			}
+		}
+	}
+}
@@ -8,6 +8,7 @@ package signing
 
 import (
 	"crypto/elliptic"
+	"errors"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
@@ -168,17 +169,31 @@ func NewSignRound4Message(
 	from *tss.PartyID,
 	deCommitment cmt.HashDeCommitment,
 	proof *schnorr.ZKProof,
+) tss.ParsedMessage {
+	return newSignRound4Message(from, deCommitment, proof, false)
+}
+
+// newSignRound4Message sends the alpha of the proof compressed when `compressPoints` is set, see tss.EnableCompressPoints
+func newSignRound4Message(
+	from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+	proof *schnorr.ZKProof,
+	compressPoints bool,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
 		IsBroadcast: true,
 	}
 	dcBzs := common.BigIntsToBytes(deCommitment)
+	proofBzs := proof.Bytes()
 	content := &SignRound4Message{
 		DeCommitment: dcBzs,
-		ProofAlphaX:  proof.Alpha.X().Bytes(),
-		ProofAlphaY:  proof.Alpha.Y().Bytes(),
-		ProofT:       proof.T.Bytes(),
+		ProofAlphaX:  proofBzs[0],
+		ProofAlphaY:  proofBzs[1],
+		ProofT:       proofBzs[2],
+	}
+	if compressPoints {
+		content.ProofAlpha, content.ProofAlphaX, content.ProofAlphaY = proof.Alpha.Bytes(), nil, nil
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
@@ -187,8 +202,7 @@ func NewSignRound4Message(
 func (m *SignRound4Message) ValidateBasic() bool {
 	return m != nil &&
 		common.NonEmptyMultiBytes(m.DeCommitment, 3) &&
-		common.NonEmptyBytes(m.ProofAlphaX) &&
-		common.NonEmptyBytes(m.ProofAlphaY) &&
+		validPointBytes(m.ProofAlpha, m.ProofAlphaX, m.ProofAlphaY) &&
 		common.NonEmptyBytes(m.ProofT)
 }
 
@@ -197,18 +211,13 @@ func (m *SignRound4Message) UnmarshalDeCommitment() []*big.Int {
 	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
 }
 
+// UnmarshalZKProof accepts the alpha of the proof either compressed or as separate coordinates
 func (m *SignRound4Message) UnmarshalZKProof(ec elliptic.Curve) (*schnorr.ZKProof, error) {
-	point, err := crypto.NewECPoint(
-		ec,
-		new(big.Int).SetBytes(m.GetProofAlphaX()),
-		new(big.Int).SetBytes(m.GetProofAlphaY()))
+	alphaX, alphaY, err := unmarshalPointBytes(ec, m.GetProofAlpha(), m.GetProofAlphaX(), m.GetProofAlphaY())
 	if err != nil {
 		return nil, err
 	}
-	return &schnorr.ZKProof{
-		Alpha: point,
-		T:     new(big.Int).SetBytes(m.GetProofT()),
-	}, nil
+	return schnorr.ZKProofFromBytes(ec, [][]byte{alphaX, alphaY, m.GetProofT()})
 }
 
 // ----- //
@@ -244,21 +253,38 @@ func NewSignRound6Message(
 	deCommitment cmt.HashDeCommitment,
 	proof *schnorr.ZKProof,
 	vProof *schnorr.ZKVProof,
+) tss.ParsedMessage {
+	return newSignRound6Message(from, deCommitment, proof, vProof, false)
+}
+
+// newSignRound6Message sends the alphas of the proofs compressed when `compressPoints` is set, see tss.EnableCompressPoints
+func newSignRound6Message(
+	from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+	proof *schnorr.ZKProof,
+	vProof *schnorr.ZKVProof,
+	compressPoints bool,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
 		IsBroadcast: true,
 	}
 	dcBzs := common.BigIntsToBytes(deCommitment)
+	proofBzs := proof.Bytes()
+	vProofBzs := vProof.Bytes()
 	content := &SignRound6Message{
 		DeCommitment: dcBzs,
-		ProofAlphaX:  proof.Alpha.X().Bytes(),
-		ProofAlphaY:  proof.Alpha.Y().Bytes(),
-		ProofT:       proof.T.Bytes(),
-		VProofAlphaX: vProof.Alpha.X().Bytes(),
-		VProofAlphaY: vProof.Alpha.Y().Bytes(),
-		VProofT:      vProof.T.Bytes(),
-		VProofU:      vProof.U.Bytes(),
+		ProofAlphaX:  proofBzs[0],
+		ProofAlphaY:  proofBzs[1],
+		ProofT:       proofBzs[2],
+		VProofAlphaX: vProofBzs[0],
+		VProofAlphaY: vProofBzs[1],
+		VProofT:      vProofBzs[2],
+		VProofU:      vProofBzs[3],
+	}
+	if compressPoints {
+		content.ProofAlpha, content.ProofAlphaX, content.ProofAlphaY = proof.Alpha.Bytes(), nil, nil
+		content.VProofAlpha, content.VProofAlphaX, content.VProofAlphaY = vProof.Alpha.Bytes(), nil, nil
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
@@ -267,11 +293,9 @@ func NewSignRound6Message(
 func (m *SignRound6Message) ValidateBasic() bool {
 	return m != nil &&
 		common.NonEmptyMultiBytes(m.DeCommitment, 5) &&
-		common.NonEmptyBytes(m.ProofAlphaX) &&
-		common.NonEmptyBytes(m.ProofAlphaY) &&
+		validPointBytes(m.ProofAlpha, m.ProofAlphaX, m.ProofAlphaY) &&
 		common.NonEmptyBytes(m.ProofT) &&
-		common.NonEmptyBytes(m.VProofAlphaX) &&
-		common.NonEmptyBytes(m.VProofAlphaY) &&
+		validPointBytes(m.VProofAlpha, m.VProofAlphaX, m.VProofAlphaY) &&
 		common.NonEmptyBytes(m.VProofT) &&
 		common.NonEmptyBytes(m.VProofU)
 }
@@ -281,33 +305,22 @@ func (m *SignRound6Message) UnmarshalDeCommitment() []*big.Int {
 	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
 }
 
+// UnmarshalZKProof accepts the alpha of the proof either compressed or as separate coordinates
 func (m *SignRound6Message) UnmarshalZKProof(ec elliptic.Curve) (*schnorr.ZKProof, error) {
-	point, err := crypto.NewECPoint(
-		ec,
-		new(big.Int).SetBytes(m.GetProofAlphaX()),
-		new(big.Int).SetBytes(m.GetProofAlphaY()))
+	alphaX, alphaY, err := unmarshalPointBytes(ec, m.GetProofAlpha(), m.GetProofAlphaX(), m.GetProofAlphaY())
 	if err != nil {
 		return nil, err
 	}
-	return &schnorr.ZKProof{
-		Alpha: point,
-		T:     new(big.Int).SetBytes(m.GetProofT()),
-	}, nil
+	return schnorr.ZKProofFromBytes(ec, [][]byte{alphaX, alphaY, m.GetProofT()})
 }
 
+// UnmarshalZKVProof accepts the alpha of the proof either compressed or as separate coordinates
 func (m *SignRound6Message) UnmarshalZKVProof(ec elliptic.Curve) (*schnorr.ZKVProof, error) {
-	point, err := crypto.NewECPoint(
-		ec,
-		new(big.Int).SetBytes(m.GetVProofAlphaX()),
-		new(big.Int).SetBytes(m.GetVProofAlphaY()))
+	alphaX, alphaY, err := unmarshalPointBytes(ec, m.GetVProofAlpha(), m.GetVProofAlphaX(), m.GetVProofAlphaY())
 	if err != nil {
 		return nil, err
 	}
-	return &schnorr.ZKVProof{
-		Alpha: point,
-		T:     new(big.Int).SetBytes(m.GetVProofT()),
-		U:     new(big.Int).SetBytes(m.GetVProofU()),
-	}, nil
+	return schnorr.ZKVProofFromBytes(ec, [][]byte{alphaX, alphaY, m.GetVProofT(), m.GetVProofU()})
 }
 
 // ----- //
@@ -369,6 +382,7 @@ func (m *SignRound8Message) UnmarshalDeCommitment() []*big.Int {
 func NewSignRound9Message(
 	from *tss.PartyID,
 	si *big.Int,
+	li *big.Int,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
@@ -376,16 +390,54 @@ func NewSignRound9Message(
 	}
 	content := &SignRound9Message{
 		S: si.Bytes(),
+		L: li.Bytes(),
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
 }
 
+// ValidateBasic accepts a message without L, as parties of older versions do not send it; they cannot be blamed for a
+// bad s_i then, see finalization.sShareCulprits.
 func (m *SignRound9Message) ValidateBasic() bool {
 	return m != nil &&
 		common.NonEmptyBytes(m.S)
 }
 
+// HasL returns whether the sender revealed l_i with s_i.
+func (m *SignRound9Message) HasL() bool {
+	return common.NonEmptyBytes(m.GetL())
+}
+
 func (m *SignRound9Message) UnmarshalS() *big.Int {
 	return new(big.Int).SetBytes(m.S)
 }
+
+func (m *SignRound9Message) UnmarshalL() *big.Int {
+	return new(big.Int).SetBytes(m.L)
+}
+
+// ----- //
+
+// validPointBytes checks that a point was sent in exactly one of the encodings: compressed, or as its coordinates
+func validPointBytes(compressed, x, y []byte) bool {
+	if common.NonEmptyBytes(compressed) {
+		return len(x) == 0 && len(y) == 0
+	}
+	return common.NonEmptyBytes(x) && common.NonEmptyBytes(y)
+}
+
+// unmarshalPointBytes returns the coordinates of a point sent in either encoding, decompressing it if needed
+func unmarshalPointBytes(ec elliptic.Curve, compressed, x, y []byte) ([]byte, []byte, error) {
+	if len(compressed) == 0 {
+		return x, y, nil
+	}
+	if len(x) != 0 || len(y) != 0 {
+		return nil, nil, errors.New("a point was sent both compressed and as coordinates")
+	}
+	point, err := crypto.ECPointFromBytes(ec, compressed)
+	if err != nil {
+		return nil, nil, err
+	}
+	alphaX, alphaY := point.CoordinateBytes()
+	return alphaX, alphaY, nil
+}
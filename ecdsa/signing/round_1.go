@@ -19,8 +19,6 @@ import (
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
-var zero = big.NewInt(0)
-
 // round 1 represents round 1 of the signing part of the GG18 ECDSA TSS spec (Gennaro, Goldfeder; 2018)
 func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
 	return &round1{
@@ -37,14 +35,20 @@ func (round *round1) Start() *tss.Error {
 	// but considered different blockchain use different hash function we accept the converted big.Int
 	// if this big.Int is not belongs to Zq, the client might not comply with common rule (for ECDSA):
 	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L263
-	if round.temp.m.Cmp(round.Params().EC().Params().N) >= 0 {
-		return round.WrapError(errors.New("hashed message is not valid"))
+	// a message out of [1, N-1] is rejected rather than reduced, as its reduction is not what the caller meant to sign;
+	// NewLocalPartyFromMessage converts a hash to a valid message
+	if err := validateMessage(round.temp.m, round.Params().EC().Params().N, round.temp.fullBytesLen); err != nil {
+		return round.WrapError(err)
+	}
+
+	if skips := round.InsecureSkipProofs(); skips != 0 {
+		round.Params().Logger().Warningf("INSECURE: skipping the verification of signing proofs (%b), this must only be used for benchmarking", skips)
 	}
 
 	round.number = 1
 	round.started = true
 	round.resetOK()
-	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	round.temp.ssidNonce = round.SSIDNonce()
 	ssid, err := round.getSSID()
 	if err != nil {
 		return round.WrapError(err)
@@ -84,6 +88,21 @@ func (round *round1) Start() *tss.Error {
 	return nil
 }
 
+// validateMessage checks that the message to sign `m` is in [1, N-1] and fits into `fullBytesLen` bytes when it is set
+func validateMessage(m, N *big.Int, fullBytesLen int) error {
+	switch {
+	case m == nil:
+		return errors.New("the message to sign is nil")
+	case m.Sign() <= 0:
+		return fmt.Errorf("the message to sign must be positive, got %s", m)
+	case m.Cmp(N) >= 0:
+		return errors.New("the message to sign must be less than the order of the curve; hash it with NewLocalPartyFromMessage, or truncate and reduce it first")
+	case fullBytesLen > 0 && m.BitLen() > 8*fullBytesLen:
+		return fmt.Errorf("the message to sign does not fit into fullBytesLen (%d bytes)", fullBytesLen)
+	}
+	return nil
+}
+
 func (round *round1) Update() (bool, *tss.Error) {
 	for j, msg1 := range round.temp.signRound1Message1s {
 		if round.ok[j] {
@@ -120,6 +139,9 @@ func (round *round1) NextRound() tss.Round {
 
 // helper to call into PrepareForSigning()
 func (round *round1) prepare() error {
+	if err := round.key.CheckCurve(round.Params().EC()); err != nil {
+		return err
+	}
 	i := round.PartyID().Index
 
 	xi := round.key.Xi
@@ -135,10 +157,22 @@ func (round *round1) prepare() error {
 		round.key.Xi = xi
 	}
 
+	// the signers are the parties of the parameters; each of them must be started for the signing to complete
+	if 0 < round.key.KeyThreshold && round.key.KeyThreshold != round.Threshold() {
+		return fmt.Errorf("the threshold of the parameters (%d) is not the threshold of the key (%d)", round.Threshold(), round.key.KeyThreshold)
+	}
 	if round.Threshold()+1 > len(ks) {
-		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+		return fmt.Errorf("t+1=%d signers are needed, but the parameters list %d: %v", round.Threshold()+1, len(ks), round.Parties().IDs())
+	}
+	// catch corrupted pre-params early; older save data may not hold Alpha
+	if round.key.Alpha != nil && !round.key.VerifyH2IsH1PowAlpha() {
+		return errors.New("the local pre-params are corrupted: h2 != h1^alpha")
 	}
 	wi, bigWs := PrepareForSigning(round.Params().EC(), i, len(ks), xi, ks, bigXs)
+	if wi == xi {
+		// w is wiped after use in round 5, so it must not share the key's memory
+		wi = new(big.Int).Set(xi)
+	}
 
 	round.temp.w = wi
 	round.temp.bigWs = bigWs
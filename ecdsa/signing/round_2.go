@@ -32,34 +32,54 @@ func (round *round2) Start() *tss.Error {
 	wg := sync.WaitGroup{}
 	wg.Add((len(round.Parties().IDs()) - 1) * 2)
 	ContextI := append(round.temp.ssid, new(big.Int).SetUint64(uint64(i)).Bytes()...)
+	skipRangeProofs := round.InsecureSkipProofs()&tss.InsecureSkipRangeProofAlice != 0
+	// at most Concurrency() proofs are generated at a time; the results are stored by index, so the messages are the same
+	semaphore := make(chan struct{}, round.Concurrency())
 	for j, Pj := range round.Parties().IDs() {
 		if j == i {
 			continue
 		}
 		// Bob_mid
+		semaphore <- struct{}{}
 		go func(j int, Pj *tss.PartyID) {
-			defer wg.Done()
+			defer func() { <-semaphore; wg.Done() }()
 			r1msg := round.temp.signRound1Message1s[j].Content().(*SignRound1Message1)
 			rangeProofAliceJ, err := r1msg.UnmarshalRangeProofAlice()
 			if err != nil {
 				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalRangeProofAlice failed"), Pj)
 				return
 			}
-			beta, c1ji, _, pi1ji, err := mta.BobMid(
-				ContextI,
-				round.Parameters.EC(),
-				round.key.PaillierPKs[j],
-				rangeProofAliceJ,
-				round.temp.gamma,
-				r1msg.UnmarshalC(),
-				round.key.NTildej[j],
-				round.key.H1j[j],
-				round.key.H2j[j],
-				round.key.NTildej[i],
-				round.key.H1j[i],
-				round.key.H2j[i],
-				round.Rand(),
-			)
+			var beta, c1ji *big.Int
+			var pi1ji *mta.ProofBob
+			if skipRangeProofs {
+				beta, c1ji, _, pi1ji, err = mta.BobMidInsecureSkipVerify(
+					ContextI,
+					round.Parameters.EC(),
+					round.key.PaillierPKs[j],
+					round.temp.gamma,
+					r1msg.UnmarshalC(),
+					round.key.NTildej[j],
+					round.key.H1j[j],
+					round.key.H2j[j],
+					round.Rand(),
+				)
+			} else {
+				beta, c1ji, _, pi1ji, err = mta.BobMid(
+					ContextI,
+					round.Parameters.EC(),
+					round.key.PaillierPKs[j],
+					rangeProofAliceJ,
+					round.temp.gamma,
+					r1msg.UnmarshalC(),
+					round.key.NTildej[j],
+					round.key.H1j[j],
+					round.key.H2j[j],
+					round.key.NTildej[i],
+					round.key.H1j[i],
+					round.key.H2j[i],
+					round.Rand(),
+				)
+			}
 			// should be thread safe as these are pre-allocated
 			round.temp.betas[j] = beta
 			round.temp.c1jis[j] = c1ji
@@ -69,30 +89,48 @@ func (round *round2) Start() *tss.Error {
 			}
 		}(j, Pj)
 		// Bob_mid_wc
+		semaphore <- struct{}{}
 		go func(j int, Pj *tss.PartyID) {
-			defer wg.Done()
+			defer func() { <-semaphore; wg.Done() }()
 			r1msg := round.temp.signRound1Message1s[j].Content().(*SignRound1Message1)
 			rangeProofAliceJ, err := r1msg.UnmarshalRangeProofAlice()
 			if err != nil {
 				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalRangeProofAlice failed"), Pj)
 				return
 			}
-			v, c2ji, _, pi2ji, err := mta.BobMidWC(
-				ContextI,
-				round.Parameters.EC(),
-				round.key.PaillierPKs[j],
-				rangeProofAliceJ,
-				round.temp.w,
-				r1msg.UnmarshalC(),
-				round.key.NTildej[j],
-				round.key.H1j[j],
-				round.key.H2j[j],
-				round.key.NTildej[i],
-				round.key.H1j[i],
-				round.key.H2j[i],
-				round.temp.bigWs[i],
-				round.Rand(),
-			)
+			var v, c2ji *big.Int
+			var pi2ji *mta.ProofBobWC
+			if skipRangeProofs {
+				v, c2ji, _, pi2ji, err = mta.BobMidWCInsecureSkipVerify(
+					ContextI,
+					round.Parameters.EC(),
+					round.key.PaillierPKs[j],
+					round.temp.w,
+					r1msg.UnmarshalC(),
+					round.key.NTildej[j],
+					round.key.H1j[j],
+					round.key.H2j[j],
+					round.temp.bigWs[i],
+					round.Rand(),
+				)
+			} else {
+				v, c2ji, _, pi2ji, err = mta.BobMidWC(
+					ContextI,
+					round.Parameters.EC(),
+					round.key.PaillierPKs[j],
+					rangeProofAliceJ,
+					round.temp.w,
+					r1msg.UnmarshalC(),
+					round.key.NTildej[j],
+					round.key.H1j[j],
+					round.key.H2j[j],
+					round.key.NTildej[i],
+					round.key.H1j[i],
+					round.key.H2j[i],
+					round.temp.bigWs[i],
+					round.Rand(),
+				)
+			}
 			round.temp.vs[j] = v
 			round.temp.c2jis[j] = c2ji
 			round.temp.pi2jis[j] = pi2ji
@@ -34,57 +34,72 @@ func (round *round3) Start() *tss.Error {
 	errChs := make(chan *tss.Error, (len(round.Parties().IDs())-1)*2)
 	wg := sync.WaitGroup{}
 	wg.Add((len(round.Parties().IDs()) - 1) * 2)
+	skipBobProofs := round.InsecureSkipProofs()&tss.InsecureSkipProofBob != 0
+	// at most Concurrency() proofs are verified at a time, as large committees would oversubscribe the CPUs
+	semaphore := make(chan struct{}, round.Concurrency())
 	for j, Pj := range round.Parties().IDs() {
 		if j == i {
 			continue
 		}
 		ContextJ := append(round.temp.ssid, new(big.Int).SetUint64(uint64(j)).Bytes()...)
 		// Alice_end
+		semaphore <- struct{}{}
 		go func(j int, Pj *tss.PartyID) {
-			defer wg.Done()
+			defer func() { <-semaphore; wg.Done() }()
 			r2msg := round.temp.signRound2Messages[j].Content().(*SignRound2Message)
 			proofBob, err := r2msg.UnmarshalProofBob()
 			if err != nil {
 				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalProofBob failed"), Pj)
 				return
 			}
-			alphaIj, err := mta.AliceEnd(
-				ContextJ,
-				round.Params().EC(),
-				round.key.PaillierPKs[i],
-				proofBob,
-				round.key.H1j[i],
-				round.key.H2j[i],
-				round.temp.cis[j],
-				new(big.Int).SetBytes(r2msg.GetC1()),
-				round.key.NTildej[i],
-				round.key.PaillierSK)
+			var alphaIj *big.Int
+			if skipBobProofs {
+				alphaIj, err = mta.AliceEndInsecureSkipVerify(round.Params().EC(), new(big.Int).SetBytes(r2msg.GetC1()), round.key.PaillierSK)
+			} else {
+				alphaIj, err = mta.AliceEnd(
+					ContextJ,
+					round.Params().EC(),
+					round.key.PaillierPKs[i],
+					proofBob,
+					round.key.H1j[i],
+					round.key.H2j[i],
+					round.temp.cis[j],
+					new(big.Int).SetBytes(r2msg.GetC1()),
+					round.key.NTildej[i],
+					round.key.PaillierSK)
+			}
 			alphas[j] = alphaIj
 			if err != nil {
 				errChs <- round.WrapError(err, Pj)
 			}
 		}(j, Pj)
 		// Alice_end_wc
+		semaphore <- struct{}{}
 		go func(j int, Pj *tss.PartyID) {
-			defer wg.Done()
+			defer func() { <-semaphore; wg.Done() }()
 			r2msg := round.temp.signRound2Messages[j].Content().(*SignRound2Message)
 			proofBobWC, err := r2msg.UnmarshalProofBobWC(round.Parameters.EC())
 			if err != nil {
 				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalProofBobWC failed"), Pj)
 				return
 			}
-			uIj, err := mta.AliceEndWC(
-				ContextJ,
-				round.Params().EC(),
-				round.key.PaillierPKs[i],
-				proofBobWC,
-				round.temp.bigWs[j],
-				round.temp.cis[j],
-				new(big.Int).SetBytes(r2msg.GetC2()),
-				round.key.NTildej[i],
-				round.key.H1j[i],
-				round.key.H2j[i],
-				round.key.PaillierSK)
+			var uIj *big.Int
+			if skipBobProofs {
+				uIj, err = mta.AliceEndInsecureSkipVerify(round.Params().EC(), new(big.Int).SetBytes(r2msg.GetC2()), round.key.PaillierSK)
+			} else {
+				uIj, err = mta.AliceEndWC(
+					ContextJ,
+					round.Params().EC(),
+					round.key.PaillierPKs[i],
+					proofBobWC,
+					round.temp.bigWs[j],
+					round.temp.cis[j],
+					new(big.Int).SetBytes(r2msg.GetC2()),
+					round.key.NTildej[i],
+					round.key.H1j[i],
+					round.key.H2j[i],
+					round.key.PaillierSK)
+			}
 			us[j] = uIj
 			if err != nil {
 				errChs <- round.WrapError(err, Pj)
@@ -117,6 +132,11 @@ func (round *round3) Start() *tss.Error {
 
 	round.temp.theta = thelta
 	round.temp.sigma = sigma
+	// the MtA shares are summed into theta and sigma and no longer needed
+	common.ZeroBigInts(alphas...)
+	common.ZeroBigInts(us...)
+	common.ZeroBigInts(round.temp.betas...)
+	common.ZeroBigInts(round.temp.vs...)
 	r3msg := NewSignRound3Message(round.PartyID(), thelta)
 	round.temp.signRound3Messages[round.PartyID().Index] = r3msg
 	round.out <- r3msg
@@ -48,7 +48,9 @@ func (round *round4) Start() *tss.Error {
 		return round.WrapError(errors2.Wrapf(err, "NewZKProof(gamma, bigGamma)"))
 	}
 	round.temp.thetaInverse = thetaInverse
-	r4msg := NewSignRound4Message(round.PartyID(), round.temp.deCommit, piGamma)
+	// gamma was last needed for the proof of knowledge of Gamma
+	common.ZeroBigInts(round.temp.gamma)
+	r4msg := newSignRound4Message(round.PartyID(), round.temp.deCommit, piGamma, round.Params().CompressPoints())
 	round.temp.signRound4Messages[round.PartyID().Index] = r4msg
 	round.out <- r4msg
 
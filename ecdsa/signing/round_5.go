@@ -48,7 +48,7 @@ func (round *round5) Start() *tss.Error {
 		if err != nil {
 			return round.WrapError(errors.New("failed to unmarshal bigGamma proof"), Pj)
 		}
-		ok = proof.Verify(ContextJ, bigGammaJPoint)
+		ok = round.InsecureSkipProofs()&tss.InsecureSkipSchnorrProofs != 0 || proof.Verify(ContextJ, bigGammaJPoint)
 		if !ok {
 			return round.WrapError(errors.New("failed to prove bigGamma"), Pj)
 		}
@@ -65,9 +65,8 @@ func (round *round5) Start() *tss.Error {
 	ry := R.Y()
 	si := modN.Add(modN.Mul(round.temp.m, round.temp.k), modN.Mul(rx, round.temp.sigma))
 
-	// clear temp.w and temp.k from memory, lint ignore
-	round.temp.w = zero
-	round.temp.k = zero
+	// clear temp.w, temp.k and temp.sigma from memory
+	common.ZeroBigInts(round.temp.w, round.temp.k, round.temp.sigma)
 
 	li := common.GetRandomPositiveInt(round.Rand(), N)  // li
 	roI := common.GetRandomPositiveInt(round.Rand(), N) // pi
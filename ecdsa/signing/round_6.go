@@ -35,7 +35,7 @@ func (round *round6) Start() *tss.Error {
 		return round.WrapError(errors2.Wrapf(err, "NewZKVProof(bigVi, bigR, si, li)"))
 	}
 
-	r6msg := NewSignRound6Message(round.PartyID(), round.temp.DPower, piAi, piV)
+	r6msg := newSignRound6Message(round.PartyID(), round.temp.DPower, piAi, piV, round.Params().CompressPoints())
 	round.temp.signRound6Messages[round.PartyID().Index] = r6msg
 	round.out <- r6msg
 	return nil
@@ -52,6 +52,9 @@ func (round *round7) Start() *tss.Error {
 			return round.WrapError(errors2.Wrapf(err, "NewECPoint(bigAj)"), Pj)
 		}
 		bigAjs[j] = bigAj
+		if round.InsecureSkipProofs()&tss.InsecureSkipSchnorrProofs != 0 {
+			continue
+		}
 		pijA, err := r6msg.UnmarshalZKProof(round.Params().EC())
 		if err != nil || !pijA.Verify(ContextJ, bigAj) {
 			return round.WrapError(errors.New("schnorr verify for Aj failed"), Pj)
@@ -62,6 +65,9 @@ func (round *round7) Start() *tss.Error {
 		}
 	}
 
+	bigVjs[round.PartyID().Index] = round.temp.bigVi
+	round.temp.bigVjs = bigVjs
+
 	modN := common.ModInt(round.Params().EC().Params().N)
 	AX, AY := round.temp.bigAi.X(), round.temp.bigAi.Y()
 	minusM := modN.Sub(big.NewInt(0), round.temp.m)
@@ -83,6 +89,8 @@ func (round *round7) Start() *tss.Error {
 	TiX, TiY := round.Params().EC().ScalarMult(AX, AY, round.temp.li.Bytes())
 	round.temp.Ui = crypto.NewECPointNoCurveCheck(round.Params().EC(), UiX, UiY)
 	round.temp.Ti = crypto.NewECPointNoCurveCheck(round.Params().EC(), TiX, TiY)
+	// roi was last needed for Ui; li is revealed in round 9
+	common.ZeroBigInts(round.temp.roi)
 	cmt := commitments.NewHashCommitment(round.Rand(), UiX, UiY, TiX, TiY)
 	r7msg := NewSignRound7Message(round.PartyID(), cmt.C)
 	round.temp.signRound7Messages[round.PartyID().Index] = r7msg
@@ -44,7 +44,12 @@ func (round *round9) Start() *tss.Error {
 		return round.WrapError(errors.New("U doesn't equal T"), round.PartyID())
 	}
 
-	r9msg := NewSignRound9Message(round.PartyID(), round.temp.si)
+	// l_i is revealed with s_i so that an s_i that does not match V_i = R^s_i * g^l_i can be attributed.
+	// This is safe: l_i is a blinding factor drawn in round 5 for this signature only. It must stay secret until the
+	// check U = T above has passed, as a party that knew it could make T_i pass that check for any V_i. Revealing it
+	// after the check opens V_i, which holds nothing that s_i, revealed anyway, does not.
+	// Older parties ignore the field.
+	r9msg := NewSignRound9Message(round.PartyID(), round.temp.si, round.temp.li)
 	round.temp.signRound9Messages[round.PartyID().Index] = r9msg
 	round.out <- r9msg
 	return nil
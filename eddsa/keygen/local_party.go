@@ -11,8 +11,8 @@ import (
 	"fmt"
 	"math/big"
 
-	"github.com/bnb-chain/tss-lib/v2/common"
 	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ecies"
 	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
@@ -66,7 +66,7 @@ func NewLocalParty(
 	partyCount := params.PartyCount()
 	data := NewLocalPartySaveData(partyCount)
 	p := &LocalParty{
-		BaseParty: new(tss.BaseParty),
+		BaseParty: tss.NewBaseParty(func(tss.ParsedMessage) int { return partyCount }),
 		params:    params,
 		temp:      localTempData{},
 		data:      data,
@@ -103,18 +103,6 @@ func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroa
 	return p.Update(msg)
 }
 
-func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
-	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
-		return ok, err
-	}
-	// check that the message's "from index" will fit into the array
-	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
-		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
-			p.params.PartyCount(), msg.GetFrom().Index), msg.GetFrom())
-	}
-	return true, nil
-}
-
 func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
 	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
@@ -123,16 +111,23 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	fromPIdx := msg.GetFrom().Index
 
 	// switch/case is necessary to store any messages beyond current round
-	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	// replays are only rejected with Parameters.EnableReplayProtection. we expect the caller to apply spoofing protection.
 	switch msg.Content().(type) {
 	case *KGRound1Message:
 		p.temp.kgRound1Messages[fromPIdx] = msg
 	case *KGRound2Message1:
+		if p.params.ShareEncryptionKey() != nil {
+			decrypted, err := ecies.DecryptShareMessage(p.params, msg)
+			if err != nil {
+				return false, p.WrapError(err, msg.GetFrom())
+			}
+			msg = decrypted
+		}
 		p.temp.kgRound2Message1s[fromPIdx] = msg
 	case *KGRound2Message2:
 		p.temp.kgRound2Message2s[fromPIdx] = msg
 	default: // unrecognised message, just ignore!
-		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		p.params.Logger().Warningf("unrecognised message ignored: %v", msg)
 		return false, nil
 	}
 	return true, nil
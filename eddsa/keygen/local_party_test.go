@@ -7,13 +7,19 @@
 package keygen
 
 import (
+	"crypto/ecdsa"
+	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"math/big"
+	"net"
 	"os"
 	"runtime"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/decred/dcrd/dcrec/edwards/v2"
 	"github.com/ipfs/go-log"
@@ -211,6 +217,251 @@ keygen:
 	}
 }
 
+func TestE2EWithShareEncryption(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+
+	// PHASE: transport keys
+	transportKeys := make([]*ecdsa.PrivateKey, len(pIDs))
+	transportPubs := make([]*ecdsa.PublicKey, len(pIDs))
+	for j := range pIDs {
+		d := common.GetRandomPositiveInt(rand.Reader, tss.Edwards().Params().N)
+		x, y := tss.Edwards().ScalarBaseMult(d.Bytes())
+		transportKeys[j] = &ecdsa.PrivateKey{PublicKey: ecdsa.PublicKey{Curve: tss.Edwards(), X: x, Y: y}, D: d}
+		transportPubs[j] = &transportKeys[j].PublicKey
+	}
+
+	// PHASE: keygen
+	// messages are delivered one at a time so that the p2p shares can be inspected on the wire
+	parties := make([]*LocalParty, 0, len(pIDs))
+	errCh := make(chan *tss.Error, len(pIDs)*len(pIDs))
+	outCh := make(chan tss.Message, 1000)
+	endCh := make(chan *LocalPartySaveData, len(pIDs))
+	for i := range pIDs {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[i], len(pIDs), threshold)
+		params.SetShareEncryptionKeys(transportKeys[i], transportPubs)
+		P := NewLocalParty(params, outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	saves := make([]*LocalPartySaveData, 0, len(pIDs))
+	for len(saves) < len(pIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index != msg.GetFrom().Index {
+						test.SharedPartyUpdater(P, msg, errCh)
+					}
+				}
+				continue
+			}
+			// a relay must not see the share
+			wire, _, err := msg.WireBytes()
+			assert.NoError(t, err)
+			parsed, err := tss.ParseWireMessage(wire, msg.GetFrom(), msg.IsBroadcast())
+			assert.NoError(t, err)
+			share := parties[msg.GetFrom().Index].temp.shares[dest[0].Index].Share
+			assert.NotEqual(t, share.Bytes(), parsed.Content().(*KGRound2Message1).GetShare())
+			test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+
+		case save := <-endCh:
+			saves = append(saves, save)
+		}
+	}
+	for _, save := range saves {
+		assert.True(t, save.EDDSAPub.Equals(saves[0].EDDSAPub), "all parties should get the same public key")
+		index, err := save.OriginalIndex()
+		assert.NoError(t, err)
+		assert.True(t, crypto.ScalarBaseMult(tss.Edwards(), save.Xi).Equals(save.BigXj[index]), "ensure BigX_j == g^x_j")
+	}
+
+	// the public keys are required for every party
+	params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[0], len(pIDs), threshold)
+	params.SetShareEncryptionKeys(transportKeys[0], transportPubs[1:])
+	assert.Error(t, NewLocalParty(params, outCh, endCh).Start())
+}
+
+func TestReplayProtection(t *testing.T) {
+	setUp("info")
+	pIDs := tss.GenerateTestPartyIDs(2)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	outCh := make(chan tss.Message, 4)
+	endCh := make(chan *LocalPartySaveData, 2)
+	parties := make([]*LocalParty, len(pIDs))
+	for i := range pIDs {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[i], len(pIDs), 1)
+		params.EnableReplayProtection()
+		parties[i] = NewLocalParty(params, outCh, endCh).(*LocalParty)
+	}
+	assert.Nil(t, parties[0].Start())
+	r1msg := (<-outCh).(tss.ParsedMessage)
+	assert.Nil(t, parties[1].Start())
+
+	ok, err := parties[1].Update(r1msg)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	ok, err = parties[1].Update(r1msg)
+	assert.False(t, ok)
+	if assert.NotNil(t, err, "the replayed round 1 message must be rejected") {
+		assert.Equal(t, pIDs[0], err.Culprits()[0])
+	}
+
+	// a message stored before Start is recorded as well
+	params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[1], len(pIDs), 1)
+	params.EnableReplayProtection()
+	early := NewLocalParty(params, make(chan tss.Message, 4), endCh).(*LocalParty)
+	ok, err = early.Update(r1msg)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	assert.Nil(t, early.Start())
+	ok, err = early.Update(r1msg)
+	assert.False(t, ok)
+	assert.NotNil(t, err, "a replay of a message received before Start must be rejected")
+}
+
+func TestRoundTimeoutAndTransitions(t *testing.T) {
+	setUp("info")
+	pIDs := tss.GenerateTestPartyIDs(2)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	outChs := []chan tss.Message{make(chan tss.Message, 4), make(chan tss.Message, 4)}
+	endCh := make(chan *LocalPartySaveData, 2)
+	timeoutCh := make(chan *tss.Error, 1)
+	var transitions [][2]int
+	parties := make([]*LocalParty, len(pIDs))
+	for i := range pIDs {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[i], len(pIDs), 1)
+		if i == 0 {
+			params.SetRoundTimeout(200*time.Millisecond, func(err *tss.Error) { timeoutCh <- err })
+			params.SetOnRoundTransition(func(oldRound, newRound int) {
+				transitions = append(transitions, [2]int{oldRound, newRound})
+			})
+		}
+		parties[i] = NewLocalParty(params, outChs[i], endCh).(*LocalParty)
+		assert.Nil(t, parties[i].Start())
+	}
+
+	// party 0 gets the round 1 message of party 1 but none of its round 2 messages
+	ok, err := parties[0].Update((<-outChs[1]).(tss.ParsedMessage))
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	assert.Equal(t, [][2]int{{1, 2}}, transitions)
+
+	select {
+	case err := <-timeoutCh:
+		assert.Equal(t, 2, err.Round())
+		assert.Equal(t, []*tss.PartyID{pIDs[1]}, err.Culprits(), "the error should name the party that is missing")
+	case <-time.After(5 * time.Second):
+		assert.FailNow(t, "the round timeout did not fire")
+	}
+}
+
+func TestE2EOverMemoryTransport(t *testing.T) {
+	setUp("info")
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	memoryTransports, err := tss.NewMemoryTransports(pIDs...)
+	assert.NoError(t, err)
+	transports := make([]closableTransport, len(pIDs))
+	for i, transport := range memoryTransports {
+		transports[i] = transport
+	}
+	testE2EOverTransports(t, pIDs, transports)
+}
+
+func TestE2EOverTCPTransport(t *testing.T) {
+	setUp("info")
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	configs, err := test.TLSConfigs(pIDs)
+	assert.NoError(t, err)
+	listeners := make([]net.Listener, len(pIDs))
+	for i := range pIDs {
+		var err error
+		listeners[i], err = net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+	}
+	transports := make([]closableTransport, len(pIDs))
+	for i := range pIDs {
+		peers := make([]tss.TCPPeer, 0, len(pIDs)-1)
+		for j, pID := range pIDs {
+			if j != i {
+				peers = append(peers, tss.TCPPeer{ID: pID, Addr: listeners[j].Addr().String()})
+			}
+		}
+		var err error
+		transports[i], err = tss.NewTCPTransport(pIDs[i], listeners[i], peers, configs[i])
+		assert.NoError(t, err)
+	}
+	testE2EOverTransports(t, pIDs, transports)
+}
+
+type closableTransport interface {
+	tss.Transport
+	Close()
+}
+
+func testE2EOverTransports(t *testing.T, pIDs tss.SortedPartyIDs, transports []closableTransport) {
+	threshold := testThreshold
+	p2pCtx := tss.NewPeerContext(pIDs)
+
+	errCh := make(chan *tss.Error, len(pIDs)*2)
+	endCh := make(chan *LocalPartySaveData, len(pIDs))
+	for i := range pIDs {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[i], len(pIDs), threshold)
+		outCh := make(chan tss.Message, len(pIDs))
+		P := NewLocalParty(params, outCh, endCh)
+		transport := transports[i]
+		go func() {
+			for msg := range outCh {
+				if err := transport.Send(msg); err != nil {
+					errCh <- P.WrapError(err)
+				}
+			}
+		}()
+		go func() {
+			for {
+				if _, err := tss.ReceiveAndUpdate(P, transport); err != nil {
+					if !errors.Is(err.Cause(), io.EOF) {
+						errCh <- err
+					}
+					return
+				}
+			}
+		}()
+		go func() {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}()
+	}
+	defer func() {
+		for _, transport := range transports {
+			transport.Close()
+		}
+	}()
+
+	saves := make([]*LocalPartySaveData, 0, len(pIDs))
+	for len(saves) < len(pIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case save := <-endCh:
+			saves = append(saves, save)
+		}
+	}
+	for _, save := range saves {
+		assert.True(t, save.EDDSAPub.Equals(saves[0].EDDSAPub), "all parties should get the same public key")
+	}
+}
+
 func tryWriteTestFixtureFile(t *testing.T, index int, data LocalPartySaveData) {
 	fixtureFileName := makeTestFixtureFilePath(index)
 
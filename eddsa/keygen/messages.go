@@ -11,7 +11,6 @@ import (
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
-	"github.com/bnb-chain/tss-lib/v2/crypto"
 	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
 	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
 	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
@@ -57,6 +56,14 @@ func (m *KGRound1Message) UnmarshalCommitment() *big.Int {
 func NewKGRound2Message1(
 	to, from *tss.PartyID,
 	share *vss.Share,
+) tss.ParsedMessage {
+	return newKGRound2Message1(to, from, share.Share.Bytes())
+}
+
+// newKGRound2Message1 takes the share as bytes, which may be encrypted to the recipient's transport key
+func newKGRound2Message1(
+	to, from *tss.PartyID,
+	shareBz []byte,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
@@ -64,7 +71,7 @@ func NewKGRound2Message1(
 		IsBroadcast: false,
 	}
 	content := &KGRound2Message1{
-		Share: share.Share.Bytes(),
+		Share: shareBz,
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
@@ -91,11 +98,12 @@ func NewKGRound2Message2(
 		IsBroadcast: true,
 	}
 	dcBzs := common.BigIntsToBytes(deCommitment)
+	proofBzs := proof.Bytes()
 	content := &KGRound2Message2{
 		DeCommitment: dcBzs,
-		ProofAlphaX:  proof.Alpha.X().Bytes(),
-		ProofAlphaY:  proof.Alpha.Y().Bytes(),
-		ProofT:       proof.T.Bytes(),
+		ProofAlphaX:  proofBzs[0],
+		ProofAlphaY:  proofBzs[1],
+		ProofT:       proofBzs[2],
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
@@ -112,15 +120,5 @@ func (m *KGRound2Message2) UnmarshalDeCommitment() []*big.Int {
 }
 
 func (m *KGRound2Message2) UnmarshalZKProof(ec elliptic.Curve) (*schnorr.ZKProof, error) {
-	point, err := crypto.NewECPoint(
-		ec,
-		new(big.Int).SetBytes(m.GetProofAlphaX()),
-		new(big.Int).SetBytes(m.GetProofAlphaY()))
-	if err != nil {
-		return nil, err
-	}
-	return &schnorr.ZKProof{
-		Alpha: point,
-		T:     new(big.Int).SetBytes(m.GetProofT()),
-	}, nil
+	return schnorr.ZKProofFromBytes(ec, [][]byte{m.GetProofAlphaX(), m.GetProofAlphaY(), m.GetProofT()})
 }
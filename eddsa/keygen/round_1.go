@@ -33,11 +33,14 @@ func (round *round1) Start() *tss.Error {
 	round.number = 1
 	round.started = true
 	round.resetOK()
+	if err := round.ValidateShareEncryptionKeys(); err != nil {
+		return round.WrapError(err)
+	}
 
 	Pi := round.PartyID()
 	i := Pi.Index
 
-	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	round.temp.ssidNonce = round.SSIDNonce()
 	ssid, err := round.getSSID()
 	if err != nil {
 		return round.WrapError(err)
@@ -12,6 +12,7 @@ import (
 
 	errors2 "github.com/pkg/errors"
 
+	"github.com/bnb-chain/tss-lib/v2/crypto/ecies"
 	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
@@ -41,6 +42,13 @@ func (round *round2) Start() *tss.Error {
 			round.temp.kgRound2Message1s[j] = r2msg1
 			continue
 		}
+		if round.ShareEncryptionKey() != nil {
+			shareBz, err := ecies.EncryptShare(round.Params(), j, shares[j].Share)
+			if err != nil {
+				return round.WrapError(err, round.PartyID())
+			}
+			r2msg1 = newKGRound2Message1(Pj, round.PartyID(), shareBz)
+		}
 		round.temp.kgRound2Message1s[i] = r2msg1
 		round.out <- r2msg1
 	}
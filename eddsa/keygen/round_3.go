@@ -89,6 +89,10 @@ func (round *round3) Start() *tss.Error {
 				ch <- vssOut{err, nil}
 				return
 			}
+			if err = vss.ValidateCommitments(PjVs, round.Threshold()); err != nil {
+				ch <- vssOut{err, nil}
+				return
+			}
 			proof, err := r2msg2.UnmarshalZKProof(round.Params().EC())
 			if err != nil {
 				ch <- vssOut{errors.New("failed to unmarshal schnorr proof"), nil}
@@ -193,7 +197,7 @@ func (round *round3) Start() *tss.Error {
 	round.save.EDDSAPub = eddsaPubKey
 
 	// PRINT public key & private share
-	common.Logger.Debugf("%s public key: %x", round.PartyID(), eddsaPubKey)
+	round.Params().Logger().Debugf("%s public key: %x", round.PartyID(), eddsaPubKey)
 
 	round.end <- round.save
 	return nil
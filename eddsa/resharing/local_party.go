@@ -10,7 +10,6 @@ import (
 	"fmt"
 	"math/big"
 
-	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
 	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
 	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
@@ -59,6 +58,18 @@ type (
 	}
 )
 
+// senderCommitteeSize returns the size of the committee that sends each type of message, for the sender index check.
+func senderCommitteeSize(params *tss.ReSharingParameters) func(msg tss.ParsedMessage) int {
+	return func(msg tss.ParsedMessage) int {
+		switch msg.Content().(type) {
+		case *DGRound2Message, *DGRound4Message:
+			return len(params.NewParties().IDs())
+		default:
+			return len(params.OldParties().IDs())
+		}
+	}
+}
+
 // Exported, used in `tss` client
 // The `key` is read from and/or written to depending on whether this party is part of the old or the new committee.
 // You may optionally generate and set the LocalPreParams if you would like to use pre-generated safe primes and Paillier secret.
@@ -75,7 +86,7 @@ func NewLocalParty(
 		subset = keygen.BuildLocalSaveDataSubset(key, params.OldParties().IDs())
 	}
 	p := &LocalParty{
-		BaseParty: new(tss.BaseParty),
+		BaseParty: tss.NewBaseParty(senderCommitteeSize(params)),
 		params:    params,
 		temp:      localTempData{},
 		input:     subset,
@@ -113,25 +124,6 @@ func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroa
 	return p.Update(msg)
 }
 
-func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
-	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
-		return ok, err
-	}
-	// check that the message's "from index" will fit into the array
-	var maxFromIdx int
-	switch msg.Content().(type) {
-	case *DGRound2Message, *DGRound4Message:
-		maxFromIdx = len(p.params.NewParties().IDs()) - 1
-	default:
-		maxFromIdx = len(p.params.OldParties().IDs()) - 1
-	}
-	if maxFromIdx < msg.GetFrom().Index {
-		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
-			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
-	}
-	return true, nil
-}
-
 func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
 	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
@@ -140,7 +132,7 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	fromPIdx := msg.GetFrom().Index
 
 	// switch/case is necessary to store any messages beyond current round
-	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	// replays are only rejected with Parameters.EnableReplayProtection. we expect the caller to apply spoofing protection.
 	switch msg.Content().(type) {
 	case *DGRound1Message:
 		p.temp.dgRound1Messages[fromPIdx] = msg
@@ -153,7 +145,7 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	case *DGRound4Message:
 		p.temp.dgRound4Messages[fromPIdx] = msg
 	default: // unrecognised message, just ignore!
-		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		p.params.Logger().Warningf("unrecognised message ignored: %v", msg)
 		return false, nil
 	}
 	return true, nil
@@ -17,6 +17,7 @@ import (
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
 	. "github.com/bnb-chain/tss-lib/v2/eddsa/resharing"
 	"github.com/bnb-chain/tss-lib/v2/eddsa/signing"
@@ -228,3 +229,72 @@ signing:
 		}
 	}
 }
+
+func TestTamperedShareCulprit(t *testing.T) {
+	setUp("info")
+
+	threshold, newThreshold := testThreshold, testThreshold
+
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	oldP2PCtx := tss.NewPeerContext(oldPIDs)
+	newPIDs := tss.GenerateTestPartyIDs(testParticipants)
+	newP2PCtx := tss.NewPeerContext(newPIDs)
+	newPCount := len(newPIDs)
+
+	oldCommittee := make([]*LocalParty, 0, len(oldPIDs))
+	newCommittee := make([]*LocalParty, 0, newPCount)
+	errCh := make(chan *tss.Error, len(oldPIDs)+newPCount)
+	outCh := make(chan tss.Message, len(oldPIDs)+newPCount)
+	endCh := make(chan *keygen.LocalPartySaveData, len(oldPIDs)+newPCount)
+
+	updater := test.SharedPartyUpdater
+
+	for j, pID := range oldPIDs {
+		params := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
+		oldCommittee = append(oldCommittee, NewLocalParty(params, oldKeys[j], outCh, endCh).(*LocalParty))
+	}
+	for _, pID := range newPIDs {
+		params := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
+		newCommittee = append(newCommittee, NewLocalParty(params, keygen.NewLocalPartySaveData(newPCount), outCh, endCh).(*LocalParty))
+	}
+	for _, P := range append(newCommittee, oldCommittee...) {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// the share that old party 1 sends to new party 0 is tampered with
+	cheater, victim := oldPIDs[1], newPIDs[0]
+	for {
+		select {
+		case err := <-errCh:
+			assert.Equal(t, 4, err.Round())
+			assert.Equal(t, victim, err.Victim(), "the error should come from the party that got the tampered share")
+			assert.Equal(t, []*tss.PartyID{cheater}, err.Culprits(), "the error should name exactly the cheating party")
+			return
+
+		case <-endCh:
+			t.Fatal("the resharing should not end with a tampered share")
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if r3msg1, ok := msg.(tss.ParsedMessage).Content().(*DGRound3Message1); ok && msg.GetFrom() == cheater && dest[0] == victim {
+				share := new(big.Int).Add(new(big.Int).SetBytes(r3msg1.GetShare()), big.NewInt(1))
+				msg = NewDGRound3Message1(victim, cheater, &vss.Share{Share: share})
+			}
+			if msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+				for _, destP := range dest[:len(oldCommittee)] {
+					go updater(oldCommittee[destP.Index], msg, errCh)
+				}
+			}
+			if !msg.IsToOldCommittee() || msg.IsToOldAndNewCommittees() {
+				for _, destP := range dest {
+					go updater(newCommittee[destP.Index], msg, errCh)
+				}
+			}
+		}
+	}
+}
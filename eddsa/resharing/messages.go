@@ -44,9 +44,10 @@ func NewDGRound1Message(
 		IsBroadcast:      true,
 		IsToOldCommittee: false,
 	}
+	pubX, pubY := eddsaPub.CoordinateBytes()
 	content := &DGRound1Message{
-		EddsaPubX:   eddsaPub.X().Bytes(),
-		EddsaPubY:   eddsaPub.Y().Bytes(),
+		EddsaPubX:   pubX,
+		EddsaPubY:   pubY,
 		VCommitment: vct.Bytes(),
 	}
 	msg := tss.NewMessageWrapper(meta, content)
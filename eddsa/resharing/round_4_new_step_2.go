@@ -42,6 +42,8 @@ func (round *round4) Start() *tss.Error {
 	// 2-8.
 	modQ := common.ModInt(round.Params().EC().Params().N)
 	vjc := make([][]*crypto.ECPoint, len(round.OldParties().IDs()))
+	// who sent a share that did not verify
+	shareCulprits := make([]*tss.PartyID, 0, len(vjc))
 	for j := 0; j <= len(vjc)-1; j++ { // P1..P_t+1. Ps are indexed from 0 here
 		r1msg := round.temp.dgRound1Messages[j].Content().(*DGRound1Message)
 		r3msg2 := round.temp.dgRound3Message2s[j].Content().(*DGRound3Message2)
@@ -51,7 +53,7 @@ func (round *round4) Start() *tss.Error {
 		// 3. unpack flat "v" commitment content
 		vCmtDeCmt := commitments.HashCommitDecommit{C: vCj, D: vDj}
 		ok, flatVs := vCmtDeCmt.DeCommit()
-		if !ok || len(flatVs) != (round.NewThreshold()+1)*2 { // they're points so * 2
+		if !ok || len(flatVs)%2 != 0 { // they're points so * 2
 			// TODO collect culprits and return a list of them as per convention
 			return round.WrapError(errors.New("de-commitment of v_j0..v_jt failed"), round.Parties().IDs()[j])
 		}
@@ -59,6 +61,10 @@ func (round *round4) Start() *tss.Error {
 		if err != nil {
 			return round.WrapError(err, round.Parties().IDs()[j])
 		}
+		// the polynomial must be of the degree of the new threshold, otherwise the share cannot verify against it
+		if err = vss.ValidateCommitments(vj, round.NewThreshold()); err != nil {
+			return round.WrapError(err, round.Parties().IDs()[j])
+		}
 
 		for i, v := range vj {
 			vj[i] = v.EightInvEight()
@@ -73,11 +79,16 @@ func (round *round4) Start() *tss.Error {
 			Share:     new(big.Int).SetBytes(r3msg1.Share),
 		}
 		if ok := sharej.Verify(round.Params().EC(), round.NewThreshold(), vj); !ok {
-			return round.WrapError(errors.New("share from old committee did not pass Verify()"), round.Parties().IDs()[j])
+			round.Params().Logger().Warningf("share from old committee party %s did not pass Verify()", round.Parties().IDs()[j])
+			shareCulprits = append(shareCulprits, round.Parties().IDs()[j])
+			continue
 		}
 
 		newXi = new(big.Int).Add(newXi, sharej.Share)
 	}
+	if len(shareCulprits) > 0 {
+		return round.WrapError(errors.New("share from old committee did not pass Verify()"), shareCulprits...)
+	}
 
 	// 9-12.
 	var err error
@@ -104,20 +115,22 @@ func (round *round4) Start() *tss.Error {
 	for j := 0; j < round.NewPartyCount(); j++ {
 		Pj := round.NewParties().IDs()[j]
 		kj := Pj.KeyInt()
-		newBigXj := Vc[0]
 		newKs = append(newKs, kj)
-		z := new(big.Int).SetInt64(int64(1))
+		// newBigXj = sum(Vc[c] * kj^c)
+		zs := make([]*big.Int, round.NewThreshold()+1)
+		zs[0] = big.NewInt(1)
 		for c := 1; c <= round.NewThreshold(); c++ {
-			z = modQ.Mul(z, kj)
-			newBigXj, err = newBigXj.Add(Vc[c].ScalarMult(z))
-			if err != nil {
-				culprits = append(culprits, Pj)
-			}
+			zs[c] = modQ.Mul(zs[c-1], kj)
+		}
+		var newBigXj *crypto.ECPoint
+		newBigXj, err = crypto.MultiScalarMult(Vc, zs)
+		if err != nil {
+			culprits = append(culprits, Pj)
 		}
 		newBigXjs[j] = newBigXj
 	}
 	if len(culprits) > 0 {
-		return round.WrapError(errors.Wrapf(err, "newBigXj.Add(Vc[c].ScalarMult(z))"), culprits...)
+		return round.WrapError(errors.Wrapf(err, "crypto.MultiScalarMult(Vc, zs)"), culprits...)
 	}
 
 	round.temp.newXi = newXi
@@ -7,13 +7,13 @@
 package signing
 
 import (
+	"crypto/ed25519"
 	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/agl/ed25519/edwards25519"
 	"github.com/bnb-chain/tss-lib/v2/tss"
-	"github.com/decred/dcrd/dcrec/edwards/v2"
 )
 
 func (round *finalization) Start() *tss.Error {
@@ -50,13 +50,9 @@ func (round *finalization) Start() *tss.Error {
 		round.data.M = mBytes
 	}
 
-	pk := edwards.PublicKey{
-		Curve: round.Params().EC(),
-		X:     round.key.EDDSAPub.X(),
-		Y:     round.key.EDDSAPub.Y(),
-	}
-
-	ok := edwards.Verify(&pk, round.data.M, round.temp.r, s)
+	// verify as RFC 8032 verifiers do, e.g. libsodium, so that the signature is usable wherever Ed25519 is
+	encodedPubKey := ecPointToEncodedBytes(round.key.EDDSAPub.X(), round.key.EDDSAPub.Y())
+	ok := ed25519.Verify(encodedPubKey[:], round.data.M, round.data.Signature)
 	if !ok {
 		return round.WrapError(fmt.Errorf("signature verification failed"))
 	}
@@ -0,0 +1,54 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// adjustPublicKeyAndBigXj shifts the shared public key and the BigXj of `key` by the key derivation delta, so that they
+// match the derived child key.
+func adjustPublicKeyAndBigXj(key *keygen.LocalPartySaveData, keyDerivationDelta *big.Int) error {
+	var err error
+	gDelta := crypto.BaseMultTable(tss.Edwards()).VarTimeScalarBaseMult(keyDerivationDelta)
+	if key.EDDSAPub, err = key.EDDSAPub.Add(gDelta); err != nil {
+		common.Logger.Errorf("error in delta operation")
+		return err
+	}
+	// Suppose X_j has shamir shares X_j0,     X_j1,     ..., X_jn
+	// So X_j + D has shamir shares  X_j0 + D, X_j1 + D, ..., X_jn + D
+	for j := range key.BigXj {
+		if key.BigXj[j], err = key.BigXj[j].Add(gDelta); err != nil {
+			common.Logger.Errorf("error in delta operation")
+			return err
+		}
+	}
+	return nil
+}
+
+func derivingPubkeyFromPath(masterPub *crypto.ECPoint, chainCode []byte, path []uint32) (*big.Int, *ckd.ExtendedKey, error) {
+	ec := tss.Edwards()
+	extendedParentPk := &ckd.ExtendedKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: ec,
+			X:     masterPub.X(),
+			Y:     masterPub.Y(),
+		},
+		Depth:      0,
+		ChildIndex: 0,
+		ChainCode:  chainCode[:],
+		ParentFP:   []byte{0x00, 0x00, 0x00, 0x00},
+	}
+	return ckd.DeriveChildKeyFromHierarchy(path, extendedParentPk, ec.Params().N, ec)
+}
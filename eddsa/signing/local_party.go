@@ -49,7 +49,8 @@ type (
 		// temp data (thrown away after sign) / round 1
 		wi,
 		m,
-		ri *big.Int
+		ri,
+		keyDerivationDelta *big.Int
 		fullBytesLen int
 		pointRi      *crypto.ECPoint
 		deCommit     cmt.HashDeCommitment
@@ -66,6 +67,9 @@ type (
 	}
 )
 
+// NewLocalParty returns a party that signs `msg` with Ed25519 as specified in RFC 8032, with the challenge
+// SHA512(R || A || M), where M is the big-endian encoding of `msg`, padded to `fullBytesLen` bytes when it is given.
+// The signature verifies with crypto/ed25519.Verify over M; use NewLocalPartyFromMessage to sign arbitrary bytes.
 func NewLocalParty(
 	msg *big.Int,
 	params *tss.Parameters,
@@ -73,10 +77,25 @@ func NewLocalParty(
 	out chan<- tss.Message,
 	end chan<- *common.SignatureData,
 	fullBytesLen ...int,
+) tss.Party {
+	return NewLocalPartyWithKDD(msg, params, key, nil, out, end, fullBytesLen...)
+}
+
+// NewLocalPartyWithKDD returns a party with key derivation delta for HD support, see ckd.DeriveChildKeyEd25519.
+// `key` is the master key data; the party shifts its share and the public keys by the delta before signing, so the
+// signature verifies under the derived child public key.
+func NewLocalPartyWithKDD(
+	msg *big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	keyDerivationDelta *big.Int,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
 ) tss.Party {
 	partyCount := len(params.Parties().IDs())
 	p := &LocalParty{
-		BaseParty: new(tss.BaseParty),
+		BaseParty: tss.NewBaseParty(func(tss.ParsedMessage) int { return partyCount }),
 		params:    params,
 		keys:      keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
 		temp:      localTempData{},
@@ -90,6 +109,7 @@ func NewLocalParty(
 	p.temp.signRound3Messages = make([]tss.ParsedMessage, partyCount)
 
 	// temp data init
+	p.temp.keyDerivationDelta = keyDerivationDelta
 	p.temp.m = msg
 	if len(fullBytesLen) > 0 {
 		p.temp.fullBytesLen = fullBytesLen[0]
@@ -100,6 +120,20 @@ func NewLocalParty(
 	return p
 }
 
+// NewLocalPartyFromMessage returns a party that signs `rawMsg`. EdDSA hashes the message itself, so `hash` should be
+// common.HashNone unless the chain expects a pre-hashed message.
+func NewLocalPartyFromMessage(
+	rawMsg []byte,
+	hash common.MessageHash,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	msg := hash.Digest(rawMsg)
+	return NewLocalParty(new(big.Int).SetBytes(msg), params, key, out, end, len(msg))
+}
+
 func (p *LocalParty) FirstRound() tss.Round {
 	return newRound1(p.params, &p.keys, p.data, &p.temp, p.out, p.end)
 }
@@ -129,18 +163,6 @@ func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroa
 	return p.Update(msg)
 }
 
-func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
-	if msg.GetFrom() == nil || !msg.GetFrom().ValidateBasic() {
-		return false, p.WrapError(fmt.Errorf("received msg with an invalid sender: %s", msg))
-	}
-	// check that the message's "from index" will fit into the array
-	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
-		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
-			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
-	}
-	return p.BaseParty.ValidateMessage(msg)
-}
-
 func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
 	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
@@ -149,7 +171,7 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	fromPIdx := msg.GetFrom().Index
 
 	// switch/case is necessary to store any messages beyond current round
-	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	// replays are only rejected with Parameters.EnableReplayProtection. we expect the caller to apply spoofing protection.
 	switch msg.Content().(type) {
 	case *SignRound1Message:
 		p.temp.signRound1Messages[fromPIdx] = msg
@@ -161,7 +183,7 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 		p.temp.signRound3Messages[fromPIdx] = msg
 
 	default: // unrecognised message, just ignore!
-		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		p.params.Logger().Warningf("unrecognised message ignored: %v", msg)
 		return false, nil
 	}
 	return true, nil
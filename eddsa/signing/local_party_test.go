@@ -7,6 +7,9 @@
 package signing
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"math/big"
@@ -19,6 +22,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
 	"github.com/bnb-chain/tss-lib/v2/test"
 	"github.com/bnb-chain/tss-lib/v2/tss"
@@ -135,6 +139,7 @@ signing:
 
 				ok := edwards.Verify(&pk, msg.Bytes(), newSig.R, newSig.S)
 				assert.True(t, ok, "eddsa verify must pass")
+				assert.True(t, ed25519.Verify(pk.Serialize(), msg.Bytes(), parties[0].data.Signature), "RFC 8032 verify must pass")
 				t.Log("EDDSA signing test done.")
 				// END EDDSA verify
 
@@ -240,6 +245,7 @@ signing:
 
 				ok := edwards.Verify(&pk, msg, newSig.R, newSig.S)
 				assert.True(t, ok, "eddsa verify must pass")
+				assert.True(t, ed25519.Verify(pk.Serialize(), msg, parties[0].data.Signature), "RFC 8032 verify must pass")
 				t.Log("EDDSA signing test done.")
 				// END EDDSA verify
 
@@ -248,3 +254,142 @@ signing:
 		}
 	}
 }
+
+func TestE2EFromMessage(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// an arbitrary-length message with leading zeros, signed without a pre-hash
+	rawMsg := append([]byte{0, 0}, bytes.Repeat([]byte("tss-lib"), 100)...)
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs)*len(signPIDs))
+	outCh := make(chan tss.Message, 1000)
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	for i := range signPIDs {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewLocalPartyFromMessage(rawMsg, common.HashNone, params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	var sig *common.SignatureData
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index != msg.GetFrom().Index {
+					test.SharedPartyUpdater(P, msg, errCh)
+				}
+			}
+		case sig = <-endCh:
+			ended++
+		}
+	}
+	assert.Equal(t, rawMsg, sig.M)
+	pk := edwards.PublicKey{
+		Curve: tss.Edwards(),
+		X:     keys[0].EDDSAPub.X(),
+		Y:     keys[0].EDDSAPub.Y(),
+	}
+	assert.True(t, ed25519.Verify(pk.Serialize(), rawMsg, sig.Signature), "eddsa verify must pass")
+	assert.Equal(t, sig.Signature, sig.Ed25519Bytes())
+	assert.True(t, ed25519.Verify(pk.Serialize(), rawMsg, sig.Ed25519Bytes()), "eddsa verify must pass with Ed25519Bytes")
+}
+
+func TestE2EWithHDKeyDerivation(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	masterPk := edwards.PublicKey{
+		Curve: tss.Edwards(),
+		X:     keys[0].EDDSAPub.X(),
+		Y:     keys[0].EDDSAPub.Y(),
+	}
+
+	chainCode := make([]byte, 32)
+	_, _ = rand.Read(chainCode)
+	keyDerivationDelta, extendedChildPk, err := derivingPubkeyFromPath(keys[0].EDDSAPub, chainCode, []uint32{44, 501, 0})
+	assert.NoError(t, err, "there should not be an error deriving the child public key")
+
+	rawMsg := []byte("tss-lib hd")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs)*len(signPIDs))
+	outCh := make(chan tss.Message, 1000)
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	for i := range signPIDs {
+		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewLocalPartyWithKDD(new(big.Int).SetBytes(rawMsg), params, keys[i], keyDerivationDelta, outCh, endCh, len(rawMsg)).(*LocalParty)
+		parties = append(parties, P)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	var sig *common.SignatureData
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index != msg.GetFrom().Index {
+					test.SharedPartyUpdater(P, msg, errCh)
+				}
+			}
+		case sig = <-endCh:
+			ended++
+		}
+	}
+	childPk := edwards.PublicKey{
+		Curve: tss.Edwards(),
+		X:     extendedChildPk.X,
+		Y:     extendedChildPk.Y,
+	}
+	edSig, err := edwards.ParseSignature(sig.Signature)
+	assert.NoError(t, err)
+	assert.True(t, edwards.Verify(&childPk, rawMsg, edSig.R, edSig.S), "eddsa verify must pass with the child key")
+	assert.True(t, ed25519.Verify(childPk.Serialize(), rawMsg, sig.Signature), "eddsa verify must pass with the child key")
+	assert.False(t, ed25519.Verify(masterPk.Serialize(), rawMsg, sig.Signature), "the master key must not verify")
+	assert.True(t, keys[0].EDDSAPub.Equals(crypto.NewECPointNoCurveCheck(tss.Edwards(), masterPk.X, masterPk.Y)),
+		"the caller's key data must not be modified")
+}
+
+func TestBatchVerify(t *testing.T) {
+	const n, forged1, forged2 = 52, 7, 40
+	pubKeys := make([]*crypto.ECPoint, n)
+	msgs := make([][]byte, n)
+	sigs := make([]*common.SignatureData, n)
+	for i := 0; i < n; i++ {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		pubKeys[i], err = crypto.ECPointFromBytes(tss.Edwards(), pub)
+		assert.NoError(t, err)
+		msgs[i] = []byte(fmt.Sprintf("message %d", i))
+		sigs[i] = &common.SignatureData{Signature: ed25519.Sign(priv, msgs[i]), M: msgs[i]}
+	}
+	ok, failed := BatchVerify(pubKeys, msgs, sigs)
+	assert.True(t, ok, "the valid signatures should verify as a batch")
+	assert.Empty(t, failed)
+
+	// a signature of another message and a signature with an altered s
+	sigs[forged1] = &common.SignatureData{Signature: sigs[forged1+1].Signature, M: msgs[forged1]}
+	forgedSig := append([]byte{}, sigs[forged2].Signature...)
+	forgedSig[33] ^= 0x01
+	sigs[forged2] = &common.SignatureData{Signature: forgedSig, M: msgs[forged2]}
+	ok, failed = BatchVerify(pubKeys, msgs, sigs)
+	assert.False(t, ok, "the forged signatures should fail the batch")
+	assert.Equal(t, []int{forged1, forged2}, failed)
+
+	ok, _ = BatchVerify(pubKeys, msgs[1:], sigs)
+	assert.False(t, ok)
+}
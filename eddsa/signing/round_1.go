@@ -9,7 +9,6 @@ package signing
 import (
 	"errors"
 	"fmt"
-	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
@@ -34,7 +33,7 @@ func (round *round1) Start() *tss.Error {
 	round.started = true
 	round.resetOK()
 
-	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	round.temp.ssidNonce = round.SSIDNonce()
 	var err error
 	round.temp.ssid, err = round.getSSID()
 	if err != nil {
@@ -99,6 +98,18 @@ func (round *round1) prepare() error {
 	xi := round.key.Xi
 	ks := round.key.Ks
 
+	if round.temp.keyDerivationDelta != nil {
+		// adding the key derivation delta to the xi's
+		// Suppose x has shamir shares x_0,     x_1,     ..., x_n
+		// So x + D has shamir shares  x_0 + D, x_1 + D, ..., x_n + D
+		mod := common.ModInt(round.Params().EC().Params().N)
+		xi = mod.Add(round.temp.keyDerivationDelta, xi)
+		round.key.Xi = xi
+		if err := adjustPublicKeyAndBigXj(round.key, round.temp.keyDerivationDelta); err != nil {
+			return err
+		}
+	}
+
 	if round.Threshold()+1 > len(ks) {
 		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
 	}
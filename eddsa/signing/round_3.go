@@ -53,10 +53,11 @@ func (round *round3) Start() *tss.Error {
 		}
 
 		Rj, err := crypto.NewECPoint(round.Params().EC(), coordinates[0], coordinates[1])
-		Rj = Rj.EightInvEight()
 		if err != nil {
 			return round.WrapError(errors.Wrapf(err, "NewECPoint(Rj)"), Pj)
 		}
+		// clear the small-order component of Rj, as the cofactor-less verification of RFC 8032 would fail on it
+		Rj = Rj.EightInvEight()
 		proof, err := r2msg.UnmarshalZKProof(round.Params().EC())
 		if err != nil {
 			return round.WrapError(errors.New("failed to unmarshal Rj proof"), Pj)
@@ -75,7 +76,7 @@ func (round *round3) Start() *tss.Error {
 	R.ToBytes(&encodedR)
 	encodedPubKey := ecPointToEncodedBytes(round.key.EDDSAPub.X(), round.key.EDDSAPub.Y())
 
-	// h = hash512(k || A || M)
+	// h = hash512(R || A || M), the challenge of RFC 8032 (5.1.6)
 	h := sha512.New()
 	h.Reset()
 	h.Write(encodedR[:])
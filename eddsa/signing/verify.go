@@ -0,0 +1,97 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// the bit length of the random weights of BatchVerify; a forged signature passes with a probability of 2^-128
+const batchVerifyWeightBits = 128
+
+type batchVerifyInput struct {
+	R, A *crypto.ECPoint
+	s, k *big.Int
+}
+
+// BatchVerify checks the Ed25519 signatures `sigs` of the messages `msgs` under the public keys `pubKeys`, matched by
+// index, with a single multi-scalar multiplication over a random linear combination of the verification equations:
+// 8 * sum(z_i * s_i) * B == 8 * (sum(z_i * R_i) + sum(z_i * k_i * A_i)). It returns whether all the signatures are
+// valid and, if not, the indices of the ones that fail the same equation on their own.
+// The equation is the cofactored one of RFC 8032, which unlike the random linear combination of the cofactorless one
+// never rejects a valid batch; it accepts an R or a public key with a small-order component which ed25519.Verify
+// rejects, so verify a signature on its own when that matters.
+func BatchVerify(pubKeys []*crypto.ECPoint, msgs [][]byte, sigs []*common.SignatureData) (bool, []int) {
+	if len(sigs) == 0 || len(sigs) != len(pubKeys) || len(sigs) != len(msgs) {
+		return false, nil
+	}
+	ec := tss.Edwards()
+	modN := common.ModInt(ec.Params().N)
+	inputs := make([]*batchVerifyInput, len(sigs))
+	points := make([]*crypto.ECPoint, 0, 2*len(sigs))
+	weights := make([]*big.Int, 0, 2*len(sigs))
+	sumS := big.NewInt(0)
+	for i := range sigs {
+		if inputs[i] = newBatchVerifyInput(pubKeys[i], msgs[i], sigs[i]); inputs[i] == nil {
+			continue
+		}
+		z := common.MustGetRandomInt(rand.Reader, batchVerifyWeightBits)
+		points = append(points, inputs[i].R, inputs[i].A)
+		weights = append(weights, z, modN.Mul(z, inputs[i].k))
+		sumS = modN.Add(sumS, modN.Mul(z, inputs[i].s))
+	}
+	if len(points) == 2*len(sigs) {
+		lhs, err := crypto.MultiScalarMult(points, weights)
+		if err == nil && lhs.EightInvEight().Equals(crypto.BaseMultTable(ec).VarTimeScalarBaseMult(sumS)) {
+			return true, nil
+		}
+	}
+	// find the signatures that do not verify
+	return false, crypto.BatchVerify(len(sigs), 0, func(i int) bool {
+		in := inputs[i]
+		if in == nil {
+			return false
+		}
+		rhs, err := in.R.Add(in.A.ScalarMult(in.k))
+		return err == nil && rhs.EightInvEight().Equals(crypto.BaseMultTable(ec).VarTimeScalarBaseMult(in.s))
+	})
+}
+
+// newBatchVerifyInput decodes the signature `sig` and computes its challenge k = SHA-512(R || A || M), returning nil
+// if the signature or the public key is malformed.
+func newBatchVerifyInput(pubKey *crypto.ECPoint, msg []byte, sig *common.SignatureData) *batchVerifyInput {
+	ec := tss.Edwards()
+	if pubKey == nil || !pubKey.ValidateBasic() || !tss.SameCurve(pubKey.Curve(), ec) ||
+		sig == nil || len(sig.GetSignature()) != 64 {
+		return nil
+	}
+	R, err := crypto.ECPointFromBytes(ec, sig.GetSignature()[:32])
+	if err != nil {
+		return nil
+	}
+	s := encodedBytesToBigInt(copyBytes(sig.GetSignature()[32:]))
+	if s.Cmp(ec.Params().N) >= 0 {
+		// RFC 8032 rejects a non-canonical s
+		return nil
+	}
+	h := sha512.New()
+	h.Write(sig.GetSignature()[:32])
+	h.Write(pubKey.Bytes())
+	h.Write(msg)
+	digest := h.Sum(nil)
+	for i, j := 0, len(digest)-1; i < j; i, j = i+1, j-1 {
+		digest[i], digest[j] = digest[j], digest[i]
+	}
+	k := new(big.Int).Mod(new(big.Int).SetBytes(digest), ec.Params().N)
+	return &batchVerifyInput{R: R, A: pubKey, s: s, k: k}
+}
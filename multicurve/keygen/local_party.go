@@ -0,0 +1,210 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package keygen runs the ECDSA (secp256k1) and EdDSA (ed25519) keygen protocols side by side in one ceremony
+// for the same set of parties, so that multi-chain wallets only need to coordinate a single session.
+//
+// The two keys are completely independent: each protocol samples its own secret contribution from its own
+// randomness and no share, commitment or proof is reused between them. The shares of the two keys must never be
+// correlated (e.g. derived from one another or from the same deterministic seed), as that could let a leak of one key
+// help an attacker recover the other.
+package keygen
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	eddsakeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	TaskName = "multicurve-keygen"
+)
+
+var (
+	ecdsaKeygenPackage = proto.MessageName(&ecdsakeygen.KGRound1Message{}).Parent()
+	eddsaKeygenPackage = proto.MessageName(&eddsakeygen.KGRound1Message{}).Parent()
+)
+
+type (
+	// LocalParty runs an ECDSA keygen party and an EdDSA keygen party for the same PartyID.
+	// Outbound messages of both protocols are sent through the same `out` channel and inbound messages are routed
+	// to the right protocol by their type, so a single transport can carry the whole ceremony.
+	LocalParty struct {
+		ecdsaParty, eddsaParty tss.Party
+
+		mtx       sync.Mutex
+		ecdsaEnd  chan *ecdsakeygen.LocalPartySaveData
+		eddsaEnd  chan *eddsakeygen.LocalPartySaveData
+		ecdsaData *ecdsakeygen.LocalPartySaveData
+		eddsaData *eddsakeygen.LocalPartySaveData
+		end       chan<- *LocalPartySaveData
+		finished  bool
+	}
+
+	// LocalPartySaveData holds the save data of both keys; each should be stored as usual for its own protocol.
+	LocalPartySaveData struct {
+		ECDSA *ecdsakeygen.LocalPartySaveData
+		EdDSA *eddsakeygen.LocalPartySaveData
+	}
+)
+
+// NewLocalParty returns a party that generates a secp256k1 and an ed25519 key in one ceremony.
+// `ecdsaParams` and `eddsaParams` must describe the same party set, party and threshold, on tss.S256() and
+// tss.Edwards() respectively. The optional pre-params are used by the ECDSA protocol; EdDSA keygen needs none.
+func NewLocalParty(
+	ecdsaParams, eddsaParams *tss.Parameters,
+	out chan<- tss.Message,
+	end chan<- *LocalPartySaveData,
+	optionalPreParams ...ecdsakeygen.LocalPreParams,
+) (*LocalParty, error) {
+	if err := checkParameters(ecdsaParams, eddsaParams); err != nil {
+		return nil, err
+	}
+	p := &LocalParty{
+		ecdsaEnd: make(chan *ecdsakeygen.LocalPartySaveData, 1),
+		eddsaEnd: make(chan *eddsakeygen.LocalPartySaveData, 1),
+		end:      end,
+	}
+	p.ecdsaParty = ecdsakeygen.NewLocalParty(ecdsaParams, out, p.ecdsaEnd, optionalPreParams...)
+	p.eddsaParty = eddsakeygen.NewLocalParty(eddsaParams, out, p.eddsaEnd)
+	return p, nil
+}
+
+// Start starts both protocols. Generating the ECDSA pre-params may take a while if none were given.
+func (p *LocalParty) Start() *tss.Error {
+	if err := p.eddsaParty.Start(); err != nil {
+		return err
+	}
+	if err := p.ecdsaParty.Start(); err != nil {
+		return err
+	}
+	p.collect()
+	return nil
+}
+
+// Update routes a message to the protocol that it belongs to.
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	party, err := p.route(msg)
+	if err != nil {
+		return false, err
+	}
+	if ok, err = party.Update(msg); ok && err == nil {
+		p.collect()
+	}
+	return
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.wrapError(err)
+	}
+	return p.Update(msg)
+}
+
+// Running returns true while either protocol is still running.
+func (p *LocalParty) Running() bool {
+	return p.ecdsaParty.Running() || p.eddsaParty.Running()
+}
+
+// WaitingFor returns the parties that either protocol is waiting for, without duplicates.
+func (p *LocalParty) WaitingFor() []*tss.PartyID {
+	seen := make(map[int]bool)
+	ids := make([]*tss.PartyID, 0)
+	for _, id := range append(p.ecdsaParty.WaitingFor(), p.eddsaParty.WaitingFor()...) {
+		if !seen[id.Index] {
+			seen[id.Index] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.ecdsaParty.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, ecdsa: %s, eddsa: %s", p.PartyID(), p.ecdsaParty.String(), p.eddsaParty.String())
+}
+
+// ----- //
+
+func (p *LocalParty) route(msg tss.ParsedMessage) (tss.Party, *tss.Error) {
+	if msg == nil || msg.Content() == nil {
+		return nil, p.wrapError(errors.New("received nil msg"))
+	}
+	switch proto.MessageName(msg.Content()).Parent() {
+	case ecdsaKeygenPackage:
+		return p.ecdsaParty, nil
+	case eddsaKeygenPackage:
+		return p.eddsaParty, nil
+	}
+	return nil, p.wrapError(fmt.Errorf("received msg of an unexpected type: %s", msg.Type()), msg.GetFrom())
+}
+
+// collect picks up the output of each protocol and emits the combined save data once both have finished
+func (p *LocalParty) collect() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	select {
+	case data := <-p.ecdsaEnd:
+		p.ecdsaData = data
+	default:
+	}
+	select {
+	case data := <-p.eddsaEnd:
+		p.eddsaData = data
+	default:
+	}
+	if p.finished || p.ecdsaData == nil || p.eddsaData == nil {
+		return
+	}
+	p.finished = true
+	p.end <- &LocalPartySaveData{ECDSA: p.ecdsaData, EdDSA: p.eddsaData}
+}
+
+func (p *LocalParty) wrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, TaskName, -1, p.PartyID(), culprits...)
+}
+
+func checkParameters(ecdsaParams, eddsaParams *tss.Parameters) error {
+	if ecdsaParams == nil || eddsaParams == nil {
+		return errors.New("both the ECDSA and EdDSA parameters are required")
+	}
+	if !tss.SameCurve(ecdsaParams.EC(), tss.S256()) || !tss.SameCurve(eddsaParams.EC(), tss.Edwards()) {
+		return errors.New("the ECDSA parameters must use secp256k1 and the EdDSA parameters must use ed25519")
+	}
+	if ecdsaParams.PartyID().KeyInt().Cmp(eddsaParams.PartyID().KeyInt()) != 0 ||
+		ecdsaParams.PartyCount() != eddsaParams.PartyCount() ||
+		ecdsaParams.Threshold() != eddsaParams.Threshold() {
+		return errors.New("the ECDSA and EdDSA parameters must have the same party, party count and threshold")
+	}
+	ecdsaIDs, eddsaIDs := ecdsaParams.Parties().IDs(), eddsaParams.Parties().IDs()
+	if len(ecdsaIDs) != len(eddsaIDs) {
+		return errors.New("the ECDSA and EdDSA parameters must have the same parties")
+	}
+	for j := range ecdsaIDs {
+		if ecdsaIDs[j].KeyInt().Cmp(eddsaIDs[j].KeyInt()) != 0 {
+			return errors.New("the ECDSA and EdDSA parameters must have the same parties")
+		}
+	}
+	// a shared deterministic source would correlate the secret contributions of the two keys
+	if a, b := ecdsaParams.PartialKeyRand(), eddsaParams.PartialKeyRand(); a != nil &&
+		reflect.TypeOf(a).Comparable() && reflect.TypeOf(a) == reflect.TypeOf(b) && a == b &&
+		a != rand.Reader {
+		return errors.New("the ECDSA and EdDSA parameters must not share a partial key random source")
+	}
+	return nil
+}
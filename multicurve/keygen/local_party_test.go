@@ -0,0 +1,114 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = 3
+	testThreshold    = 1
+)
+
+func TestE2EConcurrent(t *testing.T) {
+	fixtures, _, err := ecdsakeygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs)*4)
+	endCh := make(chan *LocalPartySaveData, len(pIDs))
+
+	for i := 0; i < len(pIDs); i++ {
+		ecdsaParams := tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		eddsaParams := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		P, err := NewLocalParty(ecdsaParams, eddsaParams, outCh, endCh, fixtures[i].LocalPreParams)
+		assert.NoError(t, err)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	updater := func(P *LocalParty, msg tss.Message) {
+		bz, _, err := msg.WireBytes()
+		if err != nil {
+			errCh <- P.wrapError(err)
+			return
+		}
+		if _, err := P.UpdateFromBytes(bz, msg.GetFrom(), msg.IsBroadcast()); err != nil {
+			errCh <- err
+		}
+	}
+
+	saves := make([]*LocalPartySaveData, 0, len(pIDs))
+keygen:
+	for {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			if dest := msg.GetTo(); dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index != msg.GetFrom().Index {
+						go updater(P, msg)
+					}
+				}
+			} else {
+				go updater(parties[dest[0].Index], msg)
+			}
+
+		case save := <-endCh:
+			saves = append(saves, save)
+			if len(saves) == len(pIDs) {
+				break keygen
+			}
+		}
+	}
+
+	for _, save := range saves {
+		assert.NotNil(t, save.ECDSA)
+		assert.NotNil(t, save.EdDSA)
+		assert.True(t, save.ECDSA.ECDSAPub.Equals(saves[0].ECDSA.ECDSAPub), "all parties should agree on the ECDSA key")
+		assert.True(t, save.EdDSA.EDDSAPub.Equals(saves[0].EdDSA.EDDSAPub), "all parties should agree on the EdDSA key")
+		assert.NotEqual(t, 0, save.ECDSA.Xi.Cmp(save.EdDSA.Xi), "the shares of the two keys should be independent")
+	}
+}
+
+func TestNewLocalPartyChecksParameters(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	out, end := make(chan tss.Message), make(chan *LocalPartySaveData)
+
+	ecdsaParams := tss.NewParameters(tss.S256(), p2pCtx, pIDs[0], len(pIDs), testThreshold)
+	_, err := NewLocalParty(ecdsaParams, ecdsaParams, out, end)
+	assert.Error(t, err, "the EdDSA parameters must be on ed25519")
+
+	eddsaParams := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[1], len(pIDs), testThreshold)
+	_, err = NewLocalParty(ecdsaParams, eddsaParams, out, end)
+	assert.Error(t, err, "both parameters must be for the same party")
+
+	eddsaParams = tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[0], len(pIDs), testThreshold)
+	seeded := rand.New(rand.NewSource(1))
+	ecdsaParams.SetPartialKeyRand(seeded)
+	eddsaParams.SetPartialKeyRand(seeded)
+	_, err = NewLocalParty(ecdsaParams, eddsaParams, out, end)
+	assert.Error(t, err, "both parameters must not share a deterministic random source")
+}
@@ -0,0 +1,36 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/sha256"
+	"math/big"
+)
+
+// BIP-340 tags of the tagged hashes
+const (
+	tagAux       = "BIP0340/aux"
+	tagNonce     = "BIP0340/nonce"
+	tagChallenge = "BIP0340/challenge"
+)
+
+// taggedHash returns the BIP-340 tagged hash SHA256(SHA256(tag) || SHA256(tag) || x).
+func taggedHash(tag string, x ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, b := range x {
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
+
+// bytes32 returns the 32-byte big-endian encoding of `x`, bytes(x) in BIP-340.
+func bytes32(x *big.Int) []byte {
+	return x.FillBytes(make([]byte, 32))
+}
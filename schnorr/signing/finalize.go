@@ -0,0 +1,98 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	modN := common.ModInt(round.Params().EC().Params().N)
+	sumS := round.temp.si
+	for j := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		r3msg := round.temp.signRound3Messages[j].Content().(*SignRound3Message)
+		sumS = modN.Add(sumS, r3msg.UnmarshalS())
+	}
+
+	// save the signature for final output
+	round.data.R = bytes32(round.temp.bigR.X())
+	round.data.S = bytes32(sumS)
+	round.data.Signature = append(append([]byte{}, round.data.R...), round.data.S...)
+	round.data.M = bytes32(round.temp.m)
+
+	pk, err := schnorr.ParsePubKey(bytes32(round.key.ECDSAPub.X()))
+	if err != nil {
+		return round.WrapError(err)
+	}
+	sig, err := schnorr.ParseSignature(round.data.Signature)
+	if err != nil {
+		return round.WrapError(err, round.sShareCulprits()...)
+	}
+	if !sig.Verify(round.data.M, pk) {
+		return round.WrapError(fmt.Errorf("signature verification failed"), round.sShareCulprits()...)
+	}
+	round.end <- round.data
+
+	return nil
+}
+
+// sShareCulprits returns the parties whose s_j does not satisfy s_j*G = Rj + e*Wj, with Rj and Wj negated as the
+// nonce and the share were in round 3.
+func (round *finalization) sShareCulprits() []*tss.PartyID {
+	ec := round.Params().EC()
+	culprits := make([]*tss.PartyID, 0)
+	for j, Pj := range round.Parties().IDs() {
+		Rj, Wj := round.temp.pointRjs[j], round.temp.bigWs[j]
+		if round.temp.bigR.Y().Bit(0) == 1 {
+			Rj = Rj.Neg()
+		}
+		if round.key.ECDSAPub.Y().Bit(0) == 1 {
+			Wj = Wj.Neg()
+		}
+		sj := round.temp.si
+		if j != round.PartyID().Index {
+			sj = round.temp.signRound3Messages[j].Content().(*SignRound3Message).UnmarshalS()
+		}
+		expected, err := Rj.Add(Wj.ScalarMult(round.temp.e))
+		if err != nil || !crypto.ScalarBaseMult(ec, sj).Equals(expected) {
+			culprits = append(culprits, Pj)
+		}
+	}
+	return culprits
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,143 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	assert.Equal(t, testThreshold+1, len(keys))
+	assert.Equal(t, testThreshold+1, len(signPIDs))
+
+	// PHASE: signing
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	hash := sha256.Sum256([]byte("a taproot spend"))
+	msg := new(big.Int).SetBytes(hash[:])
+	// init the parties
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+
+		P := NewLocalParty(msg, params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case data := <-endCh:
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
+				t.Logf("Done. Received signature data from %d participants", ended)
+
+				// BEGIN BIP-340 verify
+				assert.Len(t, data.Signature, 64)
+				assert.Equal(t, hash[:], data.M)
+				pk, err := schnorr.ParsePubKey(keys[0].ECDSAPub.X().FillBytes(make([]byte, 32)))
+				assert.NoError(t, err)
+				sig, err := schnorr.ParseSignature(data.Signature)
+				if assert.NoError(t, err) {
+					assert.True(t, sig.Verify(hash[:], pk), "BIP-340 signature should verify")
+				}
+				for _, P := range parties {
+					assert.Equal(t, data.Signature, P.data.Signature)
+				}
+
+				// a wrong s_j is blamed on its sender
+				P := parties[0]
+				round := &finalization{&round3{&round2{&round1{
+					&base{P.params, &P.keys, P.data, &P.temp, nil, nil, make([]bool, len(signPIDs)), true, 4},
+				}}}}
+				assert.Empty(t, round.sShareCulprits())
+				P.temp.si = new(big.Int).Add(P.temp.si, big.NewInt(1))
+				assert.Equal(t, []*tss.PartyID{P.PartyID()}, round.sShareCulprits())
+				t.Log("BIP-340 signing test done.")
+				// END BIP-340 verify
+
+				break signing
+			}
+		}
+	}
+}
+
+func TestStartRejectsInvalidMessage(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+
+	tooLong := new(big.Int).Lsh(big.NewInt(1), 256)
+	P := NewLocalParty(tooLong, params, keys[0], make(chan tss.Message, len(signPIDs)), nil)
+	assert.NotNil(t, P.Start(), "a message longer than 32 bytes should be rejected")
+}
@@ -0,0 +1,134 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// round 1 represents round 1 of the BIP-340 Schnorr signing protocol
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	round.temp.ssidNonce = round.SSIDNonce()
+	var err error
+	round.temp.ssid, err = round.getSSID()
+	if err != nil {
+		return round.WrapError(err)
+	}
+	// 1. select ri as the synthetic nonce of BIP-340, over wi and fresh randomness
+	aux := make([]byte, 32)
+	if _, err = io.ReadFull(round.Rand(), aux); err != nil {
+		return round.WrapError(err)
+	}
+	t := taggedHash(tagAux, aux)
+	for k, b := range bytes32(round.temp.wi) {
+		t[k] ^= b
+	}
+	ri := new(big.Int).SetBytes(taggedHash(tagNonce, t, bytes32(round.key.ECDSAPub.X()), bytes32(round.temp.m)))
+	ri.Mod(ri, round.Params().EC().Params().N)
+	if ri.Sign() == 0 {
+		return round.WrapError(errors.New("the nonce is zero"))
+	}
+
+	// 2. make commitment
+	pointRi := crypto.ScalarBaseMult(round.Params().EC(), ri)
+	cmt := commitments.NewHashCommitment(round.Rand(), pointRi.X(), pointRi.Y())
+
+	// 3. store r1 message pieces
+	round.temp.ri = ri
+	round.temp.pointRi = pointRi
+	round.temp.deCommit = cmt.D
+
+	i := round.PartyID().Index
+	round.ok[i] = true
+
+	// 4. broadcast commitment
+	r1msg := NewSignRound1Message(round.PartyID(), cmt.C)
+	round.temp.signRound1Messages[i] = r1msg
+	round.out <- r1msg
+
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// ----- //
+
+// helper to call into PrepareForSigning()
+func (round *round1) prepare() error {
+	if round.Params().EC().Params() != tss.S256().Params() {
+		return errors.New("BIP-340 signatures require the secp256k1 curve")
+	}
+	if err := round.key.CheckCurve(round.Params().EC()); err != nil {
+		return err
+	}
+	if round.temp.m == nil || round.temp.m.Sign() < 0 || round.temp.m.BitLen() > 256 {
+		return errors.New("the message must be a 32-byte unsigned integer")
+	}
+
+	i := round.PartyID().Index
+
+	xi := round.key.Xi
+	ks := round.key.Ks
+	bigXs := round.key.BigXj
+
+	if round.Threshold()+1 > len(ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+	}
+	wi, bigWs := PrepareForSigning(round.Params().EC(), i, len(ks), xi, ks, bigXs)
+
+	round.temp.wi = wi
+	round.temp.bigWs = bigWs
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	// 1-5. de-commit and verify each Rj
+	i := round.PartyID().Index
+	round.temp.pointRjs[i] = round.temp.pointRi
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+
+		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		msg := round.temp.signRound2Messages[j]
+		r2msg := msg.Content().(*SignRound2Message)
+		cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.cjs[j], D: r2msg.UnmarshalDeCommitment()}
+		ok, coordinates := cmtDeCmt.DeCommit()
+		if !ok {
+			return round.WrapError(errors.New("de-commitment verify failed"), Pj)
+		}
+		if len(coordinates) != 2 {
+			return round.WrapError(errors.New("length of de-commitment should be 2"), Pj)
+		}
+
+		Rj, err := crypto.NewECPoint(round.Params().EC(), coordinates[0], coordinates[1])
+		if err != nil {
+			return round.WrapError(errors2.Wrapf(err, "NewECPoint(Rj)"), Pj)
+		}
+		proof, err := r2msg.UnmarshalZKProof(round.Params().EC())
+		if err != nil {
+			return round.WrapError(errors.New("failed to unmarshal Rj proof"), Pj)
+		}
+		ok = proof.Verify(ContextJ, Rj)
+		if !ok {
+			return round.WrapError(errors.New("failed to prove Rj"), Pj)
+		}
+		round.temp.pointRjs[j] = Rj
+	}
+
+	// 6. compute R = sum(Rj)
+	R := round.temp.pointRjs[0]
+	for j := 1; j < len(round.temp.pointRjs); j++ {
+		var err error
+		if R, err = R.Add(round.temp.pointRjs[j]); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "R.Add(Rj)"))
+		}
+	}
+
+	// 7. compute the challenge e = int(hash_BIP0340/challenge(bytes(R) || bytes(P) || m)) mod n
+	N := round.Params().EC().Params().N
+	pubKey := round.key.ECDSAPub
+	eHash := taggedHash(tagChallenge, bytes32(R.X()), bytes32(pubKey.X()), bytes32(round.temp.m))
+	e := new(big.Int).Mod(new(big.Int).SetBytes(eHash), N)
+
+	// 8. compute si = ki + e * di, where the nonce and the share are negated when R and P have an odd Y, as BIP-340
+	// only encodes their X coordinates
+	modN := common.ModInt(N)
+	ki, di := round.temp.ri, round.temp.wi
+	if R.Y().Bit(0) == 1 {
+		ki = new(big.Int).Sub(N, ki)
+	}
+	if pubKey.Y().Bit(0) == 1 {
+		di = new(big.Int).Sub(N, di)
+	}
+	si := modN.Add(ki, modN.Mul(e, di))
+
+	// 9. store r3 message pieces
+	round.temp.bigR = R
+	round.temp.e = e
+	round.temp.si = si
+
+	// 10. broadcast si to other parties
+	r3msg := NewSignRound3Message(round.PartyID(), si)
+	round.temp.signRound3Messages[i] = r3msg
+	round.out <- r3msg
+
+	return nil
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound3Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
@@ -0,0 +1,53 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// CheckBatchVerify checks a batch verifier of `n` inputs against the verification of the inputs one at a time:
+// `batch` must return the indices in `failed` at any concurrency, and `verify(i)` must fail for exactly those indices.
+func CheckBatchVerify(t *testing.T, n int, batch func(concurrency int) []int, verify func(i int) bool, failed []int) {
+	for _, concurrency := range []int{0, 1, 3, 10} {
+		assert.Equal(t, failed, batch(concurrency), "concurrency %d", concurrency)
+	}
+	for i := 0; i < n; i++ {
+		assert.Equal(t, !contains(failed, i), verify(i), "the batch must decide as the single-proof Verify for input %d", i)
+	}
+}
+
+// BenchmarkBatchVerify compares verifying `n` valid inputs one at a time with `verify` to verifying them with `batch`.
+func BenchmarkBatchVerify(b *testing.B, n int, batch func(concurrency int) []int, verify func(i int) bool) {
+	b.Run("serial", func(b *testing.B) {
+		for k := 0; k < b.N; k++ {
+			for i := 0; i < n; i++ {
+				if !verify(i) {
+					b.Fatal("verify failed")
+				}
+			}
+		}
+	})
+	b.Run("batch", func(b *testing.B) {
+		for k := 0; k < b.N; k++ {
+			if failed := batch(0); len(failed) > 0 {
+				b.Fatal("verify failed")
+			}
+		}
+	})
+}
+
+func contains(indices []int, i int) bool {
+	for _, j := range indices {
+		if j == i {
+			return true
+		}
+	}
+	return false
+}
@@ -10,6 +10,7 @@ import (
 	"crypto/elliptic"
 	"errors"
 	"reflect"
+	"sync"
 
 	s256k1 "github.com/btcsuite/btcd/btcec/v2"
 	"github.com/decred/dcrd/dcrec/edwards/v2"
@@ -20,28 +21,49 @@ type CurveName string
 const (
 	Secp256k1 CurveName = "secp256k1"
 	Ed25519   CurveName = "ed25519"
+	P256      CurveName = "P-256"
+	P384      CurveName = "P-384"
+	P521      CurveName = "P-521"
 )
 
 var (
-	ec       elliptic.Curve
-	registry map[CurveName]elliptic.Curve
+	ec         elliptic.Curve
+	registry   map[CurveName]elliptic.Curve
+	registered []CurveName // the registry's names in the order they were first registered
+	registryMu sync.RWMutex
 )
 
-// Init default curve (secp256k1)
+// Init default curve (secp256k1) and register the curves supported out of the box,
+// so that ECPoints on them can be marshalled without calling RegisterCurve
 func init() {
 	ec = s256k1.S256()
 
 	registry = make(map[CurveName]elliptic.Curve)
-	registry[Secp256k1] = s256k1.S256()
-	registry[Ed25519] = edwards.Edwards()
+	RegisterCurve(Secp256k1, s256k1.S256())
+	RegisterCurve(Ed25519, edwards.Edwards())
+	RegisterCurve(P256, elliptic.P256())
+	RegisterCurve(P384, elliptic.P384())
+	RegisterCurve(P521, elliptic.P521())
 }
 
+// RegisterCurve registers a custom curve under a name, which is used when marshalling ECPoints on it.
+// Registering a name again replaces its curve; registering the same curve again has no effect.
 func RegisterCurve(name CurveName, curve elliptic.Curve) {
+	if curve == nil {
+		panic(errors.New("RegisterCurve received a nil curve"))
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exist := registry[name]; !exist {
+		registered = append(registered, name)
+	}
 	registry[name] = curve
 }
 
 // return curve, exist(bool)
 func GetCurveByName(name CurveName) (elliptic.Curve, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
 	if val, exist := registry[name]; exist {
 		return val, true
 	}
@@ -50,9 +72,13 @@ func GetCurveByName(name CurveName) (elliptic.Curve, bool) {
 }
 
 // return name, exist(bool)
+// when a curve is registered under several names, the first one registered is returned
 func GetCurveName(curve elliptic.Curve) (CurveName, bool) {
-	for name, e := range registry {
-		if reflect.TypeOf(curve) == reflect.TypeOf(e) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, name := range registered {
+		// curves of the same type, such as the NIST curves before Go 1.19, are told apart by their parameters
+		if reflect.TypeOf(curve) == reflect.TypeOf(registry[name]) && curve.Params().Name == registry[name].Params().Name {
 			return name, true
 		}
 	}
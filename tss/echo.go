@@ -0,0 +1,71 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"bytes"
+	"errors"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+// EchoHashes returns the hash of the content of each of the broadcasts `msgs`, by party index, for the parties to
+// compare the broadcasts that they received, see EnableEchoBroadcast. The content is re-encoded deterministically
+// before it is hashed, so two encodings of the same content have the same hash.
+func EchoHashes(msgs []ParsedMessage) ([][]byte, error) {
+	hashes := make([][]byte, len(msgs))
+	for j, msg := range msgs {
+		if msg == nil || msg.Content() == nil {
+			return nil, errors.New("EchoHashes: a broadcast is missing")
+		}
+		bz, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg.Content())
+		if err != nil {
+			return nil, err
+		}
+		hashes[j] = common.SHA512_256(bz)
+	}
+	return hashes, nil
+}
+
+// EchoCulprits compares the hashes of the broadcasts received by the party at index `self`, `own`, with the `echoes`
+// of the other parties, by party index, and returns the parties to blame: the senders of broadcasts whose hashes
+// differ, the parties whose echo of the broadcast of `self` differs from what `self` sent, and the parties whose echo
+// has the wrong number of hashes. An equivocating sender cannot tell which of its broadcasts each honest party echoes,
+// but a malicious party may echo a wrong hash to blame an honest sender, so the culprits are only trustworthy when the
+// echoing parties are.
+func EchoCulprits(parties []*PartyID, self int, own [][]byte, echoes [][][]byte) []*PartyID {
+	blamed := make([]bool, len(parties))
+	for j, echo := range echoes {
+		if j == self {
+			continue
+		}
+		if len(echo) != len(own) {
+			blamed[j] = true
+			continue
+		}
+		for k, hash := range echo {
+			if bytes.Equal(hash, own[k]) {
+				continue
+			}
+			if k == self {
+				// this party knows what it sent
+				blamed[j] = true
+			} else {
+				blamed[k] = true
+			}
+		}
+	}
+	culprits := make([]*PartyID, 0)
+	for j, isBlamed := range blamed {
+		if isBlamed {
+			culprits = append(culprits, parties[j])
+		}
+	}
+	return culprits
+}
@@ -0,0 +1,38 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEchoCulprits(t *testing.T) {
+	pIDs := GenerateTestPartyIDs(4)
+	own := [][]byte{{0}, {1}, {2}, {3}}
+	echoes := func() [][][]byte {
+		return [][][]byte{own, {{0}, {1}, {2}, {3}}, {{0}, {1}, {2}, {3}}, {{0}, {1}, {2}, {3}}}
+	}
+
+	assert.Empty(t, EchoCulprits(pIDs, 0, own, echoes()))
+
+	// party 2 received another broadcast from party 3
+	es := echoes()
+	es[2][3] = []byte{9}
+	assert.Equal(t, []*PartyID{pIDs[3]}, EchoCulprits(pIDs, 0, own, es))
+
+	// party 1 claims to have received another broadcast from this party
+	es = echoes()
+	es[1][0] = []byte{9}
+	assert.Equal(t, []*PartyID{pIDs[1]}, EchoCulprits(pIDs, 0, own, es))
+
+	// party 3 sent no echo
+	es = echoes()
+	es[3] = nil
+	assert.Equal(t, []*PartyID{pIDs[3]}, EchoCulprits(pIDs, 0, own, es))
+}
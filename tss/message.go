@@ -137,13 +137,21 @@ func (mm *MessageImpl) IsToOldAndNewCommittees() bool {
 }
 
 func (mm *MessageImpl) WireBytes() ([]byte, *MessageRouting, error) {
-	bz, err := proto.Marshal(mm.wire.Message)
+	bz, err := mm.WireBytesInto(nil)
 	if err != nil {
 		return nil, nil, err
 	}
 	return bz, &mm.MessageRouting, nil
 }
 
+// WireBytesInto appends the bytes of WireBytes to `buf` and returns the extended buffer, so that a server sending many
+// messages can reuse its buffers, e.g. from a sync.Pool, instead of allocating new ones. The routing is given by GetTo
+// and IsBroadcast. The result aliases `buf` when it has the capacity, so it must not be reused while still in use.
+// The messages sent by the parties are all *MessageImpl.
+func (mm *MessageImpl) WireBytesInto(buf []byte) ([]byte, error) {
+	return proto.MarshalOptions{}.MarshalAppend(buf, mm.wire.Message)
+}
+
 func (mm *MessageImpl) WireMsg() *MessageWrapper {
 	return mm.wire
 }
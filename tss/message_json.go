@@ -0,0 +1,138 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+type (
+	// MessageJSON is a human-readable envelope of a Message, intended for logging and debugging transports.
+	// Payload holds the hex of the same bytes returned by Message.WireBytes.
+	MessageJSON struct {
+		Type                    string         `json:"type"`
+		From                    *PartyIDJSON   `json:"from"`
+		To                      []*PartyIDJSON `json:"to"` // `null` when the message is for all parties
+		IsBroadcast             bool           `json:"is_broadcast"`
+		IsToOldCommittee        bool           `json:"is_to_old_committee"`
+		IsToOldAndNewCommittees bool           `json:"is_to_old_and_new_committees"`
+		Payload                 string         `json:"payload"`
+	}
+
+	// PartyIDJSON is the representation of a PartyID within a MessageJSON; the key is hex encoded.
+	PartyIDJSON struct {
+		ID      string `json:"id"`
+		Moniker string `json:"moniker"`
+		Key     string `json:"key"`
+		Index   int    `json:"index"`
+	}
+)
+
+// MessageToJSON encodes a message into a JSON envelope with its type, routing and hex payload.
+func MessageToJSON(msg Message) ([]byte, error) {
+	if msg == nil || msg.GetFrom() == nil {
+		return nil, errors.New("MessageToJSON: the message or its sender is nil")
+	}
+	bz, _, err := msg.WireBytes()
+	if err != nil {
+		return nil, err
+	}
+	env := &MessageJSON{
+		Type:                    msg.Type(),
+		From:                    partyIDToJSON(msg.GetFrom()),
+		IsBroadcast:             msg.IsBroadcast(),
+		IsToOldCommittee:        msg.IsToOldCommittee(),
+		IsToOldAndNewCommittees: msg.IsToOldAndNewCommittees(),
+		Payload:                 hex.EncodeToString(bz),
+	}
+	if to := msg.GetTo(); to != nil {
+		env.To = make([]*PartyIDJSON, len(to))
+		for i, pID := range to {
+			env.To[i] = partyIDToJSON(pID)
+		}
+	}
+	return json.Marshal(env)
+}
+
+// MessageFromJSON parses a JSON envelope produced by MessageToJSON back into a ParsedMessage, including its routing.
+// An error is returned if the payload does not decode to a message of the declared type.
+func MessageFromJSON(bz []byte) (ParsedMessage, error) {
+	env := new(MessageJSON)
+	if err := json.Unmarshal(bz, env); err != nil {
+		return nil, err
+	}
+	if env.From == nil {
+		return nil, errors.New("MessageFromJSON: the envelope has no sender")
+	}
+	from, err := partyIDFromJSON(env.From)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := hex.DecodeString(env.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("MessageFromJSON: invalid payload: %v", err)
+	}
+	routing := MessageRouting{
+		From:                    from,
+		IsBroadcast:             env.IsBroadcast,
+		IsToOldCommittee:        env.IsToOldCommittee,
+		IsToOldAndNewCommittees: env.IsToOldAndNewCommittees,
+	}
+	if env.To != nil {
+		routing.To = make([]*PartyID, len(env.To))
+		for i, to := range env.To {
+			if to == nil {
+				return nil, errors.New("MessageFromJSON: the envelope has a nil recipient")
+			}
+			if routing.To[i], err = partyIDFromJSON(to); err != nil {
+				return nil, err
+			}
+		}
+	}
+	any := new(anypb.Any)
+	if err = proto.Unmarshal(payload, any); err != nil {
+		return nil, err
+	}
+	m, err := any.UnmarshalNew()
+	if err != nil {
+		return nil, err
+	}
+	content, ok := m.(MessageContent)
+	if !ok {
+		return nil, errors.New("MessageFromJSON: the message contained unknown content")
+	}
+	if name := string(proto.MessageName(content)); name != env.Type {
+		return nil, fmt.Errorf("MessageFromJSON: the payload is a %s but the envelope declares %s", name, env.Type)
+	}
+	return NewMessage(routing, content, NewMessageWrapper(routing, content)), nil
+}
+
+func partyIDToJSON(pID *PartyID) *PartyIDJSON {
+	return &PartyIDJSON{
+		ID:      pID.GetId(),
+		Moniker: pID.GetMoniker(),
+		Key:     hex.EncodeToString(pID.GetKey()),
+		Index:   pID.Index,
+	}
+}
+
+func partyIDFromJSON(pID *PartyIDJSON) (*PartyID, error) {
+	key, err := hex.DecodeString(pID.Key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid party key: %v", err)
+	}
+	party := NewPartyID(pID.ID, pID.Moniker, new(big.Int).SetBytes(key))
+	party.Index = pID.Index
+	return party, nil
+}
@@ -0,0 +1,63 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/resharing"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestMessageJSONRoundTrip(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(3)
+	msgs := []tss.ParsedMessage{
+		signing.NewSignRound1Message2(pIDs[0], big.NewInt(42)),
+		resharing.NewDGRound2Message2(pIDs[1:], pIDs[0]),
+		resharing.NewDGRound4Message2(pIDs, pIDs[2]),
+	}
+	for _, msg := range msgs {
+		bz, err := tss.MessageToJSON(msg)
+		assert.NoError(t, err)
+
+		parsed, err := tss.MessageFromJSON(bz)
+		assert.NoError(t, err)
+		assert.Equal(t, msg.Type(), parsed.Type())
+		assert.Equal(t, msg.IsBroadcast(), parsed.IsBroadcast())
+		assert.Equal(t, msg.GetFrom().Index, parsed.GetFrom().Index)
+		assert.Equal(t, msg.GetFrom().Key, parsed.GetFrom().Key)
+		assert.Equal(t, msg.IsToOldCommittee(), parsed.IsToOldCommittee())
+		assert.Equal(t, msg.IsToOldAndNewCommittees(), parsed.IsToOldAndNewCommittees())
+		assert.Equal(t, len(msg.GetTo()), len(parsed.GetTo()))
+		for i, to := range msg.GetTo() {
+			assert.Equal(t, to.Index, parsed.GetTo()[i].Index)
+			assert.Equal(t, to.Moniker, parsed.GetTo()[i].Moniker)
+		}
+		wire1, _, _ := msg.WireBytes()
+		wire2, _, _ := parsed.WireBytes()
+		assert.Equal(t, wire1, wire2)
+	}
+}
+
+func TestMessageFromJSONRejectsTypeMismatch(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(2)
+	bz, err := tss.MessageToJSON(signing.NewSignRound1Message2(pIDs[0], big.NewInt(42)))
+	assert.NoError(t, err)
+
+	env := new(tss.MessageJSON)
+	assert.NoError(t, json.Unmarshal(bz, env))
+	env.Type = "binance.tsslib.ecdsa.signing.SignRound2Message"
+	bz, err = json.Marshal(env)
+	assert.NoError(t, err)
+	_, err = tss.MessageFromJSON(bz)
+	assert.Error(t, err)
+}
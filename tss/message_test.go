@@ -0,0 +1,82 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss_test
+
+import (
+	"crypto/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto/mta"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// newTestSignRound2Message returns a SignRound2Message of the size of one of a signing with 2048-bit keys
+func newTestSignRound2Message() *tss.MessageImpl {
+	pIDs := tss.GenerateTestPartyIDs(2)
+	randomParts := func(n, size int) [][]byte {
+		parts := make([][]byte, n)
+		for i := range parts {
+			parts[i] = make([]byte, size)
+			_, _ = rand.Read(parts[i])
+		}
+		return parts
+	}
+	meta := tss.MessageRouting{From: pIDs[0], To: []*tss.PartyID{pIDs[1]}}
+	content := &signing.SignRound2Message{
+		C1:         randomParts(1, 512)[0],
+		C2:         randomParts(1, 512)[0],
+		ProofBob:   randomParts(mta.ProofBobBytesParts, 256),
+		ProofBobWc: randomParts(mta.ProofBobWCBytesParts, 256),
+	}
+	return tss.NewMessage(meta, content, tss.NewMessageWrapper(meta, content)).(*tss.MessageImpl)
+}
+
+func TestWireBytesInto(t *testing.T) {
+	msg := newTestSignRound2Message()
+	expected, _, err := msg.WireBytes()
+	assert.NoError(t, err)
+
+	prefix := []byte("prefix")
+	bz, err := msg.WireBytesInto(append(make([]byte, 0, 64*1024), prefix...))
+	assert.NoError(t, err)
+	assert.Equal(t, append(prefix, expected...), bz)
+
+	parsed, err := tss.ParseWireMessage(bz[len(prefix):], msg.GetFrom(), msg.IsBroadcast())
+	if assert.NoError(t, err) {
+		assert.Equal(t, msg.Type(), parsed.Type())
+		assert.True(t, parsed.ValidateBasic())
+	}
+}
+
+func BenchmarkWireBytes(b *testing.B) {
+	msg := newTestSignRound2Message()
+	b.Run("WireBytes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := msg.WireBytes(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("WireBytesInto", func(b *testing.B) {
+		pool := sync.Pool{New: func() interface{} { return new([]byte) }}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf := pool.Get().(*[]byte)
+			bz, err := msg.WireBytesInto((*buf)[:0])
+			if err != nil {
+				b.Fatal(err)
+			}
+			*buf = bz
+			pool.Put(buf)
+		}
+	})
+}
@@ -7,11 +7,17 @@
 package tss
 
 import (
+	"context"
+	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"errors"
 	"io"
+	"math/big"
 	"runtime"
 	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
 )
 
 type (
@@ -24,14 +30,45 @@ type (
 		concurrency         int
 		safePrimeGenTimeout time.Duration
 		// proof session info
-		nonce int
+		nonce *big.Int
 		// for keygen
 		noProofMod bool
 		noProofFac bool
+		noProofDln bool
+		// reject a second message of the same type from a party
+		replayProtection bool
+		// send EC points in the compressed encoding where messages support it
+		compressPoints bool
+		// echo the hashes of the broadcasts received to check that every party received the same
+		echoBroadcast bool
+		// round progress hooks
+		roundTimeout      time.Duration
+		onRoundTimeout    func(*Error)
+		onRoundTransition func(oldRound, newRound int)
+		// aborts the computations of the rounds when done
+		ctx context.Context
+		// transport keys to encrypt the p2p keygen shares to
+		shareEncryptionKey  *ecdsa.PrivateKey
+		shareEncryptionPubs []*ecdsa.PublicKey
+		// for signing benchmarks ONLY
+		insecureSkipProofs InsecureProofSkips
 		// random sources
 		partialKeyRand, rand io.Reader
+		// the logger of the party, see SetLogger
+		logger Logger
+	}
+
+	// Logger is the leveled logger the parties write to. common.Logger implements it.
+	Logger interface {
+		Debugf(format string, args ...interface{})
+		Infof(format string, args ...interface{})
+		Warningf(format string, args ...interface{})
+		Errorf(format string, args ...interface{})
 	}
 
+	// InsecureProofSkips is a set of signing proof verifications to skip, see SetInsecureSkipProofsForBenchmarkOnly.
+	InsecureProofSkips uint
+
 	ReSharingParameters struct {
 		*Parameters
 		newParties    *PeerContext
@@ -44,6 +81,15 @@ const (
 	defaultSafePrimeGenTimeout = 5 * time.Minute
 )
 
+const (
+	// InsecureSkipRangeProofAlice skips the verification of the MtA range proofs on the encrypted k_j (signing round 2)
+	InsecureSkipRangeProofAlice InsecureProofSkips = 1 << iota
+	// InsecureSkipProofBob skips the verification of the MtA and MtAwc respondent proofs (signing round 3)
+	InsecureSkipProofBob
+	// InsecureSkipSchnorrProofs skips the verification of the Schnorr and V proofs (signing rounds 5 and 7)
+	InsecureSkipSchnorrProofs
+)
+
 // Exported, used in `tss` client
 func NewParameters(ec elliptic.Curve, ctx *PeerContext, partyID *PartyID, partyCount, threshold int) *Parameters {
 	return &Parameters{
@@ -112,6 +158,167 @@ func (params *Parameters) SetNoProofFac() {
 	params.noProofFac = true
 }
 
+// NoProofDln returns whether the party skips the verification of the DLN proofs of NTilde, h1 and h2, see
+// SetNoProofDln.
+func (params *Parameters) NoProofDln() bool {
+	return params.noProofDln
+}
+
+// SetNoProofDln makes ECDSA keygen (round 2) and resharing (round 4) skip the verification of the DLN proofs of the
+// NTilde, h1 and h2 of the other parties, which are still stored. WARNING: this is INSECURE, as a party may then use
+// values for which the range proofs of signing are unsound; use it only for tests and benchmarks with trusted parties.
+func (params *Parameters) SetNoProofDln() {
+	params.noProofDln = true
+}
+
+// ReplayProtection returns whether the party rejects a second message of the same type from the same sender.
+func (params *Parameters) ReplayProtection() bool {
+	return params.replayProtection
+}
+
+// RoundTimeout returns the time a round may take and the function called when it takes longer, see SetRoundTimeout.
+func (params *Parameters) RoundTimeout() (time.Duration, func(*Error)) {
+	return params.roundTimeout, params.onRoundTimeout
+}
+
+// SetRoundTimeout makes the party call `onTimeout` when a round has not completed `timeout` after it started.
+// The error names the round and has the parties whose messages are still missing as culprits. The party keeps
+// running, so a late message may still complete the round.
+func (params *Parameters) SetRoundTimeout(timeout time.Duration, onTimeout func(*Error)) {
+	params.roundTimeout = timeout
+	params.onRoundTimeout = onTimeout
+}
+
+// Context returns the context set with SetContext, or context.Background().
+func (params *Parameters) Context() context.Context {
+	if params.ctx == nil {
+		return context.Background()
+	}
+	return params.ctx
+}
+
+// SetContext sets a context that aborts the long computations of the rounds, such as the verification of the proofs
+// of all the parties, once it is done. The round then fails with an error that has no culprits.
+func (params *Parameters) SetContext(ctx context.Context) {
+	params.ctx = ctx
+}
+
+// RoundContext returns the context for the computations of a round, derived from the context of SetContext.
+// The round timeout does not apply to it: a party keeps running after the timeout, see SetRoundTimeout.
+// The caller must call the returned cancel function when the round is done.
+func (params *Parameters) RoundContext() (context.Context, context.CancelFunc) {
+	return context.WithCancel(params.Context())
+}
+
+// OnRoundTransition returns the function set with SetOnRoundTransition, or nil.
+func (params *Parameters) OnRoundTransition() func(oldRound, newRound int) {
+	return params.onRoundTransition
+}
+
+// SetOnRoundTransition sets a function called by Update when the party moves on from `oldRound`. `newRound` is 0
+// once the last round has completed. It is called with the party locked, so it must not call back into the party.
+func (params *Parameters) SetOnRoundTransition(fn func(oldRound, newRound int)) {
+	params.onRoundTransition = fn
+}
+
+// EnableReplayProtection makes the party reject, with an error naming the sender as the culprit, a message of a type
+// that it has already received from the same sender. By default the transport is expected to filter out replays.
+func (params *Parameters) EnableReplayProtection() {
+	params.replayProtection = true
+}
+
+// CompressPoints returns whether the party sends EC points in their compressed encoding, see EnableCompressPoints.
+func (params *Parameters) CompressPoints() bool {
+	return params.compressPoints
+}
+
+// EnableCompressPoints makes the party send the EC points of the ECDSA signing messages in their compressed encoding
+// (ECPoint.Bytes) in place of separate X and Y coordinates, which takes about half the bytes.
+// Both encodings are always accepted, but older parties can only parse the uncompressed one, so enable this only once
+// every party runs a version that supports it.
+func (params *Parameters) EnableCompressPoints() {
+	params.compressPoints = true
+}
+
+// EchoBroadcast returns whether the parties echo the broadcasts that they received, see EnableEchoBroadcast.
+func (params *Parameters) EchoBroadcast() bool {
+	return params.echoBroadcast
+}
+
+// EnableEchoBroadcast makes the party check that every party received the same round 1 broadcasts of ECDSA keygen.
+// Each party sends the hashes of the round 1 messages that it received along with its round 2 broadcast, and round 3
+// fails, naming as the culprits the parties that sent different round 1 messages to different peers, when the hashes
+// differ. Use it when the transport cannot guarantee that a broadcast delivers the same content to everyone.
+// Every party must enable it, as a party that has it enabled blames the parties whose round 2 broadcasts have no echo.
+func (params *Parameters) EnableEchoBroadcast() {
+	params.echoBroadcast = true
+}
+
+func (params *Parameters) ShareEncryptionKey() *ecdsa.PrivateKey {
+	return params.shareEncryptionKey
+}
+
+func (params *Parameters) ShareEncryptionPubKeys() []*ecdsa.PublicKey {
+	return params.shareEncryptionPubs
+}
+
+// SetShareEncryptionKeys makes keygen encrypt each p2p share to the recipient's transport key (ECIES over the keygen
+// curve), so that a relay between the parties cannot read the shares even if the transport is not encrypted.
+// `own` is this party's transport key pair and `parties` holds every party's transport public key, in the order of
+// Parties().IDs(). All parties must enable this mode, otherwise the shares will fail to verify.
+func (params *Parameters) SetShareEncryptionKeys(own *ecdsa.PrivateKey, parties []*ecdsa.PublicKey) {
+	params.shareEncryptionKey = own
+	params.shareEncryptionPubs = parties
+}
+
+// ValidateShareEncryptionKeys checks the keys set by SetShareEncryptionKeys, if any.
+func (params *Parameters) ValidateShareEncryptionKeys() error {
+	if params.shareEncryptionKey == nil && params.shareEncryptionPubs == nil {
+		return nil
+	}
+	own := params.shareEncryptionKey
+	if own == nil || own.D == nil || !SameCurve(own.Curve, params.ec) {
+		return errors.New("the share encryption key must be a private key on the keygen curve")
+	}
+	if len(params.shareEncryptionPubs) != params.partyCount {
+		return errors.New("a share encryption public key is required for each party")
+	}
+	for j, pub := range params.shareEncryptionPubs {
+		if pub == nil || pub.X == nil || pub.Y == nil || !SameCurve(pub.Curve, params.ec) || !params.ec.IsOnCurve(pub.X, pub.Y) {
+			return errors.New("the share encryption public keys must be points on the keygen curve")
+		}
+		if j == params.partyID.Index && (pub.X.Cmp(own.X) != 0 || pub.Y.Cmp(own.Y) != 0) {
+			return errors.New("the share encryption public key of this party does not match its private key")
+		}
+	}
+	return nil
+}
+
+func (params *Parameters) InsecureSkipProofs() InsecureProofSkips {
+	return params.insecureSkipProofs
+}
+
+// SetInsecureSkipProofsForBenchmarkOnly makes signing skip the verification of the given proofs so that their share of
+// the signing latency can be measured. This is INSECURE: a party that skips a proof verification can be tricked into
+// leaking its secret share. It must only be used in benchmarks with throwaway keys, never in production.
+func (params *Parameters) SetInsecureSkipProofsForBenchmarkOnly(skips InsecureProofSkips) {
+	params.insecureSkipProofs = skips
+}
+
+// SSIDNonce returns the nonce that is mixed into the session id (ssid) of the ZK proofs; it is 0 unless set.
+func (params *Parameters) SSIDNonce() *big.Int {
+	if params.nonce == nil {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Set(params.nonce)
+}
+
+// SetSSIDNonce sets the nonce that is mixed into the session id of the ZK proofs, which makes the proof challenges
+// reproducible across implementations, e.g. to build interop test vectors. All parties must use the same nonce.
+func (params *Parameters) SetSSIDNonce(nonce *big.Int) {
+	params.nonce = new(big.Int).Set(nonce)
+}
+
 func (params *Parameters) PartialKeyRand() io.Reader {
 	return params.partialKeyRand
 }
@@ -128,6 +335,20 @@ func (params *Parameters) SetRand(rand io.Reader) {
 	params.rand = rand
 }
 
+// Logger returns the logger set with SetLogger, or the package-global common.Logger.
+func (params *Parameters) Logger() Logger {
+	if params.logger == nil {
+		return common.Logger
+	}
+	return params.logger
+}
+
+// SetLogger makes the party write its logs to `logger` instead of common.Logger, e.g. to attach the party and
+// session of a ceremony to each entry or to route them to another sink.
+func (params *Parameters) SetLogger(logger Logger) {
+	params.logger = logger
+}
+
 // ----- //
 
 // Exported, used in `tss` client
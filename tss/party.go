@@ -9,7 +9,9 @@ package tss
 import (
 	"errors"
 	"fmt"
+	"io"
 	"sync"
+	"time"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 )
@@ -36,12 +38,31 @@ type Party interface {
 	advance()
 	lock()
 	unlock()
+	hasReceived(msg ParsedMessage) bool
+	markReceived(msg ParsedMessage)
+	watchRound()
+	checkpointRun(rand io.Reader) ([]byte, error)
+	setCheckpointRun(run []byte)
 }
 
 type BaseParty struct {
 	mtx        sync.Mutex
 	rnd        Round
 	FirstRound Round
+	// the messages stored so far by sender key and type, checked when replay protection is enabled
+	received map[string]struct{}
+	// fires when the current round takes longer than the round timeout
+	roundTimer *time.Timer
+	// returns the size of the committee that the sender of a message belongs to
+	committeeSize func(msg ParsedMessage) int
+	// identifies the run of the party in its checkpoints, see CheckpointLedger
+	run []byte
+}
+
+// NewBaseParty returns a BaseParty that rejects messages whose sender index does not fit into the committee of the
+// sender, as given by committeeSize. The parties store messages in arrays indexed by the sender index.
+func NewBaseParty(committeeSize func(msg ParsedMessage) int) *BaseParty {
+	return &BaseParty{committeeSize: committeeSize}
 }
 
 func (p *BaseParty) Running() bool {
@@ -72,6 +93,13 @@ func (p *BaseParty) ValidateMessage(msg ParsedMessage) (bool, *Error) {
 	if msg.GetFrom() == nil || !msg.GetFrom().ValidateBasic() {
 		return false, p.WrapError(fmt.Errorf("received msg with an invalid sender: %s", msg))
 	}
+	// check that the message's "from index" will fit into the array
+	if p.committeeSize != nil {
+		if maxFromIdx := p.committeeSize(msg) - 1; maxFromIdx < msg.GetFrom().Index {
+			return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+				maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+		}
+	}
 	if !msg.ValidateBasic() {
 		return false, p.WrapError(fmt.Errorf("message failed ValidateBasic: %s", msg), msg.GetFrom())
 	}
@@ -113,6 +141,69 @@ func (p *BaseParty) unlock() {
 	p.mtx.Unlock()
 }
 
+// hasReceived returns whether a message of this type was already stored from its sender.
+// Each round of the protocols expects at most one message of each type from each party.
+func (p *BaseParty) hasReceived(msg ParsedMessage) bool {
+	_, ok := p.received[receivedKey(msg)]
+	return ok
+}
+
+// markReceived records that a message of this type was stored from its sender
+func (p *BaseParty) markReceived(msg ParsedMessage) {
+	if p.received == nil {
+		p.received = make(map[string]struct{})
+	}
+	p.received[receivedKey(msg)] = struct{}{}
+}
+
+func receivedKey(msg ParsedMessage) string {
+	return string(msg.GetFrom().Key) + "/" + msg.Type()
+}
+
+// checkpointRun returns the id of this run of the party, drawing it from `rand` the first time
+func (p *BaseParty) checkpointRun(rand io.Reader) ([]byte, error) {
+	if p.run == nil {
+		run := make([]byte, 32)
+		if _, err := io.ReadFull(rand, run); err != nil {
+			return nil, err
+		}
+		p.run = run
+	}
+	return p.run, nil
+}
+
+func (p *BaseParty) setCheckpointRun(run []byte) {
+	p.run = run
+}
+
+// watchRound arms the round timeout for the current round, replacing the timer of the previous round.
+// It is called with the lock held whenever a round has started.
+func (p *BaseParty) watchRound() {
+	if p.roundTimer != nil {
+		p.roundTimer.Stop()
+		p.roundTimer = nil
+	}
+	rnd := p.rnd
+	if rnd == nil {
+		return
+	}
+	timeout, onTimeout := rnd.Params().RoundTimeout()
+	if timeout <= 0 || onTimeout == nil {
+		return
+	}
+	p.roundTimer = time.AfterFunc(timeout, func() {
+		p.lock()
+		if p.rnd != rnd {
+			p.unlock()
+			return
+		}
+		waiting := rnd.WaitingFor()
+		err := rnd.WrapError(fmt.Errorf("round %d did not complete within %s, still waiting for %v", rnd.RoundNumber(), timeout, waiting), waiting...)
+		p.unlock()
+		onTimeout(err)
+	})
+}
+
 // ----- //
 
 func BaseStart(p Party, task string, prepare ...func(Round) *Error) *Error {
@@ -125,6 +216,9 @@ func BaseStart(p Party, task string, prepare ...func(Round) *Error) *Error {
 		return p.WrapError(errors.New("could not start. this party is in an unexpected state. use the constructor and Start()"))
 	}
 	round := p.FirstRound()
+	if err := round.Params().Parties().IDs().ValidateDistinctKeys(); err != nil {
+		return p.WrapError(fmt.Errorf("could not start. %v", err))
+	}
 	if err := p.setRound(round); err != nil {
 		return err
 	}
@@ -136,11 +230,16 @@ func BaseStart(p Party, task string, prepare ...func(Round) *Error) *Error {
 			return err
 		}
 	}
-	common.Logger.Infof("party %s: %s round %d starting", p.round().Params().PartyID(), task, 1)
+	round.Params().Logger().Infof("party %s: %s round %d starting", round.Params().PartyID(), task, 1)
 	defer func() {
-		common.Logger.Debugf("party %s: %s round %d finished", p.round().Params().PartyID(), task, 1)
+		round.Params().Logger().Debugf("party %s: %s round %d finished", round.Params().PartyID(), task, 1)
 	}()
-	return p.round().Start()
+	if err := round.Start(); err != nil {
+		return err
+	}
+	p.watchRound()
+	// messages that were received before Start are applied now
+	return advanceRounds(p, task)
 }
 
 // an implementation of Update that is shared across the different types of parties (keygen, signing, dynamic groups)
@@ -149,39 +248,102 @@ func BaseUpdate(p Party, msg ParsedMessage, task string) (ok bool, err *Error) {
 	if _, err := p.ValidateMessage(msg); err != nil {
 		return false, err
 	}
-	// lock the mutex. need this mtx unlock hook; L108 is recursive so cannot use defer
 	r := func(ok bool, err *Error) (bool, *Error) {
 		p.unlock()
 		return ok, err
 	}
 	p.lock() // data is written to P state below
-	common.Logger.Debugf("party %s received message: %s", p.PartyID(), msg.String())
-	if p.round() != nil {
-		common.Logger.Debugf("party %s round %d update: %s", p.PartyID(), p.round().RoundNumber(), msg.String())
+	if p.round() == nil {
+		common.Logger.Debugf("party %s received message: %s", p.PartyID(), msg.String())
+	} else {
+		p.round().Params().Logger().Debugf("party %s received message: %s", p.PartyID(), msg.String())
+		p.round().Params().Logger().Debugf("party %s round %d update: %s", p.PartyID(), p.round().RoundNumber(), msg.String())
+	}
+	checkReplay := p.round() != nil && p.round().Params().ReplayProtection()
+	if checkReplay && p.hasReceived(msg) {
+		return r(false, p.WrapError(fmt.Errorf("received a duplicate %s from party %s", msg.Type(), msg.GetFrom()), msg.GetFrom()))
 	}
 	if ok, err := p.StoreMessage(msg); err != nil || !ok {
 		return r(false, err)
 	}
-	if p.round() != nil {
-		common.Logger.Debugf("party %s: %s round %d update", p.round().Params().PartyID(), task, p.round().RoundNumber())
+	// recorded also before Start, as replay protection is only known once the party has a round
+	p.markReceived(msg)
+	if err := advanceRounds(p, task); err != nil {
+		return r(false, err)
+	}
+	return r(true, nil)
+}
+
+// advanceRounds runs the update of the current round and moves on to the next rounds for as long as they can proceed.
+// As each round re-scans the messages stored so far, messages that arrived ahead of their round are applied as soon as
+// the party reaches it, without the caller feeding them again. It is called with the lock held.
+func advanceRounds(p Party, task string) *Error {
+	for p.round() != nil {
+		p.round().Params().Logger().Debugf("party %s: %s round %d update", p.round().Params().PartyID(), task, p.round().RoundNumber())
 		if _, err := p.round().Update(); err != nil {
-			return r(false, err)
+			return err
 		}
-		if p.round().CanProceed() {
-			if p.advance(); p.round() != nil {
-				if err := p.round().Start(); err != nil {
-					return r(false, err)
-				}
-				rndNum := p.round().RoundNumber()
-				common.Logger.Infof("party %s: %s round %d started", p.round().Params().PartyID(), task, rndNum)
-			} else {
-				// finished! the round implementation will have sent the data through the `end` channel.
-				common.Logger.Infof("party %s: %s finished!", p.PartyID(), task)
+		if !p.round().CanProceed() {
+			return nil
+		}
+		oldRound, onTransition, logger := p.round().RoundNumber(), p.round().Params().OnRoundTransition(), p.round().Params().Logger()
+		if p.advance(); p.round() != nil {
+			if err := p.round().Start(); err != nil {
+				return err
+			}
+			rndNum := p.round().RoundNumber()
+			logger.Infof("party %s: %s round %d started", p.round().Params().PartyID(), task, rndNum)
+			if onTransition != nil {
+				onTransition(oldRound, rndNum)
+			}
+		} else {
+			// finished! the round implementation will have sent the data through the `end` channel.
+			logger.Infof("party %s: %s finished!", p.PartyID(), task)
+			if onTransition != nil {
+				onTransition(oldRound, 0)
 			}
-			p.unlock()                      // recursive so can't defer after return
-			return BaseUpdate(p, msg, task) // re-run round update or finish)
 		}
-		return r(true, nil)
+		p.watchRound()
 	}
-	return r(true, nil)
+	return nil
+}
+
+// BaseSnapshot runs `snapshot` against the party's current round while holding the party's lock,
+// so that the captured state cannot interleave with a concurrent Update.
+// `run` identifies this run of the party; it must be saved in the snapshot, see Checkpoint.
+func BaseSnapshot(p Party, snapshot func(round Round, run []byte) ([]byte, error)) ([]byte, error) {
+	p.lock()
+	defer p.unlock()
+	if p.round() == nil {
+		return nil, errors.New("could not take a snapshot. this party is not running")
+	}
+	run, err := p.checkpointRun(p.round().Params().Rand())
+	if err != nil {
+		return nil, err
+	}
+	return snapshot(p.round(), run)
+}
+
+// BaseResume sets a round that was restored from a snapshot of `run` on a party that has not been started.
+// The round must already be in its started state; the party then continues to process messages via Update.
+// The messages `restored` into the round are recorded as received, so that replay protection also covers them.
+func BaseResume(p Party, task string, round Round, run []byte, restored ...ParsedMessage) *Error {
+	p.lock()
+	defer p.unlock()
+	if p.PartyID() == nil || !p.PartyID().ValidateBasic() {
+		return p.WrapError(fmt.Errorf("could not resume. this party has an invalid PartyID: %+v", p.PartyID()))
+	}
+	if p.round() != nil {
+		return p.WrapError(errors.New("could not resume. this party is in an unexpected state. use the resume constructor only"))
+	}
+	if err := p.setRound(round); err != nil {
+		return err
+	}
+	p.setCheckpointRun(run)
+	for _, msg := range restored {
+		p.markReceived(msg)
+	}
+	p.watchRound()
+	round.Params().Logger().Infof("party %s: %s round %d resumed", round.Params().PartyID(), task, round.RoundNumber())
+	return advanceRounds(p, task)
 }
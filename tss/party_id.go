@@ -8,8 +8,10 @@ package tss
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"fmt"
 	"math/big"
+	mrand "math/rand"
 	"sort"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
@@ -53,6 +55,29 @@ func NewPartyID(id, moniker string, key *big.Int) *PartyID {
 	}
 }
 
+// DerivePartyKey derives the key of a party from a seed such as its p2p public key or a stable name:
+// the SHA-256 of the seed, reduced to a nonzero value below the order of the curve returned by EC().
+// A party's key must be unique within its group and stable across the group's lifetime, since the
+// shares of the key are bound to it; the seed should therefore never change once a key was generated.
+func DerivePartyKey(seed string) *big.Int {
+	q := EC().Params().N
+	hash := sha256.Sum256([]byte(seed))
+	key := new(big.Int).SetBytes(hash[:])
+	key.Mod(key, new(big.Int).Sub(q, big.NewInt(1)))
+	return key.Add(key, big.NewInt(1))
+}
+
+// NewDeterministicPartyIDs constructs the sorted PartyIDs of a group from one seed per party, see DerivePartyKey.
+// The seed is used as the id and moniker of each party. An error is returned if two of the parties get the same key,
+// e.g. when a seed is given twice.
+func NewDeterministicPartyIDs(seeds []string) (SortedPartyIDs, error) {
+	ids := make(UnSortedPartyIDs, 0, len(seeds))
+	for _, seed := range seeds {
+		ids = append(ids, NewPartyID(seed, seed, DerivePartyKey(seed)))
+	}
+	return SortPartyIDsE(ids)
+}
+
 func (pid PartyID) String() string {
 	return fmt.Sprintf("{%d,%s}", pid.Index, pid.Moniker)
 }
@@ -78,6 +103,14 @@ func SortPartyIDs(ids UnSortedPartyIDs, startAt ...int) SortedPartyIDs {
 	return sorted
 }
 
+// SortPartyIDsE is SortPartyIDs that returns an error if two of the parties have the same key
+func SortPartyIDsE(ids UnSortedPartyIDs, startAt ...int) (SortedPartyIDs, error) {
+	if err := SortedPartyIDs(ids).ValidateDistinctKeys(); err != nil {
+		return nil, err
+	}
+	return SortPartyIDs(ids, startAt...), nil
+}
+
 // GenerateTestPartyIDs generates a list of mock PartyIDs for tests
 func GenerateTestPartyIDs(count int, startAt ...int) SortedPartyIDs {
 	ids := make(UnSortedPartyIDs, 0, count)
@@ -102,6 +135,55 @@ func GenerateTestPartyIDs(count int, startAt ...int) SortedPartyIDs {
 	return SortPartyIDs(ids, startAt...)
 }
 
+// GenerateTestPartyIDsWithKeys generates mock PartyIDs for tests with the given keys, which may be in any order.
+// The id and moniker of each party are from its position in `keys`, and the parties are sorted by key as usual;
+// e.g. large, sparse or interleaved keys reproduce the index mapping of real committees. It panics if a key repeats.
+func GenerateTestPartyIDsWithKeys(keys []*big.Int, startAt ...int) SortedPartyIDs {
+	ids := make(UnSortedPartyIDs, 0, len(keys))
+	for i, key := range keys {
+		ids = append(ids, NewPartyID(fmt.Sprintf("%d", i+1), fmt.Sprintf("P[%d]", i+1), key))
+	}
+	sorted, err := SortPartyIDsE(ids, startAt...)
+	if err != nil {
+		panic(err)
+	}
+	return sorted
+}
+
+// GenerateTestPartyIDsWithSeed generates mock PartyIDs for tests with random keys below the order of the curve
+// returned by EC(), drawn from `seed` so that a test can reproduce a particular set of keys and their ordering.
+// The keys are not secret and must not be used outside of tests.
+func GenerateTestPartyIDsWithSeed(count int, seed int64, startAt ...int) SortedPartyIDs {
+	rng := mrand.New(mrand.NewSource(seed))
+	q := EC().Params().N
+	keys := make([]*big.Int, 0, count)
+	seen := make(map[string]struct{}, count)
+	for len(keys) < count {
+		// a nonzero key below q, like DerivePartyKey
+		key := new(big.Int).Rand(rng, new(big.Int).Sub(q, big.NewInt(1)))
+		key.Add(key, big.NewInt(1))
+		if _, ok := seen[key.String()]; ok {
+			continue
+		}
+		seen[key.String()] = struct{}{}
+		keys = append(keys, key)
+	}
+	return GenerateTestPartyIDsWithKeys(keys, startAt...)
+}
+
+// ValidateDistinctKeys returns an error if two of the parties have the same key, as the parties are told apart by their keys
+func (spids SortedPartyIDs) ValidateDistinctKeys() error {
+	seen := make(map[string]*PartyID, len(spids))
+	for _, pid := range spids {
+		key := pid.KeyInt().String()
+		if other, ok := seen[key]; ok {
+			return fmt.Errorf("parties %s and %s have the same key", other, pid)
+		}
+		seen[key] = pid
+	}
+	return nil
+}
+
 func (spids SortedPartyIDs) Keys() []*big.Int {
 	ids := make([]*big.Int, spids.Len())
 	for i, pid := range spids {
@@ -0,0 +1,114 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss_test
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestDuplicatePartyKeys(t *testing.T) {
+	a := tss.NewPartyID("a", "A", big.NewInt(1))
+	b := tss.NewPartyID("b", "B", big.NewInt(2))
+	dup := tss.NewPartyID("c", "C", big.NewInt(1))
+
+	sorted, err := tss.SortPartyIDsE(tss.UnSortedPartyIDs{b, a})
+	if assert.NoError(t, err) {
+		assert.Equal(t, tss.SortedPartyIDs{a, b}, sorted)
+		_, err = tss.NewPeerContextE(sorted)
+		assert.NoError(t, err)
+	}
+	_, err = tss.SortPartyIDsE(tss.UnSortedPartyIDs{a, b, dup})
+	assert.Error(t, err, "parties with the same key must be rejected")
+	_, err = tss.NewPeerContextE(tss.SortedPartyIDs{a, b, dup})
+	assert.Error(t, err, "parties with the same key must be rejected")
+	assert.NotPanics(t, func() { tss.NewPeerContext(tss.SortPartyIDs(tss.UnSortedPartyIDs{a, b, dup})) })
+}
+
+func TestDeterministicPartyIDs(t *testing.T) {
+	seeds := make([]string, 1000)
+	for i := range seeds {
+		seeds[i] = fmt.Sprintf("party-%d", i)
+	}
+	pIDs, err := tss.NewDeterministicPartyIDs(seeds)
+	if !assert.NoError(t, err, "the keys should not collide") {
+		return
+	}
+	assert.Len(t, pIDs, len(seeds))
+	q := tss.EC().Params().N
+	for i, pID := range pIDs {
+		key := pID.KeyInt()
+		assert.Equal(t, i, pID.Index)
+		assert.Equal(t, 0, tss.DerivePartyKey(pID.Id).Cmp(key), "the key should be derived from the seed")
+		assert.True(t, 0 < key.Sign() && key.Cmp(q) < 0, "the key should be nonzero and below the curve order")
+		if 0 < i {
+			assert.True(t, pIDs[i-1].KeyInt().Cmp(key) < 0, "the parties should be sorted by key")
+		}
+	}
+	assert.Equal(t, tss.DerivePartyKey("a"), tss.DerivePartyKey("a"))
+	_, err = tss.NewDeterministicPartyIDs([]string{"a", "b", "a"})
+	assert.Error(t, err, "a seed given twice must be rejected")
+}
+
+func TestGenerateTestPartyIDsWithSeed(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDsWithSeed(50, 42)
+	assert.Len(t, pIDs, 50)
+	again := tss.GenerateTestPartyIDsWithSeed(50, 42)
+	other := tss.GenerateTestPartyIDsWithSeed(50, 43)
+	q := tss.EC().Params().N
+	for i, pID := range pIDs {
+		assert.Equal(t, i, pID.Index)
+		assert.Equal(t, pID.Key, again[i].Key, "the same seed should give the same keys")
+		assert.Equal(t, pID.Id, again[i].Id)
+		assert.NotEqual(t, pID.Key, other[i].Key)
+		assert.True(t, 0 < pID.KeyInt().Sign() && pID.KeyInt().Cmp(q) < 0)
+	}
+	assert.Equal(t, 5, tss.GenerateTestPartyIDsWithSeed(3, 42, 5)[0].Index)
+}
+
+func TestGenerateTestPartyIDsWithKeysSortRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	keys := make([]*big.Int, 100)
+	for i := range keys {
+		// large and sparse keys of different lengths
+		keys[i] = new(big.Int).Rand(rng, new(big.Int).Lsh(big.NewInt(1), uint(8+rng.Intn(248))))
+		keys[i].Add(keys[i], big.NewInt(1))
+	}
+	pIDs := tss.GenerateTestPartyIDsWithKeys(keys)
+	assert.Len(t, pIDs, len(keys))
+
+	sorted := append([]*big.Int{}, keys...)
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a].Cmp(sorted[b]) < 0 })
+	for i, pID := range pIDs {
+		assert.Equal(t, i, pID.Index)
+		assert.Equal(t, 0, sorted[i].Cmp(pID.KeyInt()))
+		// the id is the position of the key in the input
+		pos, err := strconv.Atoi(pID.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, keys[pos-1].Cmp(pID.KeyInt()))
+	}
+
+	// shuffling and sorting again gives the same order
+	shuffled := append(tss.UnSortedPartyIDs{}, pIDs...)
+	rng.Shuffle(len(shuffled), func(a, b int) { shuffled[a], shuffled[b] = shuffled[b], shuffled[a] })
+	resorted := tss.SortPartyIDs(shuffled)
+	for i, pID := range resorted {
+		assert.Equal(t, i, pID.Index)
+		assert.Equal(t, 0, sorted[i].Cmp(pID.KeyInt()))
+		assert.Equal(t, pID, resorted.FindByKey(sorted[i]))
+	}
+
+	assert.Panics(t, func() { tss.GenerateTestPartyIDsWithKeys([]*big.Int{keys[0], keys[1], keys[0]}) })
+}
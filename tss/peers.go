@@ -16,6 +16,14 @@ func NewPeerContext(parties SortedPartyIDs) *PeerContext {
 	return &PeerContext{partyIDs: parties}
 }
 
+// NewPeerContextE is NewPeerContext that returns an error if two of the parties have the same key
+func NewPeerContextE(parties SortedPartyIDs) (*PeerContext, error) {
+	if err := parties.ValidateDistinctKeys(); err != nil {
+		return nil, err
+	}
+	return NewPeerContext(parties), nil
+}
+
 func (p2pCtx *PeerContext) IDs() SortedPartyIDs {
 	return p2pCtx.partyIDs
 }
@@ -0,0 +1,206 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+)
+
+type (
+	// Checkpoint is the part of a party's checkpoint that all the protocols share: the run and round that it was taken
+	// in, the parties and the threshold of the session, and the messages that the party had stored. The protocols embed
+	// it in the state that they seal with SealSnapshot.
+	Checkpoint struct {
+		Run       []byte
+		Round     int
+		PartyKey  *big.Int
+		PartyKeys []*big.Int
+		Threshold int
+		OK        []bool
+
+		Messages []CheckpointMessage
+	}
+
+	// CheckpointMessage is a message stored by a party when it was checkpointed, in its wire format.
+	CheckpointMessage struct {
+		From        int
+		IsBroadcast bool
+		Payload     []byte
+	}
+)
+
+// CheckpointLedger keeps track of the checkpoints that have been resumed so that each is resumed at most once.
+// Two copies of a party resumed from the same state can be answered differently by a malicious party, and in signing
+// that leaks the secret share. Every run of a party has a random id that its checkpoints carry; resuming one takes
+// over the run, after which only a checkpoint of the run from a later round can be resumed.
+// Implementations must record the consumption durably before Consume returns, and a party that was checkpointed must
+// not keep running once it has been resumed elsewhere.
+type CheckpointLedger interface {
+	// Consume records that the checkpoint of `run` taken in `round` is resumed. It must fail, atomically with the
+	// record, when a checkpoint of the run from the same or a later round was consumed before.
+	Consume(run []byte, round int) error
+}
+
+type memoryCheckpointLedger struct {
+	mtx    sync.Mutex
+	rounds map[string]int
+}
+
+// NewMemoryCheckpointLedger returns a CheckpointLedger that keeps its record in memory. It only guards the checkpoints
+// resumed within the same process; a ledger that survives a restart must be backed by durable storage.
+func NewMemoryCheckpointLedger() CheckpointLedger {
+	return &memoryCheckpointLedger{rounds: make(map[string]int)}
+}
+
+func (ledger *memoryCheckpointLedger) Consume(run []byte, round int) error {
+	ledger.mtx.Lock()
+	defer ledger.mtx.Unlock()
+	if last, ok := ledger.rounds[string(run)]; ok && round <= last {
+		return fmt.Errorf("checkpoint was already resumed: its run was resumed in round %d", last)
+	}
+	ledger.rounds[string(run)] = round
+	return nil
+}
+
+// SealSnapshot encrypts and authenticates the `plain` state of a party with AES-GCM under `key`, which must be 32 bytes,
+// e.g. a hash of a secret that only the party holds. The result starts with the format `version`, which is
+// authenticated too, followed by a random nonce read from `rand`.
+func SealSnapshot(rand io.Reader, key []byte, version byte, plain []byte) ([]byte, error) {
+	aead, err := snapshotAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	header := []byte{version}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = io.ReadFull(rand, nonce); err != nil {
+		return nil, err
+	}
+	out := append(header, nonce...)
+	return aead.Seal(out, nonce, plain, header), nil
+}
+
+// OpenSnapshot returns the state sealed with SealSnapshot under `key`, failing when the state has another format
+// `version` or was sealed under another key.
+func OpenSnapshot(key []byte, version byte, state []byte) ([]byte, error) {
+	aead, err := snapshotAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(state) < 1+aead.NonceSize() || state[0] != version {
+		return nil, errors.New("checkpoint has an unsupported format")
+	}
+	header, nonce, sealed := state[:1], state[1:1+aead.NonceSize()], state[1+aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, sealed, header)
+	if err != nil {
+		return nil, errors.New("checkpoint could not be opened with the given key data")
+	}
+	return plain, nil
+}
+
+func snapshotAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, errors.New("the key of a checkpoint must be 32 bytes")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// NewCheckpoint captures the shared state of the party of `params` in its `run`, which is in `round` with `ok` set for
+// the parties heard from, and has stored the messages in `stored`.
+func NewCheckpoint(params *Parameters, run []byte, round int, ok []bool, stored ...[]ParsedMessage) (Checkpoint, error) {
+	cp := Checkpoint{
+		Run:       run,
+		Round:     round,
+		PartyKey:  params.PartyID().KeyInt(),
+		PartyKeys: params.Parties().IDs().Keys(),
+		Threshold: params.Threshold(),
+		OK:        ok,
+	}
+	for _, msgs := range stored {
+		for _, msg := range msgs {
+			if msg == nil {
+				continue
+			}
+			payload, _, err := msg.WireBytes()
+			if err != nil {
+				return Checkpoint{}, err
+			}
+			cp.Messages = append(cp.Messages, CheckpointMessage{
+				From:        msg.GetFrom().Index,
+				IsBroadcast: msg.IsBroadcast(),
+				Payload:     payload,
+			})
+		}
+	}
+	return cp, nil
+}
+
+// Check returns an error unless the checkpoint was taken in a round from `firstRound` to `lastRound` by the party of
+// `params`, with the same threshold and parties.
+func (cp *Checkpoint) Check(params *Parameters, firstRound, lastRound int) error {
+	partyCount := len(params.Parties().IDs())
+	if cp.Round < firstRound || lastRound < cp.Round {
+		return fmt.Errorf("checkpoint has an invalid round number %d", cp.Round)
+	}
+	if cp.PartyKey == nil || cp.PartyKey.Cmp(params.PartyID().KeyInt()) != 0 {
+		return errors.New("checkpoint was taken by a different party")
+	}
+	if cp.Threshold != params.Threshold() || len(cp.PartyKeys) != partyCount {
+		return errors.New("checkpoint was taken with different parameters")
+	}
+	for j, Pj := range params.Parties().IDs() {
+		if cp.PartyKeys[j] == nil || cp.PartyKeys[j].Cmp(Pj.KeyInt()) != 0 {
+			return errors.New("checkpoint was taken with a different set of parties")
+		}
+	}
+	if len(cp.OK) != partyCount {
+		return errors.New("checkpoint is inconsistent with the number of parties")
+	}
+	return nil
+}
+
+// Resume restores the messages of the checkpoint into `p` with `store`, records the resume in `ledger` and sets `round`
+// on `p`, which must not have been started. `round` must already be restored to its started state.
+func (cp *Checkpoint) Resume(p Party, task string, round Round, ledger CheckpointLedger, store func(ParsedMessage) (bool, *Error)) error {
+	Ps := round.Params().Parties().IDs()
+	restored := make([]ParsedMessage, 0, len(cp.Messages))
+	for _, m := range cp.Messages {
+		if m.From < 0 || len(Ps) <= m.From {
+			return fmt.Errorf("checkpoint has a message from an unknown party index %d", m.From)
+		}
+		msg, err := ParseWireMessage(m.Payload, Ps[m.From], m.IsBroadcast)
+		if err != nil {
+			return err
+		}
+		if ok, err := store(msg); !ok || err != nil {
+			return fmt.Errorf("checkpoint has an invalid message from party index %d", m.From)
+		}
+		restored = append(restored, msg)
+	}
+	if ledger == nil {
+		return errors.New("could not resume. a checkpoint ledger is required")
+	}
+	if len(cp.Run) == 0 {
+		return errors.New("could not resume. the checkpoint has no run id")
+	}
+	if err := ledger.Consume(cp.Run, cp.Round); err != nil {
+		return err
+	}
+	if err := BaseResume(p, task, round, cp.Run, restored...); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCheckpointLedger(t *testing.T) {
+	ledger := NewMemoryCheckpointLedger()
+	run, other := []byte("run"), []byte("other")
+
+	assert.NoError(t, ledger.Consume(run, 5))
+	assert.Error(t, ledger.Consume(run, 5), "the same round must not be resumed twice")
+	assert.Error(t, ledger.Consume(run, 4), "an earlier round must not be resumed")
+	assert.NoError(t, ledger.Consume(other, 5), "the runs are independent")
+	assert.NoError(t, ledger.Consume(run, 6), "a later round of the run can be resumed")
+	assert.Error(t, ledger.Consume(run, 6))
+}
@@ -0,0 +1,170 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Transport carries the messages of one party to and from its peers.
+//
+// The protocols rely on the transport for the following, and misbehave (hang or abort with the wrong culprit) when
+// it is not met:
+//   - Integrity: the wire bytes returned by Receive are exactly those of a message passed to Send by a peer.
+//   - Authentication: `from` is the party that really sent the message; it must be set by the transport from the
+//     authenticated channel, never read from the message itself.
+//   - Routing: a message reaches every party in its To list, or every other party when To is empty (a broadcast),
+//     and `isBroadcast` reports how it was sent. Broadcasts must be reliable: every party gets the same message.
+//   - Exactly-once: a message is delivered once per recipient; the parties only filter out replays with
+//     Parameters.EnableReplayProtection.
+//
+// Messages from one sender must be delivered to each recipient in the order they were sent. Messages of a future
+// round are stored by the party until it gets there, but in-order delivery keeps the stored state bounded.
+type Transport interface {
+	// Send delivers `msg` to its recipients. It must not block on a recipient that is not receiving.
+	Send(msg Message) error
+	// Receive blocks until a message is available for this party. It returns io.EOF once the transport is closed.
+	Receive() (wireBytes []byte, from *PartyID, isBroadcast bool, err error)
+}
+
+// ReceiveAndUpdate receives one message from `t` and passes it to the party through UpdateFromBytes, which is the
+// canonical ingestion point for messages coming from a transport.
+func ReceiveAndUpdate(p Party, t Transport) (bool, *Error) {
+	wireBytes, from, isBroadcast, err := t.Receive()
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.UpdateFromBytes(wireBytes, from, isBroadcast)
+}
+
+// ----- //
+
+type (
+	// MemoryTransport is a reference Transport between parties in the same process, intended for tests and examples.
+	// Each party's inbox is unbounded and FIFO, and the sender of a message is the party that owns the transport.
+	MemoryTransport struct {
+		self    *PartyID
+		network *memoryNetwork
+		inbox   *memoryInbox
+	}
+
+	memoryNetwork struct {
+		inboxes map[string]*memoryInbox
+	}
+
+	memoryInbox struct {
+		mtx    sync.Mutex
+		cond   *sync.Cond
+		queue  []memoryEnvelope
+		closed bool
+	}
+
+	memoryEnvelope struct {
+		wireBytes   []byte
+		from        *PartyID
+		isBroadcast bool
+	}
+)
+
+var _ Transport = (*MemoryTransport)(nil)
+
+// NewMemoryTransports returns a connected MemoryTransport for each of `parties`, in the same order.
+// The parties are identified by their keys, so the old and new committees of a resharing may be passed together.
+func NewMemoryTransports(parties ...*PartyID) ([]*MemoryTransport, error) {
+	network := &memoryNetwork{inboxes: make(map[string]*memoryInbox, len(parties))}
+	transports := make([]*MemoryTransport, len(parties))
+	for i, id := range parties {
+		if id == nil || !id.ValidateBasic() {
+			return nil, fmt.Errorf("NewMemoryTransports: invalid party at index %d", i)
+		}
+		if _, ok := network.inboxes[string(id.Key)]; ok {
+			return nil, fmt.Errorf("NewMemoryTransports: duplicate party %s", id)
+		}
+		inbox := newMemoryInbox()
+		network.inboxes[string(id.Key)] = inbox
+		transports[i] = &MemoryTransport{self: id, network: network, inbox: inbox}
+	}
+	return transports, nil
+}
+
+func (t *MemoryTransport) Send(msg Message) error {
+	if msg == nil || msg.GetFrom() == nil || string(msg.GetFrom().Key) != string(t.self.Key) {
+		return errors.New("MemoryTransport: a party may only send its own messages")
+	}
+	wireBytes, routing, err := msg.WireBytes()
+	if err != nil {
+		return err
+	}
+	envelope := memoryEnvelope{wireBytes: wireBytes, from: t.self, isBroadcast: routing.IsBroadcast}
+	if routing.To == nil {
+		for key, inbox := range t.network.inboxes {
+			if key != string(t.self.Key) {
+				inbox.push(envelope)
+			}
+		}
+		return nil
+	}
+	for _, to := range routing.To {
+		inbox, ok := t.network.inboxes[string(to.Key)]
+		if !ok {
+			return fmt.Errorf("MemoryTransport: unknown recipient %s", to)
+		}
+		inbox.push(envelope)
+	}
+	return nil
+}
+
+func (t *MemoryTransport) Receive() ([]byte, *PartyID, bool, error) {
+	envelope, ok := t.inbox.pop()
+	if !ok {
+		return nil, nil, false, io.EOF
+	}
+	return envelope.wireBytes, envelope.from, envelope.isBroadcast, nil
+}
+
+// Close makes Receive return io.EOF once the messages already queued for this party have been received.
+func (t *MemoryTransport) Close() {
+	t.inbox.close()
+}
+
+func newMemoryInbox() *memoryInbox {
+	inbox := new(memoryInbox)
+	inbox.cond = sync.NewCond(&inbox.mtx)
+	return inbox
+}
+
+func (inbox *memoryInbox) push(envelope memoryEnvelope) {
+	inbox.mtx.Lock()
+	defer inbox.mtx.Unlock()
+	inbox.queue = append(inbox.queue, envelope)
+	inbox.cond.Signal()
+}
+
+// pop blocks until an envelope is queued, and returns false once the inbox is closed and empty.
+func (inbox *memoryInbox) pop() (memoryEnvelope, bool) {
+	inbox.mtx.Lock()
+	defer inbox.mtx.Unlock()
+	for len(inbox.queue) == 0 && !inbox.closed {
+		inbox.cond.Wait()
+	}
+	if len(inbox.queue) == 0 {
+		return memoryEnvelope{}, false
+	}
+	envelope := inbox.queue[0]
+	inbox.queue = inbox.queue[1:]
+	return envelope, true
+}
+
+func (inbox *memoryInbox) close() {
+	inbox.mtx.Lock()
+	defer inbox.mtx.Unlock()
+	inbox.closed = true
+	inbox.cond.Broadcast()
+}
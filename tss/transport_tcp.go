@@ -0,0 +1,475 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	tcpMaxFrameLen = 1 << 26 // 64 MiB, well above the largest message of the protocols
+	tcpRunLen      = 16
+	tcpSeqLen      = 8
+
+	// a peer that cannot be reached is dialed again after a delay that doubles from the min to the max
+	tcpDialMinDelay = 100 * time.Millisecond
+	tcpDialMaxDelay = 10 * time.Second
+)
+
+type (
+	// TCPPeer is a remote party of a TCPTransport and the address it listens on.
+	TCPPeer struct {
+		ID   *PartyID
+		Addr string
+	}
+
+	// TCPTransport is a reference Transport over TCP, with one connection from each party to each of its peers.
+	// The connections use mutual TLS, and each side checks that the certificate of the other was issued to the party
+	// that it connects to, see PartyURI, so the messages are confidential and attributed to their actual sender.
+	// The messages to a peer are numbered and acknowledged. When a connection fails, the peer is dialed again with
+	// a backoff and the messages that it has not acknowledged are sent again; the receiver drops those it already has.
+	TCPTransport struct {
+		self     *PartyID
+		run      []byte // identifies this transport to its peers, which number its messages anew when it changes
+		listener net.Listener
+		dial     func(addr string) (net.Conn, error)
+		config   *tls.Config
+		peers    map[string]*tcpPeer // by party key
+		inbox    *memoryInbox
+		done     chan struct{}
+
+		mtx       sync.Mutex
+		accepted  map[string]net.Conn
+		closed    bool
+		closeOnce sync.Once
+	}
+
+	tcpPeer struct {
+		TCPPeer
+		outbox *memoryInbox
+
+		mtx sync.Mutex
+		// sending: the messages sent to the peer that it has not acknowledged yet, and the next sequence number
+		unacked []tcpFrame
+		nextSeq uint64
+		// receiving: the run of the peer's transport and the last sequence number received from it
+		run     []byte
+		lastSeq uint64
+	}
+
+	tcpFrame struct {
+		seq      uint64
+		envelope memoryEnvelope
+	}
+)
+
+var _ Transport = (*TCPTransport)(nil)
+
+// NewTCPTransport returns a TCPTransport for `self` that accepts its peers' connections on `listener`, a plain TCP
+// listener that the transport wraps with TLS, and dials them with net.Dial. Peers that are not listening yet are
+// dialed again until they are, or the transport is closed.
+//
+// `config` is required: its Certificates must hold the certificate of `self`, and its RootCAs the CAs that issue the
+// certificates of the parties. The certificate of each party must carry PartyURI of the party as a URI SAN, and be
+// valid for both server and client authentication.
+func NewTCPTransport(self *PartyID, listener net.Listener, peers []TCPPeer, config *tls.Config) (*TCPTransport, error) {
+	return NewTCPTransportWithDialer(self, listener, peers, config, func(addr string) (net.Conn, error) {
+		return net.Dial("tcp", addr)
+	})
+}
+
+// NewTCPTransportWithDialer is like NewTCPTransport, but connects to the peers with `dial`, over which it runs TLS.
+func NewTCPTransportWithDialer(
+	self *PartyID,
+	listener net.Listener,
+	peers []TCPPeer,
+	config *tls.Config,
+	dial func(addr string) (net.Conn, error),
+) (*TCPTransport, error) {
+	if self == nil || !self.ValidateBasic() {
+		return nil, errors.New("NewTCPTransport: invalid party")
+	}
+	if listener == nil || dial == nil {
+		return nil, errors.New("NewTCPTransport: the listener and the dialer are required")
+	}
+	if config == nil || len(config.Certificates) == 0 || config.RootCAs == nil {
+		return nil, errors.New("NewTCPTransport: a TLS config with the certificate of the party and the CAs of the parties is required")
+	}
+	config = config.Clone()
+	config.MinVersion = tls.VersionTLS13
+	// the certificates are checked against the parties instead of a host name, see verifyPartyCertificate
+	serverConfig := config.Clone()
+	serverConfig.ClientAuth = tls.RequireAnyClientCert
+	run := make([]byte, tcpRunLen)
+	if _, err := io.ReadFull(rand.Reader, run); err != nil {
+		return nil, err
+	}
+	t := &TCPTransport{
+		self:     self,
+		run:      run,
+		listener: tls.NewListener(listener, serverConfig),
+		dial:     dial,
+		config:   config,
+		peers:    make(map[string]*tcpPeer, len(peers)),
+		inbox:    newMemoryInbox(),
+		done:     make(chan struct{}),
+		accepted: make(map[string]net.Conn, len(peers)),
+	}
+	for i, peer := range peers {
+		if peer.ID == nil || !peer.ID.ValidateBasic() || peer.Addr == "" {
+			return nil, fmt.Errorf("NewTCPTransport: invalid peer at index %d", i)
+		}
+		key := string(peer.ID.Key)
+		if _, ok := t.peers[key]; ok || key == string(self.Key) {
+			return nil, fmt.Errorf("NewTCPTransport: duplicate party %s", peer.ID)
+		}
+		t.peers[key] = &tcpPeer{TCPPeer: peer, outbox: newMemoryInbox(), nextSeq: 1}
+	}
+	go t.acceptLoop()
+	for _, peer := range t.peers {
+		go t.writeLoop(peer)
+	}
+	return t, nil
+}
+
+// Send queues `msg` for its recipients. A recipient that is not connected gets it once it is reached again.
+func (t *TCPTransport) Send(msg Message) error {
+	if msg == nil || msg.GetFrom() == nil || string(msg.GetFrom().Key) != string(t.self.Key) {
+		return errors.New("TCPTransport: a party may only send its own messages")
+	}
+	wireBytes, routing, err := msg.WireBytes()
+	if err != nil {
+		return err
+	}
+	if len(wireBytes) > tcpMaxFrameLen-tcpSeqLen {
+		return fmt.Errorf("TCPTransport: the message is too large (%d bytes)", len(wireBytes))
+	}
+	var recipients []*tcpPeer
+	if routing.To == nil {
+		for _, peer := range t.peers {
+			recipients = append(recipients, peer)
+		}
+	} else {
+		for _, to := range routing.To {
+			peer, ok := t.peers[string(to.Key)]
+			if !ok {
+				return fmt.Errorf("TCPTransport: unknown recipient %s", to)
+			}
+			recipients = append(recipients, peer)
+		}
+	}
+	envelope := memoryEnvelope{wireBytes: wireBytes, isBroadcast: routing.IsBroadcast}
+	for _, peer := range recipients {
+		peer.outbox.push(envelope)
+	}
+	return nil
+}
+
+func (t *TCPTransport) Receive() ([]byte, *PartyID, bool, error) {
+	envelope, ok := t.inbox.pop()
+	if !ok {
+		return nil, nil, false, io.EOF
+	}
+	return envelope.wireBytes, envelope.from, envelope.isBroadcast, nil
+}
+
+// Close stops accepting connections and makes Receive return io.EOF once the messages already received have been
+// returned. The messages already passed to Send are still written to the peers that are connected.
+func (t *TCPTransport) Close() {
+	t.closeOnce.Do(func() {
+		t.mtx.Lock()
+		t.closed = true
+		close(t.done)
+		for _, conn := range t.accepted {
+			_ = conn.Close()
+		}
+		t.mtx.Unlock()
+		_ = t.listener.Close()
+		t.inbox.close()
+		for _, peer := range t.peers {
+			peer.outbox.close()
+		}
+	})
+}
+
+// wait sleeps for `d` and returns true, or returns false as soon as the transport is closed.
+func (t *TCPTransport) wait(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-t.done:
+		return false
+	}
+}
+
+func (t *TCPTransport) acceptLoop() {
+	for {
+		conn, err := t.listener.Accept()
+		if err != nil {
+			return
+		}
+		go t.readLoop(conn)
+	}
+}
+
+// readLoop receives the messages of the peer that dialed `conn` and acknowledges them, until it disconnects.
+func (t *TCPTransport) readLoop(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	_, hello, err := readTCPFrame(r)
+	if err != nil || len(hello) <= tcpRunLen {
+		return
+	}
+	run, key := hello[:tcpRunLen], string(hello[tcpRunLen:])
+	peer, ok := t.peers[key]
+	if !ok {
+		return
+	}
+	// the dialer must hold the certificate of the party that it claims to be
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if err := verifyPartyCertificate(certs, t.config.RootCAs, x509.ExtKeyUsageClientAuth, peer.ID); err != nil {
+		return
+	}
+	// the new connection of a peer replaces the one it had, e.g. when it reconnects before the old one timed out
+	t.mtx.Lock()
+	if t.closed {
+		t.mtx.Unlock()
+		return
+	}
+	if old, ok := t.accepted[key]; ok {
+		_ = old.Close()
+	}
+	t.accepted[key] = conn
+	t.mtx.Unlock()
+	defer func() {
+		t.mtx.Lock()
+		defer t.mtx.Unlock()
+		if t.accepted[key] == conn {
+			delete(t.accepted, key)
+		}
+	}()
+
+	ack := make([]byte, tcpSeqLen)
+	for {
+		isBroadcast, payload, err := readTCPFrame(r)
+		if err != nil || len(payload) < tcpSeqLen {
+			return
+		}
+		seq := binary.BigEndian.Uint64(payload[:tcpSeqLen])
+		peer.receive(run, seq, func() {
+			t.inbox.push(memoryEnvelope{wireBytes: payload[tcpSeqLen:], from: peer.ID, isBroadcast: isBroadcast})
+		})
+		binary.BigEndian.PutUint64(ack, seq)
+		if _, err = conn.Write(ack); err != nil {
+			return
+		}
+	}
+}
+
+// writeLoop connects to `peer` and writes its queued messages in order, until the transport is closed.
+// A peer that cannot be reached or whose connection fails is dialed again with an exponential backoff.
+func (t *TCPTransport) writeLoop(peer *tcpPeer) {
+	delay := tcpDialMinDelay
+	for {
+		conn, err := t.dialTLS(peer)
+		if err == nil {
+			var acked bool
+			acked, err = t.writeTo(conn, peer)
+			_ = conn.Close()
+			if err == nil {
+				return
+			}
+			if acked {
+				delay = tcpDialMinDelay
+			}
+		}
+		if !t.wait(delay) {
+			return
+		}
+		if delay *= 2; delay > tcpDialMaxDelay {
+			delay = tcpDialMaxDelay
+		}
+	}
+}
+
+// writeTo writes the messages to `peer` on `conn`, starting with those that it has not acknowledged, until the
+// outbox of the peer is closed and drained, which returns no error. It also reports whether the peer acknowledged
+// any message on `conn`.
+func (t *TCPTransport) writeTo(conn net.Conn, peer *tcpPeer) (bool, error) {
+	var acked int32
+	go func() {
+		r := bufio.NewReader(conn)
+		ack := make([]byte, tcpSeqLen)
+		for {
+			if _, err := io.ReadFull(r, ack); err != nil {
+				_ = conn.Close()
+				return
+			}
+			peer.ack(binary.BigEndian.Uint64(ack))
+			atomic.StoreInt32(&acked, 1)
+		}
+	}()
+	w := bufio.NewWriter(conn)
+	hello := append(append(make([]byte, 0, tcpRunLen+len(t.self.Key)), t.run...), t.self.Key...)
+	if err := writeTCPFrame(w, false, hello); err != nil {
+		return false, err
+	}
+	for _, frame := range peer.pending() {
+		if err := writeTCPDataFrame(w, frame); err != nil {
+			return atomic.LoadInt32(&acked) == 1, err
+		}
+	}
+	for {
+		envelope, ok := peer.outbox.pop()
+		if !ok {
+			return atomic.LoadInt32(&acked) == 1, nil
+		}
+		if err := writeTCPDataFrame(w, peer.track(envelope)); err != nil {
+			return atomic.LoadInt32(&acked) == 1, err
+		}
+	}
+}
+
+// dialTLS connects to `peer` over TLS, checking that it holds the certificate of the party.
+func (t *TCPTransport) dialTLS(peer *tcpPeer) (net.Conn, error) {
+	raw, err := t.dial(peer.Addr)
+	if err != nil {
+		return nil, err
+	}
+	config := t.config.Clone()
+	config.InsecureSkipVerify = true // verified by VerifyConnection against the party instead of a host name
+	config.VerifyConnection = func(state tls.ConnectionState) error {
+		return verifyPartyCertificate(state.PeerCertificates, t.config.RootCAs, x509.ExtKeyUsageServerAuth, peer.ID)
+	}
+	conn := tls.Client(raw, config)
+	if err = conn.Handshake(); err != nil {
+		_ = raw.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// PartyURI returns the URI that the TLS certificate of party `id` must carry as a subject alternative name,
+// "tss-party:" followed by the hex encoding of the party's key.
+func PartyURI(id *PartyID) *url.URL {
+	return &url.URL{Scheme: "tss-party", Opaque: hex.EncodeToString(id.Key)}
+}
+
+// verifyPartyCertificate checks that `certs` is a chain to one of `roots` for `usage` whose leaf was issued to `id`.
+func verifyPartyCertificate(certs []*x509.Certificate, roots *x509.CertPool, usage x509.ExtKeyUsage, id *PartyID) error {
+	if len(certs) == 0 {
+		return errors.New("TCPTransport: the peer has no certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	opts := x509.VerifyOptions{Roots: roots, Intermediates: intermediates, KeyUsages: []x509.ExtKeyUsage{usage}}
+	if _, err := certs[0].Verify(opts); err != nil {
+		return err
+	}
+	want := PartyURI(id).String()
+	for _, uri := range certs[0].URIs {
+		if uri.String() == want {
+			return nil
+		}
+	}
+	return fmt.Errorf("TCPTransport: the certificate of the peer was not issued to party %s", id)
+}
+
+// track numbers a message to the peer and keeps it until the peer acknowledges it.
+func (peer *tcpPeer) track(envelope memoryEnvelope) tcpFrame {
+	peer.mtx.Lock()
+	defer peer.mtx.Unlock()
+	frame := tcpFrame{seq: peer.nextSeq, envelope: envelope}
+	peer.nextSeq++
+	peer.unacked = append(peer.unacked, frame)
+	return frame
+}
+
+// pending returns the messages sent to the peer that it has not acknowledged, in order.
+func (peer *tcpPeer) pending() []tcpFrame {
+	peer.mtx.Lock()
+	defer peer.mtx.Unlock()
+	return append([]tcpFrame{}, peer.unacked...)
+}
+
+func (peer *tcpPeer) ack(seq uint64) {
+	peer.mtx.Lock()
+	defer peer.mtx.Unlock()
+	for len(peer.unacked) > 0 && peer.unacked[0].seq <= seq {
+		peer.unacked = peer.unacked[1:]
+	}
+}
+
+// receive calls `deliver` for the message `seq` from the `run` of the peer's transport unless it was received before.
+func (peer *tcpPeer) receive(run []byte, seq uint64, deliver func()) {
+	peer.mtx.Lock()
+	defer peer.mtx.Unlock()
+	if !bytes.Equal(peer.run, run) {
+		peer.run, peer.lastSeq = append([]byte{}, run...), 0
+	}
+	if seq <= peer.lastSeq {
+		return
+	}
+	peer.lastSeq = seq
+	deliver()
+}
+
+// writeTCPFrame writes the length of `payload`, the broadcast flag and `payload`, and flushes `w`.
+func writeTCPFrame(w *bufio.Writer, isBroadcast bool, payload []byte) error {
+	var header [5]byte
+	binary.BigEndian.PutUint32(header[:4], uint32(len(payload)))
+	if isBroadcast {
+		header[4] = 1
+	}
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write(payload); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func writeTCPDataFrame(w *bufio.Writer, frame tcpFrame) error {
+	payload := make([]byte, tcpSeqLen, tcpSeqLen+len(frame.envelope.wireBytes))
+	binary.BigEndian.PutUint64(payload, frame.seq)
+	return writeTCPFrame(w, frame.envelope.isBroadcast, append(payload, frame.envelope.wireBytes...))
+}
+
+func readTCPFrame(r *bufio.Reader) (isBroadcast bool, payload []byte, err error) {
+	var header [5]byte
+	if _, err = io.ReadFull(r, header[:]); err != nil {
+		return
+	}
+	n := binary.BigEndian.Uint32(header[:4])
+	if n > tcpMaxFrameLen || header[4] > 1 {
+		return false, nil, errors.New("TCPTransport: invalid frame")
+	}
+	payload = make([]byte, n)
+	if _, err = io.ReadFull(r, payload); err != nil {
+		return
+	}
+	return header[4] == 1, payload, nil
+}
@@ -0,0 +1,202 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss_test
+
+import (
+	"crypto/tls"
+	"encoding/binary"
+	"io"
+	"math/big"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func TestMemoryTransport(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(3)
+	transports, err := tss.NewMemoryTransports(pIDs...)
+	assert.NoError(t, err)
+
+	// a broadcast reaches every other party, attributed to the sender
+	msg := keygen.NewKGRound1Message(pIDs[0], commitments.HashCommitment(pIDs[0].KeyInt()))
+	assert.NoError(t, transports[0].Send(msg))
+	for _, transport := range transports[1:] {
+		wire, from, isBroadcast, err := transport.Receive()
+		assert.NoError(t, err)
+		assert.Equal(t, pIDs[0], from)
+		assert.True(t, isBroadcast)
+		parsed, err := tss.ParseWireMessage(wire, from, isBroadcast)
+		assert.NoError(t, err)
+		assert.True(t, parsed.ValidateBasic())
+	}
+
+	// a party may not send on behalf of another
+	assert.Error(t, transports[1].Send(msg))
+
+	// closing drains the inbox before returning io.EOF
+	assert.NoError(t, transports[0].Send(msg))
+	transports[1].Close()
+	_, _, _, err = transports[1].Receive()
+	assert.NoError(t, err)
+	_, _, _, err = transports[1].Receive()
+	assert.Equal(t, io.EOF, err)
+
+	// duplicate parties are rejected
+	_, err = tss.NewMemoryTransports(pIDs[0], pIDs[0])
+	assert.Error(t, err)
+}
+
+func TestTCPTransport(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(3)
+	configs, err := test.TLSConfigs(pIDs)
+	assert.NoError(t, err)
+	listeners := make([]net.Listener, len(pIDs))
+	for i := range pIDs {
+		var err error
+		listeners[i], err = net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+	}
+	transports := make([]*tss.TCPTransport, len(pIDs))
+	for i := range pIDs {
+		var peers []tss.TCPPeer
+		for j, pID := range pIDs {
+			if j != i {
+				peers = append(peers, tss.TCPPeer{ID: pID, Addr: listeners[j].Addr().String()})
+			}
+		}
+		var err error
+		transports[i], err = tss.NewTCPTransport(pIDs[i], listeners[i], peers, configs[i])
+		assert.NoError(t, err)
+	}
+	defer func() {
+		for _, transport := range transports {
+			transport.Close()
+		}
+	}()
+
+	// a party that connects with the certificate of another party is rejected
+	impostor, err := tls.Dial("tcp", listeners[1].Addr().String(), &tls.Config{
+		Certificates:       configs[2].Certificates,
+		InsecureSkipVerify: true,
+	})
+	if assert.NoError(t, err) {
+		forged := keygen.NewKGRound1Message(pIDs[0], commitments.HashCommitment(big.NewInt(1)))
+		forgedWire, _, err := forged.WireBytes()
+		assert.NoError(t, err)
+		for _, frame := range [][]byte{pIDs[0].Key, forgedWire} {
+			header := make([]byte, 5)
+			binary.BigEndian.PutUint32(header, uint32(len(frame)))
+			header[4] = 1
+			_, _ = impostor.Write(append(header, frame...))
+		}
+		_, err = impostor.Read(make([]byte, 1))
+		assert.Error(t, err, "the connection of the impostor should be closed")
+		_ = impostor.Close()
+	}
+
+	// a broadcast reaches every other party, attributed to the sender
+	msg := keygen.NewKGRound1Message(pIDs[0], commitments.HashCommitment(pIDs[0].KeyInt()))
+	msgWire, _, err := msg.WireBytes()
+	assert.NoError(t, err)
+	assert.NoError(t, transports[0].Send(msg))
+	for _, transport := range transports[1:] {
+		wire, from, isBroadcast, err := transport.Receive()
+		assert.NoError(t, err)
+		assert.Equal(t, msgWire, wire)
+		assert.Equal(t, pIDs[0].Key, from.Key)
+		assert.True(t, isBroadcast)
+		parsed, err := tss.ParseWireMessage(wire, from, isBroadcast)
+		assert.NoError(t, err)
+		assert.True(t, parsed.ValidateBasic())
+	}
+
+	// a party may not send on behalf of another, nor to a party that is not its peer
+	assert.Error(t, transports[1].Send(msg))
+	stranger := tss.GenerateTestPartyIDs(4)[3]
+	assert.Error(t, transports[0].Send(keygen.NewKGRound2Message1(stranger, pIDs[0], &vss.Share{Share: big.NewInt(1)})))
+
+	// closing drains the received messages before returning io.EOF
+	assert.NoError(t, transports[0].Send(msg))
+	wire, _, _, err := transports[2].Receive()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, wire)
+	transports[2].Close()
+	_, _, _, err = transports[2].Receive()
+	assert.Equal(t, io.EOF, err)
+
+	// duplicate parties are rejected, and so is a transport without TLS
+	_, err = tss.NewTCPTransport(pIDs[0], listeners[0], []tss.TCPPeer{{ID: pIDs[0], Addr: "127.0.0.1:1"}}, configs[0])
+	assert.Error(t, err)
+	_, err = tss.NewTCPTransport(pIDs[0], listeners[0], []tss.TCPPeer{{ID: pIDs[1], Addr: "127.0.0.1:1"}}, nil)
+	assert.Error(t, err)
+}
+
+func TestTCPTransportReconnect(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(2)
+	configs, err := test.TLSConfigs(pIDs)
+	assert.NoError(t, err)
+	listeners := make([]net.Listener, len(pIDs))
+	for i := range pIDs {
+		listeners[i], err = net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+	}
+	// the connections dialed by party 0 are kept so that the test can break them
+	dialed := make(chan net.Conn, 10)
+	t0, err := tss.NewTCPTransportWithDialer(pIDs[0], listeners[0], []tss.TCPPeer{{ID: pIDs[1], Addr: listeners[1].Addr().String()}},
+		configs[0], func(addr string) (net.Conn, error) {
+			conn, err := net.Dial("tcp", addr)
+			if err == nil {
+				dialed <- conn
+			}
+			return conn, err
+		})
+	assert.NoError(t, err)
+	defer t0.Close()
+	t1, err := tss.NewTCPTransport(pIDs[1], listeners[1], []tss.TCPPeer{{ID: pIDs[0], Addr: listeners[0].Addr().String()}}, configs[1])
+	assert.NoError(t, err)
+	defer t1.Close()
+
+	send := func(n int64) []byte {
+		msg := keygen.NewKGRound1Message(pIDs[0], commitments.HashCommitment(big.NewInt(n)))
+		wire, _, err := msg.WireBytes()
+		assert.NoError(t, err)
+		assert.NoError(t, t0.Send(msg))
+		return wire
+	}
+	receive := func() []byte {
+		wire, from, _, err := t1.Receive()
+		assert.NoError(t, err)
+		assert.Equal(t, pIDs[0].Key, from.Key)
+		return wire
+	}
+
+	assert.Equal(t, send(1), receive())
+	// the broken connection is dialed again, and every message is received once, in order
+	(<-dialed).Close()
+	assert.Equal(t, send(2), receive())
+	assert.Equal(t, send(3), receive())
+	(<-dialed).Close()
+	want4, want5 := send(4), send(5)
+	assert.Equal(t, want4, receive())
+	assert.Equal(t, want5, receive())
+}
+
+func TestParseWireMessageRequiresSender(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(2)
+	msg := keygen.NewKGRound1Message(pIDs[0], commitments.HashCommitment(pIDs[0].KeyInt()))
+	wire, _, err := msg.WireBytes()
+	assert.NoError(t, err)
+	_, err = tss.ParseWireMessage(wire, nil, true)
+	assert.Error(t, err)
+}
@@ -8,12 +8,17 @@ package tss
 
 import (
 	"errors"
+
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
 // Used externally to update a LocalParty with a valid ParsedMessage
 func ParseWireMessage(wireBytes []byte, from *PartyID, isBroadcast bool) (ParsedMessage, error) {
+	// the sender must be attributed by the transport, see Transport
+	if from == nil || from.MessageWrapper_PartyID == nil {
+		return nil, errors.New("ParseWireMessage: the sender of the message is required")
+	}
 	wire := new(MessageWrapper)
 	wire.Message = new(anypb.Any)
 	wire.From = from.MessageWrapper_PartyID